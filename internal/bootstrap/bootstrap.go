@@ -22,6 +22,7 @@ import (
 	"github.com/ActiveMemory/ctx/internal/cli/agent"
 	"github.com/ActiveMemory/ctx/internal/cli/compact"
 	"github.com/ActiveMemory/ctx/internal/cli/complete"
+	"github.com/ActiveMemory/ctx/internal/cli/consolidate"
 	"github.com/ActiveMemory/ctx/internal/cli/decision"
 	"github.com/ActiveMemory/ctx/internal/cli/drift"
 	"github.com/ActiveMemory/ctx/internal/cli/hook"
@@ -64,6 +65,7 @@ func Initialize(cmd *cobra.Command) *cobra.Command {
 		drift.Cmd,
 		sync.Cmd,
 		compact.Cmd,
+		consolidate.Cmd,
 		decision.Cmd,
 		watch.Cmd,
 		hook.Cmd,
@@ -8,6 +8,8 @@ package add
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
 )
 
 // Cmd returns the "ctx add" command for appending entries to context files.
@@ -23,8 +25,11 @@ import (
 //   - --context, -c: Context for decisions/learnings (required)
 //   - --rationale, -r: Rationale for decisions (required for decisions)
 //   - --consequences: Consequences for decisions (required for decisions)
+//   - --status: Status for decisions (proposed, accepted, ...; default accepted)
 //   - --lesson, -l: Lesson for learnings (required for learnings)
 //   - --application, -a: Application for learnings (required for learnings)
+//   - --force: Add a decision/learning even if it looks like a duplicate
+//   - --append-to: Fold a decision/learning into the entry with this timestamp
 //
 // Returns:
 //   - *cobra.Command: Configured add command with flags registered
@@ -36,8 +41,11 @@ func Cmd() *cobra.Command {
 		context      string
 		rationale    string
 		consequences string
+		status       string
 		lesson       string
 		application  string
+		force        bool
+		appendTo     string
 	)
 
 	cmd := &cobra.Command{
@@ -52,6 +60,10 @@ Types:
   task        Add to TASKS.md
   convention  Add to CONVENTIONS.md
 
+Decisions and learnings that closely resemble an existing entry are
+refused (see duplicate_threshold in .ctxrc). Add them anyway with
+--force, or fold them into the existing entry with --append-to.
+
 Content can be provided as:
   - Command argument: ctx add learning "title here"
   - File: ctx add learning --file /path/to/content.md
@@ -62,10 +74,14 @@ Examples:
     --context "Need a reliable database for production" \
     --rationale "PostgreSQL offers ACID compliance and JSON support" \
     --consequences "Team needs PostgreSQL training"
+  ctx add decision "Split the API gateway" --status proposed \
+    --context "..." --rationale "..." --consequences "..."
   ctx add learning "Go embed requires files in same package" \
     --context "Tried to embed files from parent directory" \
     --lesson "go:embed only works with files in same or child directories" \
     --application "Keep embedded files in internal/templates/, not project root"
+  ctx add learning "gofmt before commit" --append-to 2026-01-28-143022 \
+    --context "..." --lesson "..." --application "..."
   ctx add task "Implement user authentication" --priority high`,
 		Args:      cobra.MinimumNArgs(1),
 		ValidArgs: []string{"task", "decision", "learning", "convention"},
@@ -77,8 +93,11 @@ Examples:
 				context:      context,
 				rationale:    rationale,
 				consequences: consequences,
+				status:       status,
 				lesson:       lesson,
 				application:  application,
+				force:        force,
+				appendTo:     appendTo,
 			})
 		},
 	}
@@ -89,7 +108,7 @@ Examples:
 		"Priority level for tasks (high, medium, low)",
 	)
 	_ = cmd.RegisterFlagCompletionFunc("priority", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{"high", "medium", "low"}, cobra.ShellCompDirectiveNoFileComp
+		return config.TaskPriorities, cobra.ShellCompDirectiveNoFileComp
 	})
 	cmd.Flags().StringVarP(
 		&section,
@@ -116,6 +135,14 @@ Examples:
 		"consequences", "",
 		"Consequences for decisions: what changes as a result (required for decisions)",
 	)
+	cmd.Flags().StringVar(
+		&status,
+		"status", "",
+		"Status for decisions: proposed, accepted, deprecated or superseded (default accepted)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return config.DecisionStatuses, cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().StringVarP(
 		&lesson,
 		"lesson", "l", "",
@@ -126,6 +153,16 @@ Examples:
 		"application", "a", "",
 		"Application for learnings: how to apply this going forward (required for learnings)",
 	)
+	cmd.Flags().BoolVar(
+		&force,
+		"force", false,
+		"Add a decision or learning even if it looks like a duplicate",
+	)
+	cmd.Flags().StringVar(
+		&appendTo,
+		"append-to", "",
+		"Fold a decision or learning into the existing entry with this timestamp",
+	)
 
 	return cmd
 }
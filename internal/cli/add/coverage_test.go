@@ -157,7 +157,7 @@ func TestExamplesForType(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestFormatTaskWithPriority(t *testing.T) {
-	result := FormatTask("My task", "high")
+	result := FormatTask("My task", "high", "k3f9")
 	if !strings.Contains(result, "#priority:high") {
 		t.Errorf("FormatTask with priority should contain '#priority:high', got: %s", result)
 	}
@@ -167,10 +167,13 @@ func TestFormatTaskWithPriority(t *testing.T) {
 	if !strings.Contains(result, "#added:") {
 		t.Errorf("FormatTask should contain '#added:' timestamp, got: %s", result)
 	}
+	if !strings.Contains(result, "#id:k3f9") {
+		t.Errorf("FormatTask should contain '#id:k3f9', got: %s", result)
+	}
 }
 
 func TestFormatTaskWithoutPriority(t *testing.T) {
-	result := FormatTask("Simple task", "")
+	result := FormatTask("Simple task", "", "k3f9")
 	if strings.Contains(result, "#priority:") {
 		t.Errorf("FormatTask without priority should not contain '#priority:', got: %s", result)
 	}
@@ -679,6 +682,56 @@ func TestRunAdd_TaskWithPriority(t *testing.T) {
 	if !strings.Contains(string(content), "#priority:high") {
 		t.Error("task with priority should contain '#priority:high'")
 	}
+	if !strings.Contains(string(content), "#id:") {
+		t.Error("added task should get an '#id:' tag")
+	}
+}
+
+func TestNewTaskID_AvoidsTakenIDs(t *testing.T) {
+	var existing strings.Builder
+	existing.WriteString("# Tasks\n\n")
+	for i := 0; i < 200; i++ {
+		existing.WriteString(FormatTask("Task", "", newTaskID(existing.String(), "Task")))
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(existing.String(), "\n") {
+		if _, id, ok := strings.Cut(line, "#id:"); ok {
+			if seen[id] {
+				t.Fatalf("duplicate task ID %q", id)
+			}
+			seen[id] = true
+		}
+	}
+	if len(seen) != 200 {
+		t.Errorf("got %d IDs, want 200", len(seen))
+	}
+}
+
+func TestTaskID(t *testing.T) {
+	existing := "# Tasks\n\n- [ ] Old #id:k3f9\n"
+
+	content, id, err := taskID(existing, "Cyc A #id:AAAA #depends:bbbb")
+	if err != nil || id != "aaaa" || content != "Cyc A #depends:bbbb" {
+		t.Errorf("taskID() = %q, %q, %v", content, id, err)
+	}
+	line := FormatTask(content, "", id)
+	if strings.Count(line, "#id:") != 1 || !strings.HasSuffix(line, "#id:aaaa\n") {
+		t.Errorf("FormatTask() = %q, want one #id:aaaa", line)
+	}
+
+	if content, id, err = taskID(existing, "Plain task"); err != nil ||
+		content != "Plain task" || id == "" || id == "k3f9" {
+		t.Errorf("taskID() = %q, %q, %v; want a new ID", content, id, err)
+	}
+
+	for _, bad := range []string{
+		"Dup #id:k3f9", "Two #id:aaaa #id:cccc", "Number #id:1", "Odd #id:bad/Id!",
+	} {
+		if _, _, err = taskID(existing, bad); err == nil {
+			t.Errorf("taskID(%q) should fail", bad)
+		}
+	}
 }
 
 // ---------------------------------------------------------------------------
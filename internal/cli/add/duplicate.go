@@ -0,0 +1,237 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
+	"github.com/ActiveMemory/ctx/internal/search"
+)
+
+// FindDuplicates returns the existing entries a new decision or
+// learning resembles.
+//
+// Entries are compared by the Jaccard similarity of their shingles
+// (see search.Shingles), over the title and all field values.
+// Superseded decisions are skipped: revisiting a replaced decision is
+// expected.
+//
+// Parameters:
+//   - params: The entry about to be added
+//   - threshold: Minimum similarity, from 0 to 1
+//
+// Returns:
+//   - []Duplicate: Up to config.MaxDuplicateMatches entries, most
+//     similar first; nil for other entry types or a missing file
+//   - error: Non-nil if the context file cannot be read
+func FindDuplicates(params EntryParams, threshold float64) ([]Duplicate, error) {
+	var entry string
+	switch config.UserInputToEntry(params.Type) {
+	case config.EntryDecision:
+		entry = FormatDecision(
+			params.Content, params.Status, params.Context, params.Rationale,
+			params.Consequences,
+		)
+	case config.EntryLearning:
+		entry = FormatLearning(
+			params.Content, params.Context, params.Lesson, params.Application,
+		)
+	default:
+		return nil, nil
+	}
+
+	filePath := filepath.Join(
+		rc.ContextDir(), config.FileType[config.UserInputToEntry(params.Type)],
+	)
+	content, readErr := os.ReadFile(filepath.Clean(filePath))
+	if os.IsNotExist(readErr) {
+		return nil, nil
+	}
+	if readErr != nil {
+		return nil, errFileRead(filePath, readErr)
+	}
+
+	candidate := index.ParseEntryBlocks(entry)
+	if len(candidate) == 0 {
+		return nil, nil
+	}
+	shingles := search.Shingles(candidate[0].Text())
+
+	var dups []Duplicate
+	for _, eb := range index.ParseEntryBlocks(string(content)) {
+		if eb.IsSuperseded() {
+			continue
+		}
+		score := search.Jaccard(shingles, search.Shingles(eb.Text()))
+		if score >= threshold {
+			dups = append(dups, Duplicate{Entry: eb, Score: score})
+		}
+	}
+	slices.SortStableFunc(dups, func(a, b Duplicate) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if len(dups) > config.MaxDuplicateMatches {
+		dups = dups[:config.MaxDuplicateMatches]
+	}
+	return dups, nil
+}
+
+// CheckDuplicates refuses a decision or learning that resembles an
+// existing entry.
+//
+// The threshold is duplicate_threshold in .ctxrc; zero disables the
+// check.
+//
+// Parameters:
+//   - params: The entry about to be added
+//
+// Returns:
+//   - error: Non-nil listing the closest entries if the new one looks
+//     like a duplicate, or if the context file cannot be read
+func CheckDuplicates(params EntryParams) error {
+	threshold := rc.DuplicateThreshold()
+	if threshold <= 0 {
+		return nil
+	}
+	dups, err := FindDuplicates(params, threshold)
+	if err != nil {
+		return err
+	}
+	if len(dups) > 0 {
+		return errDuplicate(config.UserInputToEntry(params.Type), dups)
+	}
+	return nil
+}
+
+// AppendToEntry folds a new decision or learning into an existing one.
+//
+// The new entry becomes a dated "**Update**:" addendum at the end of
+// the existing entry, so the entry keeps its timestamp and its place in
+// the index.
+//
+// Parameters:
+//   - params: The entry to fold in
+//   - id: Timestamp, or unique timestamp prefix, of the existing entry
+//
+// Returns:
+//   - index.EntryBlock: The entry that was extended
+//   - error: Non-nil if the type has no entries, id matches no entry or
+//     several, or file operations fail
+func AppendToEntry(params EntryParams, id string) (index.EntryBlock, error) {
+	var fields [][2]string
+	switch config.UserInputToEntry(params.Type) {
+	case config.EntryDecision:
+		fields = [][2]string{
+			{"Context", params.Context},
+			{"Rationale", params.Rationale},
+			{"Consequences", params.Consequences},
+		}
+	case config.EntryLearning:
+		fields = [][2]string{
+			{"Context", params.Context},
+			{"Lesson", params.Lesson},
+			{"Application", params.Application},
+		}
+	default:
+		return index.EntryBlock{}, errAppendType(params.Type)
+	}
+
+	filePath := filepath.Join(
+		rc.ContextDir(), config.FileType[config.UserInputToEntry(params.Type)],
+	)
+	content, readErr := os.ReadFile(filepath.Clean(filePath))
+	if os.IsNotExist(readErr) {
+		return index.EntryBlock{}, errFileNotFound(filePath)
+	}
+	if readErr != nil {
+		return index.EntryBlock{}, errFileRead(filePath, readErr)
+	}
+
+	target, findErr := FindEntry(string(content), id)
+	if findErr != nil {
+		return index.EntryBlock{}, findErr
+	}
+
+	addendum := []string{""}
+	addendum = append(addendum, strings.TrimSuffix(fmt.Sprintf(
+		config.TplEntryUpdate, time.Now().Format("2006-01-02"), params.Content,
+	), config.NewlineLF))
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		if len(addendum) == 2 {
+			addendum = append(addendum, "")
+		}
+		addendum = append(addendum, strings.TrimSuffix(
+			fmt.Sprintf(config.TplEntryUpdateField, f[0], f[1]), config.NewlineLF,
+		))
+	}
+
+	lines := strings.Split(string(content), config.NewlineLF)
+	lines = slices.Insert(lines, target.ContentEnd(), addendum...)
+	updated := strings.Join(lines, config.NewlineLF)
+	if writeErr := os.WriteFile(
+		filePath, []byte(updated), config.PermFile,
+	); writeErr != nil {
+		return index.EntryBlock{}, errFileWrite(filePath, writeErr)
+	}
+	return target, nil
+}
+
+// FindEntry finds the entry a timestamp or timestamp prefix refers to.
+//
+// Entries written in the same second share a timestamp; they are told
+// apart by timestamp and title ("[2026-01-28-143022] Title").
+//
+// Parameters:
+//   - content: Content of DECISIONS.md or LEARNINGS.md
+//   - id: Timestamp or timestamp prefix, with or without brackets, or
+//     timestamp and title
+//
+// Returns:
+//   - index.EntryBlock: The single matching entry
+//   - error: Non-nil if no entry or more than one matches
+func FindEntry(content, id string) (index.EntryBlock, error) {
+	blocks := index.ParseEntryBlocks(content)
+	var matches []index.EntryBlock
+	for _, eb := range blocks {
+		if eb.IsRef(id) {
+			matches = append(matches, eb)
+		}
+	}
+
+	id = strings.Trim(strings.TrimSpace(id), "[]")
+	if len(matches) == 0 {
+		for _, eb := range blocks {
+			if id != "" && strings.HasPrefix(eb.Entry.Timestamp, id) {
+				matches = append(matches, eb)
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return index.EntryBlock{}, errNoEntry(id)
+	case 1:
+		return matches[0], nil
+	}
+	return index.EntryBlock{}, errAmbiguousEntry(id, matches)
+}
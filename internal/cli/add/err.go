@@ -9,6 +9,9 @@ package add
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
 )
 
 // errNoContent returns a simple error when no content source is available.
@@ -154,6 +157,20 @@ func errUnknownType(fType string) error {
 	)
 }
 
+// errUnknownStatus returns an error for an unrecognized decision status.
+//
+// Parameters:
+//   - status: The unrecognized status string
+//
+// Returns:
+//   - error: Formatted error listing valid statuses
+func errUnknownStatus(status string) error {
+	return fmt.Errorf(
+		"unknown decision status %q. Valid statuses: %s",
+		status, strings.Join(config.DecisionStatuses, ", "),
+	)
+}
+
 // errFileNotFound returns an error when a context file does not exist.
 //
 // Parameters:
@@ -180,3 +197,130 @@ func errMissingFields(entryType string, missing []string) error {
 		"%s missing required fields: %s", entryType, strings.Join(missing, ", "),
 	)
 }
+
+// errDuplicate returns an error listing the entries a new one resembles.
+//
+// Parameters:
+//   - entryType: The entry type (e.g., "decision", "learning")
+//   - dups: Similar entries, most similar first
+//
+// Returns:
+//   - error: Formatted error naming --force and --append-to
+func errDuplicate(entryType string, dups []Duplicate) error {
+	var sb strings.Builder
+	for _, d := range dups {
+		sb.WriteString(fmt.Sprintf(
+			"  [%s] %s (%.0f%% similar)\n",
+			d.Entry.Entry.Timestamp, d.Entry.Entry.Title, d.Score*100,
+		))
+	}
+	return fmt.Errorf(`%s looks like a duplicate of:
+%sUse --force to add it anyway, or --append-to %s to fold it in`, entryType, sb.String(), dups[0].Entry.Entry.Timestamp)
+}
+
+// errAppendType returns an error for --append-to on a type without
+// entries.
+//
+// Parameters:
+//   - fType: The entry type
+//
+// Returns:
+//   - error: Formatted error naming the supported types
+func errAppendType(fType string) error {
+	return fmt.Errorf(
+		"--append-to works with decisions and learnings, not %q", fType,
+	)
+}
+
+// errNoEntry returns an error when no entry has the given timestamp.
+//
+// Parameters:
+//   - id: The timestamp or prefix that was looked up
+//
+// Returns:
+//   - error: Formatted error suggesting where to find timestamps
+func errNoEntry(id string) error {
+	return fmt.Errorf(
+		"no entry matches %q; use the timestamp from its heading "+
+			"(e.g., 2026-01-28-143022)", id,
+	)
+}
+
+// errAmbiguousEntry returns an error when a timestamp prefix matches
+// several entries.
+//
+// Parameters:
+//   - id: The timestamp prefix
+//   - matches: The matching entries
+//
+// Returns:
+//   - error: Formatted error listing the entries by timestamp and title
+func errAmbiguousEntry(id string, matches []index.EntryBlock) error {
+	var sb strings.Builder
+	for _, eb := range matches {
+		sb.WriteString(fmt.Sprintf("\n  %s", eb.Ref()))
+	}
+	return fmt.Errorf(
+		"%q matches %d entries; use more of the timestamp, "+
+			"or the timestamp and title, quoted:%s", id, len(matches), sb.String(),
+	)
+}
+
+// errSecret returns an error for an entry that contains credentials.
+//
+// Parameters:
+//   - found: One description per finding, prefixed with the field
+//   - allowPath: Path of the secrets allowlist
+//
+// Returns:
+//   - error: Formatted error listing the findings and the allowlist
+func errSecret(found []string, allowPath string) error {
+	var sb strings.Builder
+	for _, f := range found {
+		sb.WriteString(fmt.Sprintf("  %s\n", f))
+	}
+	return fmt.Errorf(`refusing to add an entry that contains secrets:
+%sRemove them, or list a pattern in %s if they are not secrets`, sb.String(), allowPath)
+}
+
+// errTaskIDTaken returns an error for a task ID that is already in use.
+//
+// Parameters:
+//   - id: The task ID
+//
+// Returns:
+//   - error: Formatted error suggesting to drop the tag
+func errTaskIDTaken(id string) error {
+	return fmt.Errorf(
+		"task ID %q is already used in TASKS.md; "+
+			"pick another or leave out #id: to get a new one", id,
+	)
+}
+
+// errTaskIDInvalid returns an error for a task ID that is not made of
+// lowercase letters and digits, or has no letter.
+//
+// Parameters:
+//   - id: The task ID
+//
+// Returns:
+//   - error: Formatted error explaining the ID format
+func errTaskIDInvalid(id string) error {
+	return fmt.Errorf(
+		"invalid task ID %q: use letters and digits, with at least one "+
+			"letter so it is not taken for a task number", id,
+	)
+}
+
+// errTaskIDs returns an error for a task description with several IDs.
+//
+// Parameters:
+//   - ids: The IDs found
+//
+// Returns:
+//   - error: Formatted error listing the IDs
+func errTaskIDs(ids []string) error {
+	return fmt.Errorf(
+		"a task can have only one #id:, got %s", strings.Join(ids, ", "),
+	)
+}
@@ -11,27 +11,30 @@ import (
 	"time"
 
 	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
 )
 
 // FormatTask formats a task entry as a Markdown checkbox item.
 //
-// The output includes a timestamp tag for session correlation and an optional
-// priority tag. Format: "- [ ] content #priority:level #added:YYYY-MM-DD-HHMMSS"
+// The output includes a timestamp tag for session correlation, an optional
+// priority tag and the task's stable ID.
+// Format: "- [ ] content #priority:level #added:YYYY-MM-DD-HHMMSS #id:xxxx"
 //
 // Parameters:
 //   - content: Task description text
 //   - priority: Priority level (high, medium, low); empty string omits the tag
+//   - id: Task ID (see task.NewID)
 //
 // Returns:
 //   - string: Formatted task line with trailing newline
-func FormatTask(content string, priority string) string {
+func FormatTask(content, priority, id string) string {
 	// Use YYYY-MM-DD-HHMMSS timestamp for session correlation
 	timestamp := time.Now().Format("2006-01-02-150405")
 	var priorityTag string
 	if priority != "" {
 		priorityTag = fmt.Sprintf(config.TplTaskPriority, priority)
 	}
-	return fmt.Sprintf(config.TplTask, content, priorityTag, timestamp)
+	return fmt.Sprintf(config.TplTask, content, priorityTag, timestamp, id)
 }
 
 // FormatLearning formats a learning entry as a structured Markdown section.
@@ -74,16 +77,23 @@ func FormatConvention(content string) string {
 //
 // Parameters:
 //   - title: Decision title/summary text
+//   - status: Decision status (see config.DecisionStatuses); empty means
+//     accepted
 //   - context: What prompted this decision
 //   - rationale: Why this choice over alternatives
 //   - consequences: What changes as a result
 //
 // Returns:
 //   - string: Formatted decision section with all ADR fields
-func FormatDecision(title, context, rationale, consequences string) string {
+func FormatDecision(
+	title, status, context, rationale, consequences string,
+) string {
 	timestamp := time.Now().Format("2006-01-02-150405")
+	if status == "" {
+		status = config.DecisionStatusAccepted
+	}
 	return fmt.Sprintf(
 		config.TplDecision,
-		timestamp, title, context, title, rationale, consequences,
+		timestamp, title, index.StatusLabel(status), context, title, rationale, consequences,
 	)
 }
@@ -0,0 +1,78 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// newTaskID picks an ID for a new task that no task in TASKS.md uses.
+//
+// Parameters:
+//   - existing: Current TASKS.md content
+//   - content: New task description
+//
+// Returns:
+//   - string: Unused task ID
+func newTaskID(existing, content string) string {
+	tasks := task.Parse(strings.Split(existing, config.NewlineLF))
+	return task.NewID(
+		content+time.Now().Format(time.RFC3339Nano), task.IDs(tasks),
+	)
+}
+
+// taskID works out the ID of a new task.
+//
+// An "#id:" tag in the description is honored, so "#depends:" tags
+// written against it elsewhere hold; it is moved to the end of the line
+// where FormatTask puts IDs. Without one, a new ID is picked.
+//
+// Parameters:
+//   - existing: Current TASKS.md content
+//   - content: New task description
+//
+// Returns:
+//   - string: Description without the "#id:" tag
+//   - string: Task ID
+//   - error: Non-nil if the description has several IDs, or its ID is
+//     malformed (see task.ValidID) or already used in TASKS.md
+func taskID(existing, content string) (string, string, error) {
+	var ids []string
+	matches := config.RegExTaskTag.FindAllStringSubmatchIndex(content, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		m := matches[i]
+		if m[4] < 0 || content[m[2]:m[3]] != config.TaskTagID {
+			continue
+		}
+		if id := task.NormalizeID(content[m[4]:m[5]]); !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+		content = content[:m[0]] + content[m[1]:]
+	}
+
+	switch len(ids) {
+	case 0:
+		return content, newTaskID(existing, content), nil
+	case 1:
+	default:
+		slices.Reverse(ids)
+		return "", "", errTaskIDs(ids)
+	}
+	if !task.ValidID(ids[0]) {
+		return "", "", errTaskIDInvalid(ids[0])
+	}
+	tasks := task.Parse(strings.Split(existing, config.NewlineLF))
+	if task.IDs(tasks)[ids[0]] {
+		return "", "", errTaskIDTaken(ids[0])
+	}
+	return strings.TrimSpace(content), ids[0], nil
+}
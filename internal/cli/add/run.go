@@ -7,8 +7,10 @@
 package add
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -41,6 +43,10 @@ func ValidateEntry(params EntryParams) error {
 		}); len(m) > 0 {
 			return errMissingFields(config.EntryDecision, m)
 		}
+		if params.Status != "" &&
+			!slices.Contains(config.DecisionStatuses, strings.ToLower(params.Status)) {
+			return errUnknownStatus(params.Status)
+		}
 
 	case config.EntryLearning:
 		if m := checkRequired([][2]string{
@@ -64,7 +70,8 @@ func ValidateEntry(params EntryParams) error {
 //   - params: EntryParams containing type, content, and optional fields
 //
 // Returns:
-//   - error: Non-nil if type is unknown, the file doesn't exist, or write fails
+//   - error: Non-nil if type is unknown, the file doesn't exist, a task's
+//     "#id:" is taken, or write fails
 func WriteEntry(params EntryParams) error {
 	fType := strings.ToLower(params.Type)
 
@@ -91,10 +98,15 @@ func WriteEntry(params EntryParams) error {
 	switch config.UserInputToEntry(fType) {
 	case config.EntryDecision:
 		entry = FormatDecision(
-			params.Content, params.Context, params.Rationale, params.Consequences,
+			params.Content, params.Status, params.Context, params.Rationale,
+			params.Consequences,
 		)
 	case config.EntryTask:
-		entry = FormatTask(params.Content, params.Priority)
+		content, id, idErr := taskID(string(existing), params.Content)
+		if idErr != nil {
+			return idErr
+		}
+		entry = FormatTask(content, params.Priority, id)
 	case config.EntryLearning:
 		entry = FormatLearning(
 			params.Content, params.Context, params.Lesson, params.Application,
@@ -165,6 +177,7 @@ func runAdd(cmd *cobra.Command, args []string, flags addConfig) error {
 		Context:      flags.context,
 		Rationale:    flags.rationale,
 		Consequences: flags.consequences,
+		Status:       flags.status,
 		Lesson:       flags.lesson,
 		Application:  flags.application,
 	}
@@ -179,6 +192,9 @@ func runAdd(cmd *cobra.Command, args []string, flags addConfig) error {
 		}); len(m) > 0 {
 			return errMissingDecision(m)
 		}
+		if validateErr := ValidateEntry(params); validateErr != nil {
+			return validateErr
+		}
 	case config.EntryLearning:
 		if m := checkRequired([][2]string{
 			{"--context", flags.context},
@@ -195,12 +211,36 @@ func runAdd(cmd *cobra.Command, args []string, flags addConfig) error {
 		return errUnknownType(fType)
 	}
 
+	if secretErr := CheckSecrets(params); secretErr != nil {
+		return secretErr
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+
+	// Fold into an existing entry instead of adding one
+	if flags.appendTo != "" {
+		target, appendErr := AppendToEntry(params, flags.appendTo)
+		if appendErr != nil {
+			return appendErr
+		}
+		cmd.Println(fmt.Sprintf(
+			"%s Appended to [%s] %s in %s", green("✓"),
+			target.Entry.Timestamp, target.Entry.Title, fName,
+		))
+		return nil
+	}
+
+	if !flags.force {
+		if dupErr := CheckDuplicates(params); dupErr != nil {
+			return dupErr
+		}
+	}
+
 	// Write the entry using the shared function
 	if writeErr := WriteEntry(params); writeErr != nil {
 		return writeErr
 	}
 
-	green := color.New(color.FgGreen).SprintFunc()
 	cmd.Printf("%s Added to %s\n", green("✓"), fName)
 
 	return nil
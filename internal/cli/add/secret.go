@@ -0,0 +1,53 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"path/filepath"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+	"github.com/ActiveMemory/ctx/internal/secret"
+)
+
+// CheckSecrets refuses an entry that contains a credential.
+//
+// The content and every field are scanned with secret.Scan. Context
+// files are committed to git, so a leaked token would spread with every
+// clone. Patterns in the context directory's secrets allowlist are not
+// reported.
+//
+// Parameters:
+//   - params: The entry about to be added
+//
+// Returns:
+//   - error: Non-nil naming each finding, or if the allowlist is invalid
+func CheckSecrets(params EntryParams) error {
+	allowPath := filepath.Join(rc.ContextDir(), config.FileSecretAllowlist)
+	allow, allowErr := secret.LoadAllowlist(allowPath)
+	if allowErr != nil {
+		return allowErr
+	}
+
+	var found []string
+	for _, f := range [][2]string{
+		{"content", params.Content},
+		{config.FieldContext, params.Context},
+		{config.FieldRationale, params.Rationale},
+		{config.FieldConsequence, params.Consequences},
+		{config.FieldLesson, params.Lesson},
+		{config.FieldApplication, params.Application},
+	} {
+		for _, finding := range secret.Scan(f[1], allow) {
+			found = append(found, f[0]+": "+finding.Describe())
+		}
+	}
+	if len(found) > 0 {
+		return errSecret(found, allowPath)
+	}
+	return nil
+}
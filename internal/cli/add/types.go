@@ -6,6 +6,8 @@
 
 package add
 
+import "github.com/ActiveMemory/ctx/internal/index"
+
 // EntryParams contains all parameters needed to add an entry to a context file.
 //
 // Fields:
@@ -16,6 +18,7 @@ package add
 //   - Context: Context field (for decisions/learnings)
 //   - Rationale: Rationale (for decisions)
 //   - Consequences: Consequences (for decisions)
+//   - Status: Status (for decisions; empty means accepted)
 //   - Lesson: Lesson (for learnings)
 //   - Application: Application (for learnings)
 type EntryParams struct {
@@ -26,10 +29,21 @@ type EntryParams struct {
 	Context      string
 	Rationale    string
 	Consequences string
+	Status       string
 	Lesson       string
 	Application  string
 }
 
+// Duplicate is an existing entry that resembles a new one.
+//
+// Fields:
+//   - Entry: The existing entry
+//   - Score: Similarity to the new entry, from 0 to 1
+type Duplicate struct {
+	Entry index.EntryBlock
+	Score float64
+}
+
 // addConfig holds all flags for the add command.
 //
 // Fields:
@@ -39,8 +53,11 @@ type EntryParams struct {
 //   - context: Context field for decisions/learnings
 //   - rationale: Rationale field for decisions
 //   - consequences: Consequences field for decisions
+//   - status: Status field for decisions
 //   - lesson: Lesson field for learnings
 //   - application: Application field for learnings
+//   - force: Add decisions/learnings even if they look like duplicates
+//   - appendTo: Timestamp of an entry to fold a decision/learning into
 type addConfig struct {
 	priority     string
 	section      string
@@ -48,6 +65,9 @@ type addConfig struct {
 	context      string
 	rationale    string
 	consequences string
+	status       string
 	lesson       string
 	application  string
+	force        bool
+	appendTo     string
 }
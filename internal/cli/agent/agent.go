@@ -26,6 +26,10 @@ import (
 //   - --format: Output format, "md" for Markdown or "json" (default "md")
 //   - --cooldown: Suppress repeated output within this duration (default 10m)
 //   - --session: Session identifier for cooldown tombstone isolation
+//   - --query: Rank content against a free-text query instead of tasks
+//   - --explain: Print why each entry was included or dropped
+//   - --delta: Emit only content added or changed since the last packet
+//   - --profile: Build the packet for a named profile from .ctxrc
 //
 // Returns:
 //   - *cobra.Command: Configured agent command with flags registered
@@ -35,6 +39,10 @@ func Cmd() *cobra.Command {
 		format   string
 		cooldown time.Duration
 		session  string
+		query    string
+		explain  bool
+		delta    bool
+		profile  string
 	)
 
 	cmd := &cobra.Command{
@@ -45,17 +53,42 @@ func Cmd() *cobra.Command {
 The output is designed to be copy-pasted into an AI chat
 or piped to a system prompt. It includes:
   - Constitution rules (NEVER VIOLATE)
-  - Current tasks (budget-capped)
+  - Current tasks (budget-capped), led by the one "ctx tasks next" picks
   - Key conventions (budget-capped)
-  - Recent decisions (scored by relevance, full body)
-  - Key learnings (scored by relevance, full body)
+  - Recent decisions (scored by relevance)
+  - Key learnings (scored by relevance)
+  - Glossary terms and architecture sections (leftover budget)
 
 The --budget flag controls content selection. Entries are scored by
-recency and relevance to active tasks, then included in priority order
-until the budget is consumed. Entries that don't fit get title-only
-summaries in an "Also Noted" section.
+recency and relevance to active tasks. Every entry gets its title
+while the budget allows, then entries are upgraded in score order to
+a condensed form (title plus the first sentence of each field) and
+then to their full body. Title-only entries are listed in an
+"Also Noted" section. Inside a git repository, entries that mention
+changed files, directories or packages in backticks score higher.
 
-Use --budget to set token budget (default from .ctxrc or 8000).
+Budget left over goes to GLOSSARY.md terms mentioned in the tasks or
+included entries, then to ARCHITECTURE.md sections ranked by relevance
+to the active tasks.
+
+Use --query to describe what you are about to do. Decisions,
+learnings, conventions and architecture sections are then ranked
+with BM25 (with stemming) against the query instead of the active
+task keywords.
+
+Use --explain to see why an entry is or is not in the packet. Instead
+of the packet, it prints one row per candidate decision and learning
+with its recency and relevance scores, matched keywords, superseded
+flag, token cost, section budget, and the include/exclude reason.
+Combine with --format json for machine-readable output.
+
+Use --profile to build the packet for a role defined under "profiles"
+in .ctxrc. A profile can choose the sections to include, weight the
+decision/learning budget split, and set its own budget and closing
+instruction.
+
+Use --budget to set token budget (default from the profile, .ctxrc
+or 8000).
 Use --format to choose between Markdown (md) or JSON output.
 
 Cooldown (for hooks and automation):
@@ -63,16 +96,37 @@ Cooldown (for hooks and automation):
   is disabled and every call produces output. When --session is set,
   repeated calls within the --cooldown window (default 10m) are suppressed.
 
+Delta mode (for hooks that run on every tool use):
+  --delta (requires --session) ignores the cooldown. The first call of a
+  session prints the full packet; later calls print only tasks, rules,
+  conventions, decisions and learnings that were added or changed since
+  the previous packet, or that an earlier packet left out to fit the
+  budget, and nothing when there is nothing new.
+
 Examples:
   ctx agent                              # Default budget, Markdown output
   ctx agent --budget 4000                # Smaller context packet
   ctx agent --format json                # JSON output for programmatic use
-  ctx agent --session $PPID              # Cooldown scoped to calling process`,
+  ctx agent --query "hook stdin parsing" # Packet targeted at a topic
+  ctx agent --profile reviewer           # Packet for a .ctxrc profile
+  ctx agent --explain --format json      # Scoring report as JSON
+  ctx agent --session $PPID              # Cooldown scoped to calling process
+  ctx agent --delta --session $PPID      # Only what changed this session`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			prof, err := resolveProfile(profile)
+			if err != nil {
+				return err
+			}
 			if !cmd.Flags().Changed("budget") {
 				budget = rc.TokenBudget()
+				if prof != nil && prof.Budget > 0 {
+					budget = prof.Budget
+				}
 			}
-			return runAgent(cmd, budget, format, cooldown, session)
+			return runAgent(
+				cmd, budget, format, cooldown, session, query, explain, delta,
+				prof,
+			)
 		},
 	}
 
@@ -91,6 +145,22 @@ Examples:
 		&session, "session", "",
 		"Session identifier for cooldown isolation (e.g., $PPID)",
 	)
+	cmd.Flags().StringVar(
+		&query, "query", "",
+		"Rank content against this query instead of active tasks",
+	)
+	cmd.Flags().BoolVar(
+		&explain, "explain", false,
+		"Explain why each entry was included or dropped",
+	)
+	cmd.Flags().BoolVar(
+		&delta, "delta", false,
+		"Only emit content added or changed since the session's last packet",
+	)
+	cmd.Flags().StringVar(
+		&profile, "profile", "",
+		"Build the packet for a named profile from .ctxrc (e.g., reviewer)",
+	)
 
 	return cmd
 }
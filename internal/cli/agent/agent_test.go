@@ -7,10 +7,15 @@
 package agent
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 // TestAgentCommand tests the agent command.
@@ -71,3 +76,44 @@ func TestAgentJSONOutput(t *testing.T) {
 		t.Fatalf("agent --format json failed: %v", err)
 	}
 }
+
+func TestAgentProfileFlag(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(rc.Reset)
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+	rcContent := "profiles:\n  docs:\n    sections: [glossary]\n" +
+		"    budget: 1234\n    instruction: Write the docs.\n"
+	if err := os.WriteFile(config.FileContextRC, []byte(rcContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rc.Reset()
+
+	agentCmd := Cmd()
+	var out bytes.Buffer
+	agentCmd.SetOut(&out)
+	agentCmd.SetArgs([]string{"--profile", "docs", "--format", "json"})
+	if err := agentCmd.Execute(); err != nil {
+		t.Fatalf("agent --profile failed: %v", err)
+	}
+
+	var pkt Packet
+	if err := json.Unmarshal(out.Bytes(), &pkt); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if pkt.Profile != "docs" || pkt.Budget != 1234 ||
+		pkt.Instruction != "Write the docs." || len(pkt.Constitution) != 0 {
+		t.Errorf("profile not applied: %+v", pkt)
+	}
+
+	unknown := Cmd()
+	unknown.SetArgs([]string{"--profile", "reviewer"})
+	if err := unknown.Execute(); err == nil ||
+		!strings.Contains(err.Error(), "defined: docs") {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
 	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 // Budget tier allocation percentages.
@@ -22,141 +23,377 @@ const (
 	conventionBudgetPct = 0.20
 )
 
+// defaultInstruction closes the packet unless a profile replaces it.
+const defaultInstruction = "Before starting work, confirm to the user: " +
+	"\"I have read the required context files and " +
+	"I'm following project conventions.\""
+
 // assembledPacket holds the budget-aware output sections ready for rendering.
 //
 // Fields:
+//   - Layers: Merged context directories, outermost first (nil unless
+//     the context is layered)
 //   - ReadOrder: File paths in recommended reading order
 //   - Constitution: Constitution rules (always included)
 //   - Tasks: Active tasks (budget-capped)
+//   - Next: Task "ctx tasks next" picks, shown at the top of Tasks
+//     (empty if no task is ready, and in delta packets)
 //   - Conventions: Convention items (budget-capped)
-//   - Decisions: Full decision entries (scored, budget-fitted)
-//   - Learnings: Full learning entries (scored, budget-fitted)
+//   - Decisions: Full or condensed decision entries (scored,
+//     budget-fitted)
+//   - Learnings: Full or condensed learning entries (scored,
+//     budget-fitted)
+//   - Architecture: ARCHITECTURE.md sections relevant to the query
+//     or the active tasks
+//   - Glossary: GLOSSARY.md terms mentioned in the packet, with their
+//     definitions
 //   - Summaries: Title-only summaries of entries that didn't fit
 //   - Instruction: Behavioral instruction for the agent
+//   - Query: Free-text query the packet was ranked against (empty for
+//     the default task-keyword ranking)
 //   - Budget: Requested token budget
 //   - TokensUsed: Actual tokens consumed by the packet
+//   - Profile: Agent profile the packet was built for (nil for the
+//     default packet)
+//   - Delta: True if only content new since the last packet is included
+//   - Keywords: Task keywords used for relevance (task-keyword mode)
+//   - Changes: Files changed in git, used for the working-tree boost
+//     (nil outside a repository or with a clean tree)
+//   - PinnedConventions: Conventions marked #pin (also in Conventions)
+//   - ScoredDecisions: Every decision candidate with its outcome
+//   - ScoredLearnings: Every learning candidate with its outcome
+//   - DecisionBudget: Section budget splitBudget gave to decisions
+//   - LearningBudget: Section budget splitBudget gave to learnings
 type assembledPacket struct {
+	Layers       []string
 	ReadOrder    []string
 	Constitution []string
 	Tasks        []string
+	Next         string
 	Conventions  []string
 	Decisions    []string
 	Learnings    []string
+	Architecture []string
+	Glossary     []string
 	Summaries    []string
 	Instruction  string
+	Query        string
 	Budget       int
 	TokensUsed   int
+	Profile      *rc.AgentProfile
+	Delta        bool
+
+	Keywords          []string
+	Changes           *workTree
+	PinnedConventions []string
+	ScoredDecisions   []ScoredEntry
+	ScoredLearnings   []ScoredEntry
+	DecisionBudget    int
+	LearningBudget    int
 }
 
 // assembleBudgetPacket builds a context packet respecting the token budget.
 //
 // Allocation tiers:
-//   - Tier 1 (always): constitution, read order, instruction
+//   - Tier 1 (always): constitution, read order, instruction, and
+//     entries and conventions marked #pin
 //   - Tier 2 (40%): active tasks
 //   - Tier 3 (20%): conventions
 //   - Tier 4+5 (remaining): decisions + learnings, scored by relevance
+//   - Tier 6 (leftover): glossary terms mentioned in the packet, then
+//     architecture sections by relevance
+//
+// Entries and conventions marked #nopacket are never included. By
+// default, entries are scored against keywords from the active tasks.
+// With a query, decisions, learnings, conventions and architecture
+// sections are ranked with BM25 against the query instead. Either way,
+// entries that reference files changed in git get a boost.
+//
+// A profile can leave sections out (their tiers then cost nothing),
+// weight the decision/learning split and replace the instruction. Tasks
+// left out of the packet are still used for relevance.
 //
 // Parameters:
 //   - ctx: Loaded context containing the files
 //   - budget: Token budget to respect
+//   - query: Free-text query, or empty for task-keyword ranking
+//   - profile: Agent profile from .ctxrc, or nil for the default packet
 //
 // Returns:
 //   - *assembledPacket: Assembled packet within budget
-func assembleBudgetPacket(ctx *context.Context, budget int) *assembledPacket {
+func assembleBudgetPacket(
+	ctx *context.Context, budget int, query string, profile *rc.AgentProfile,
+) *assembledPacket {
 	now := time.Now()
 	pkt := &assembledPacket{
-		Budget: budget,
-		Instruction: "Before starting work, confirm to the user: " +
-			"\"I have read the required context files and " +
-			"I'm following project conventions.\"",
+		Budget:      budget,
+		Query:       query,
+		Profile:     profile,
+		Instruction: profileInstruction(profile),
 	}
 
 	remaining := budget
 
-	// Tier 1: Always included (constitution, read order, instruction)
-	pkt.ReadOrder = getReadOrder(ctx)
-	pkt.Constitution = extractConstitutionRules(ctx)
+	if ctx.IsLayered() {
+		pkt.Layers = ctx.Layers
+	}
+
+	// Candidate units are parsed up front: --query ranks them all against
+	// one index, and --explain reports on them even when an earlier tier
+	// exhausts the budget.
+	pinnedConventions, allConventions := splitConventions(
+		keep(profile, sectionConventions, extractAllConventions(ctx)),
+	)
+	decisionBlocks := keep(
+		profile, sectionDecisions, parseEntryBlocks(ctx, config.FileDecision),
+	)
+	learningBlocks := keep(
+		profile, sectionLearnings, parseEntryBlocks(ctx, config.FileLearning),
+	)
+	pinnedDecisions := pinnedBodies(decisionBlocks)
+	pinnedLearnings := pinnedBodies(learningBlocks)
+	archSections := keep(
+		profile, sectionArchitecture, extractArchitectureSections(ctx),
+	)
+	glossary := keep(profile, sectionGlossary, extractGlossaryTerms(ctx))
+	var rankTasks []string
+
+	pkt.Changes = readWorkTree(context.LayerRoot(ctx.Dir))
+
+	var qs *queryScores
+	if query != "" {
+		qs = scoreQuery(
+			query, decisionBlocks, learningBlocks, allConventions, archSections,
+		)
+		allConventions = rankByRelevance(allConventions, qs.Conventions)
+	}
+
+	// scoreCandidates ranks decisions and learnings against the query,
+	// or against active task keywords by default.
+	// Pinned entries are marked so budgetSection leaves them alone.
+	scoreCandidates := func() {
+		if qs != nil {
+			pkt.ScoredDecisions = scoreEntriesByQuery(
+				decisionBlocks, qs.Decisions, qs.DecisionTerms, now,
+			)
+			pkt.ScoredLearnings = scoreEntriesByQuery(
+				learningBlocks, qs.Learnings, qs.LearningTerms, now,
+			)
+		} else {
+			pkt.Keywords = extractTaskKeywords(rankTasks)
+			pkt.ScoredDecisions = scoreEntries(decisionBlocks, pkt.Keywords, now)
+			pkt.ScoredLearnings = scoreEntries(learningBlocks, pkt.Keywords, now)
+		}
+		addWorkTreeScores(pkt.ScoredDecisions, pkt.Changes)
+		addWorkTreeScores(pkt.ScoredLearnings, pkt.Changes)
+		markPinned(pkt.ScoredDecisions)
+		markPinned(pkt.ScoredLearnings)
+	}
+
+	// exhausted finishes a packet whose budget ran out before the entry
+	// tiers; candidates are still scored so --explain can show them.
+	exhausted := func(tokensUsed int, tier string) *assembledPacket {
+		pkt.TokensUsed = tokensUsed
+		scoreCandidates()
+		reason := "budget exhausted by " + tier
+		excludeAll(pkt.ScoredDecisions, reason)
+		excludeAll(pkt.ScoredLearnings, reason)
+		return pkt
+	}
+
+	// Tier 1: Always included (constitution, read order, instruction,
+	// pinned entries and conventions)
+	pkt.ReadOrder = keep(profile, sectionReadOrder, getReadOrder(ctx))
+	pkt.Constitution = keep(
+		profile, sectionConstitution, extractConstitutionRules(ctx),
+	)
+	pkt.Conventions = pinnedConventions
+	pkt.PinnedConventions = pinnedConventions
+	pkt.Decisions = pinnedDecisions
+	pkt.Learnings = pinnedLearnings
 
 	tier1Tokens := estimateSliceTokens(pkt.ReadOrder) +
 		estimateSliceTokens(pkt.Constitution) +
-		context.EstimateTokensString(pkt.Instruction)
+		context.CountTokensString(pkt.Instruction) +
+		estimateSliceTokens(pinnedConventions) +
+		estimateSliceTokens(pinnedDecisions) +
+		estimateSliceTokens(pinnedLearnings)
 	remaining -= tier1Tokens
 
 	if remaining <= 0 {
-		pkt.TokensUsed = tier1Tokens
-		return pkt
+		return exhausted(tier1Tokens, "constitution, read order and pins")
 	}
 
 	// Tier 2: Tasks (up to 40% of original budget)
 	taskCap := int(float64(budget) * taskBudgetPct)
 	allTasks := extractActiveTasks(ctx)
-	pkt.Tasks = fitItemsInBudget(allTasks, taskCap)
-	taskTokens := estimateSliceTokens(pkt.Tasks)
+	rankTasks = fitItemsInBudget(allTasks, taskCap)
+	pkt.Tasks = keep(profile, sectionTasks, rankTasks)
+	if profile.Includes(sectionTasks) {
+		pkt.Next = extractNextTask(ctx)
+	}
+	taskTokens := estimateSliceTokens(pkt.Tasks) +
+		context.CountTokensString(pkt.Next)
 	remaining -= taskTokens
 
 	if remaining <= 0 {
-		pkt.TokensUsed = budget - remaining
-		return pkt
+		return exhausted(budget-remaining, "tasks")
 	}
 
 	// Tier 3: Conventions (up to 20% of original budget)
 	convCap := int(float64(budget) * conventionBudgetPct)
-	allConventions := extractAllConventions(ctx)
-	pkt.Conventions = fitItemsInBudget(allConventions, convCap)
-	convTokens := estimateSliceTokens(pkt.Conventions)
+	fitConventions := fitItemsInBudget(allConventions, convCap)
+	pkt.Conventions = append(pkt.Conventions, fitConventions...)
+	convTokens := estimateSliceTokens(fitConventions)
 	remaining -= convTokens
 
 	if remaining <= 0 {
-		pkt.TokensUsed = budget - remaining
-		return pkt
+		return exhausted(budget-remaining, "conventions")
 	}
 
-	// Extract keywords from tasks for relevance scoring
-	keywords := extractTaskKeywords(pkt.Tasks)
-
 	// Tier 4+5: Decisions + Learnings (share remaining budget)
-	decisionBlocks := parseEntryBlocks(ctx, config.FileDecision)
-	learningBlocks := parseEntryBlocks(ctx, config.FileLearning)
+	scoreCandidates()
 
-	scoredDecisions := scoreEntries(decisionBlocks, keywords, now)
-	scoredLearnings := scoreEntries(learningBlocks, keywords, now)
+	entryTokens := pkt.fillEntries(remaining)
 
-	// Split remaining budget: proportional to content size, minimum 30% each
-	decTokens, learnTokens := splitBudget(
-		remaining, scoredDecisions, scoredLearnings,
+	// Tier 6: glossary and architecture compete for whatever the
+	// entries left over
+	var archRelevance []float64
+	if qs != nil {
+		archRelevance = qs.Architecture
+	} else {
+		archRelevance = keywordRelevance(archSections, pkt.Keywords)
+	}
+	addWorkTreeRelevance(archSections, archRelevance, pkt.Changes)
+	refTokens := pkt.fillReference(
+		remaining-entryTokens, glossary, append(allTasks, query),
+		archSections, archRelevance,
 	)
 
-	pkt.Decisions, pkt.Summaries = fillSection(scoredDecisions, decTokens)
+	pkt.TokensUsed = tier1Tokens + taskTokens + convTokens + entryTokens +
+		refTokens
 
-	var learnSummaries []string
-	pkt.Learnings, learnSummaries = fillSection(scoredLearnings, learnTokens)
-	pkt.Summaries = append(pkt.Summaries, learnSummaries...)
+	return pkt
+}
 
-	pkt.TokensUsed = tier1Tokens + taskTokens + convTokens +
-		estimateSliceTokens(pkt.Decisions) +
-		estimateSliceTokens(pkt.Learnings) +
+// fillEntries fills the decision and learning sections from the scored
+// candidates.
+//
+// The budget is split between the two sections (proportional to
+// content size and profile weights, see splitBudget), then each is
+// filled with graceful degradation. Filled entries are appended after
+// any pinned bodies already in the packet.
+//
+// Parameters:
+//   - budget: Tokens available to both sections
+//
+// Returns:
+//   - int: Tokens used by the filled entries and summaries
+func (pkt *assembledPacket) fillEntries(budget int) int {
+	pkt.budgetEntries(budget)
+	return pkt.renderEntries()
+}
+
+// budgetEntries splits a budget between decisions and learnings and
+// records the tier each scored candidate gets, without rendering them.
+//
+// Parameters:
+//   - budget: Tokens available to both sections
+func (pkt *assembledPacket) budgetEntries(budget int) {
+	pkt.DecisionBudget, pkt.LearningBudget = splitBudget(
+		budget, pkt.ScoredDecisions, pkt.ScoredLearnings,
+		pkt.Profile.Weight(sectionDecisions),
+		pkt.Profile.Weight(sectionLearnings),
+	)
+	budgetSection(pkt.ScoredDecisions, pkt.DecisionBudget)
+	budgetSection(pkt.ScoredLearnings, pkt.LearningBudget)
+}
+
+// renderEntries adds the scored candidates to the packet at the tier
+// budgetEntries gave them.
+//
+// Returns:
+//   - int: Tokens used by the rendered entries and summaries
+func (pkt *assembledPacket) renderEntries() int {
+	decisions, decSummaries := renderSection(pkt.ScoredDecisions)
+	learnings, learnSummaries := renderSection(pkt.ScoredLearnings)
+	pkt.Decisions = append(pkt.Decisions, decisions...)
+	pkt.Learnings = append(pkt.Learnings, learnings...)
+	pkt.Summaries = append(decSummaries, learnSummaries...)
+
+	return estimateSliceTokens(decisions) +
+		estimateSliceTokens(learnings) +
 		estimateSliceTokens(pkt.Summaries)
+}
 
-	return pkt
+// fillReference fills the glossary and architecture sections from the
+// budget left over by the entries.
+//
+// Glossary terms mentioned in the packet's tasks and entries, or in
+// the extra texts, come first, in glossary order: they are short and
+// explain words the agent is about to read. Architecture sections then
+// compete for the rest by relevance; sections with no relevance are
+// never included.
+//
+// Parameters:
+//   - budget: Tokens left over
+//   - terms: Candidate glossary terms
+//   - texts: Extra texts that can mention terms (active tasks, query)
+//   - sections: Candidate architecture sections
+//   - relevance: Relevance per section
+//
+// Returns:
+//   - int: Tokens used by both sections
+func (pkt *assembledPacket) fillReference(
+	budget int, terms []glossaryTerm, texts []string,
+	sections []string, relevance []float64,
+) int {
+	var sources []string
+	sources = append(sources, texts...)
+	sources = append(sources, pkt.Tasks...)
+	sources = append(sources, pkt.Decisions...)
+	sources = append(sources, pkt.Learnings...)
+	sources = append(sources, pkt.Summaries...)
+
+	used := 0
+	for _, g := range mentionedTerms(terms, sources) {
+		def := g.String()
+		tokens := context.CountTokensString(def)
+		if used+tokens > budget {
+			continue
+		}
+		pkt.Glossary = append(pkt.Glossary, def)
+		used += tokens
+	}
+
+	pkt.Architecture = fitRelevant(sections, relevance, budget-used)
+
+	return used + estimateSliceTokens(pkt.Architecture)
 }
 
 // extractAllConventions extracts all bullet items from CONVENTIONS.md
 // (not limited to 5 like the old implementation).
 //
+// In a layered context, the nearest layer's conventions come first so
+// they survive budget trimming ahead of inherited ones.
+//
 // Parameters:
 //   - ctx: Loaded context containing the files
 //
 // Returns:
 //   - []string: All convention bullet items; nil if the file is not found
 func extractAllConventions(ctx *context.Context) []string {
-	if f := ctx.File(config.FileConvention); f != nil {
-		return extractBulletItems(string(f.Content), 1000)
+	var items []string
+	for _, f := range ctx.FileLayers(config.FileConvention) {
+		items = append(items, extractBulletItems(string(f.Content), 1000)...)
 	}
-	return nil
+	return items
 }
 
 // parseEntryBlocks parses a context file into entry blocks.
 //
+// Entries from every layer are returned, nearest layer first.
+//
 // Parameters:
 //   - ctx: Loaded context
 //   - fileName: Name of the file to parse (e.g., config.FileDecision)
@@ -164,26 +401,36 @@ func extractAllConventions(ctx *context.Context) []string {
 // Returns:
 //   - []index.EntryBlock: Parsed entry blocks; nil if file not found
 func parseEntryBlocks(ctx *context.Context, fileName string) []index.EntryBlock {
-	if f := ctx.File(fileName); f != nil {
-		return index.ParseEntryBlocks(string(f.Content))
+	var blocks []index.EntryBlock
+	for _, f := range ctx.FileLayers(fileName) {
+		blocks = append(blocks, index.ParseEntryBlocks(string(f.Content))...)
 	}
-	return nil
+	return blocks
 }
 
 // splitBudget divides a token budget between two scored sections.
 //
-// Each section gets at least 30% of the budget (if content exists).
-// The remaining 40% is allocated proportionally to content size.
+// With equal weights, each section gets at least 30% of the budget (if
+// content exists) and the remaining 40% is allocated proportionally to
+// content size. Weights scale both: a section weighted 2 against 1 gets
+// a 40% minimum against 20%, and its content counts double in the
+// proportional part. Pinned entries are already paid for and do not
+// count as content.
 //
 // Parameters:
 //   - total: Total tokens to split
 //   - a: First section's scored entries
 //   - b: Second section's scored entries
+//   - weightA: Relative weight of section a (positive)
+//   - weightB: Relative weight of section b (positive)
 //
 // Returns:
 //   - int: Budget for section a
 //   - int: Budget for section b
-func splitBudget(total int, a, b []ScoredEntry) (int, int) {
+func splitBudget(
+	total int, a, b []ScoredEntry, weightA, weightB float64,
+) (int, int) {
+	a, b = competing(a), competing(b)
 	if len(a) == 0 && len(b) == 0 {
 		return 0, 0
 	}
@@ -207,56 +454,214 @@ func splitBudget(total int, a, b []ScoredEntry) (int, int) {
 		return aTokens, bTokens
 	}
 
-	// Minimum 30% each, proportional split of the rest
-	minA := total * 30 / 100
-	minB := total * 30 / 100
-	flex := total - minA - minB
+	// 60% split by weight as minimums, weighted proportional split of
+	// the rest
+	minTotal := total * 60 / 100
+	minA := int(float64(minTotal) * weightA / (weightA + weightB))
+	flex := total - minTotal
 
-	aProportion := float64(aTokens) / float64(totalContent)
+	aWeighted := weightA * float64(aTokens)
+	aProportion := aWeighted / (aWeighted + weightB*float64(bTokens))
 	aFlex := int(float64(flex) * aProportion)
 
 	return minA + aFlex, total - (minA + aFlex)
 }
 
-// fillSection selects scored entries to fill a budget, with graceful degradation.
-//
-// Includes full entries by score order until ~80% of budget is consumed.
-// Remaining entries get title-only summaries.
+// competing returns the entries that compete for a section budget.
 //
 // Parameters:
-//   - entries: Scored entries sorted by score descending
-//   - budget: Token budget for this section
+//   - entries: Scored entries
 //
 // Returns:
-//   - []string: Full entry bodies that fit in the budget
-//   - []string: Title-only summaries for entries that didn't fit
-func fillSection(entries []ScoredEntry, budget int) ([]string, []string) {
-	if len(entries) == 0 || budget <= 0 {
-		return nil, nil
+//   - []ScoredEntry: Entries that are neither pinned nor #nopacket
+func competing(entries []ScoredEntry) []ScoredEntry {
+	var result []ScoredEntry
+	for i := range entries {
+		eb := &entries[i].EntryBlock
+		if !isPinned(eb) && !eb.Entry.NoPacket {
+			result = append(result, entries[i])
+		}
+	}
+	return result
+}
+
+// budgetSection selects scored entries to fill a budget, with graceful
+// degradation.
+//
+// Each entry can be rendered at three tiers: full body, condensed
+// (title plus the first sentence of each field, see condenseEntry), or
+// title only. Coverage comes first: every entry gets a title in score
+// order while the budget allows, then entries are upgraded to condensed
+// in score order, then to full. As the budget shrinks, the packet keeps
+// many short entries rather than a few long ones. Pinned entries are
+// left to the caller and #nopacket entries are skipped. The outcome for each
+// entry is recorded in its Result and Reason fields for --explain.
+//
+// Parameters:
+//   - entries: Scored entries sorted by score descending (annotated in
+//     place)
+//   - budget: Token budget for this section
+func budgetSection(entries []ScoredEntry, budget int) {
+	if len(entries) == 0 {
+		return
+	}
+	if budget <= 0 {
+		excludeAll(entries, "section budget is 0")
+		return
 	}
 
-	fullBudget := budget * 80 / 100
+	cost := make([]int, len(entries))
 	used := 0
-	var full []string
-	var summaries []string
 
+	// Pass 1: a title for every entry that fits
 	for i := range entries {
-		if entries[i].Score == 0.0 {
-			// Superseded entries: skip entirely
+		e := &entries[i]
+		if isPinned(&e.EntryBlock) {
 			continue
 		}
-		body := entries[i].BlockContent()
-		tokens := entries[i].Tokens
-		if used+tokens <= fullBudget {
-			full = append(full, body)
-			used += tokens
-		} else {
-			// Title-only summary
+		if reason := skipReason(e); reason != "" {
+			e.Result, e.Reason = ResultExcluded, reason
+			continue
+		}
+		tokens := context.CountTokensString(e.Entry.Title)
+		if used+tokens > budget {
+			e.Result = ResultExcluded
+			e.Reason = fmt.Sprintf(
+				"title (%d tokens) exceeds remaining section budget (%d/%d left)",
+				tokens, budget-used, budget,
+			)
+			continue
+		}
+		used += tokens
+		cost[i] = tokens
+		e.Result = ResultSummary
+		e.Reason = "title only: richer tiers exceed remaining section budget"
+	}
+
+	// upgrade moves an entry to a richer tier if the extra tokens fit.
+	upgrade := func(i, tokens int, result Result) {
+		e := &entries[i]
+		if used-cost[i]+tokens > budget {
+			return
+		}
+		used += tokens - cost[i]
+		cost[i] = tokens
+		e.Result = result
+		e.Reason = fmt.Sprintf(
+			"%s tier fits section budget (%d tokens)", result, tokens,
+		)
+	}
+
+	// Pass 2: condensed for entries where it is cheaper than full
+	for i := range entries {
+		if entries[i].Result != ResultSummary {
+			continue
+		}
+		condensed := condenseEntry(&entries[i].EntryBlock)
+		if tokens := context.CountTokensString(condensed); tokens < entries[i].Tokens {
+			upgrade(i, tokens, ResultCondensed)
+		}
+	}
+
+	// Pass 3: full body
+	for i := range entries {
+		if r := entries[i].Result; r == ResultSummary || r == ResultCondensed {
+			upgrade(i, entries[i].Tokens, ResultFull)
+		}
+	}
+}
+
+// renderSection renders the entries of a section at the tier
+// budgetSection gave them.
+//
+// Parameters:
+//   - entries: Scored entries with their Result set
+//
+// Returns:
+//   - []string: Full and condensed entry bodies, in score order
+//   - []string: Title-only summaries for entries that didn't fit richer
+func renderSection(entries []ScoredEntry) ([]string, []string) {
+	var bodies, summaries []string
+	for i := range entries {
+		switch entries[i].Result {
+		case ResultFull:
+			bodies = append(bodies, entries[i].BlockContent())
+		case ResultCondensed:
+			bodies = append(bodies, condenseEntry(&entries[i].EntryBlock))
+		case ResultSummary:
 			summaries = append(summaries, entries[i].Entry.Title)
 		}
 	}
 
-	return full, summaries
+	return bodies, summaries
+}
+
+// excludeAll marks every entry except pinned ones as excluded.
+//
+// Entries that never compete keep their own reason (see skipReason).
+//
+// Parameters:
+//   - entries: Entries to annotate in place
+//   - reason: Explanation recorded on each other entry
+func excludeAll(entries []ScoredEntry, reason string) {
+	for i := range entries {
+		e := &entries[i]
+		if isPinned(&e.EntryBlock) {
+			continue
+		}
+		e.Result, e.Reason = ResultExcluded, reason
+		if r := skipReason(e); r != "" {
+			e.Reason = r
+		}
+	}
+}
+
+// skipReason explains why an entry never competes for a section budget.
+//
+// Parameters:
+//   - e: Scored entry
+//
+// Returns:
+//   - string: "#nopacket marker" or "superseded"; empty if the entry
+//     competes
+func skipReason(e *ScoredEntry) string {
+	switch {
+	case e.Entry.NoPacket:
+		return "#nopacket marker"
+	case e.Score == 0.0:
+		return "superseded"
+	}
+	return ""
+}
+
+// keep returns items if a profile includes their section.
+//
+// Parameters:
+//   - profile: Agent profile, or nil for the default packet
+//   - section: Section the items belong to (e.g., sectionTasks)
+//   - items: Candidate items
+//
+// Returns:
+//   - []T: items, or nil if the profile leaves the section out
+func keep[T any](profile *rc.AgentProfile, section string, items []T) []T {
+	if !profile.Includes(section) {
+		return nil
+	}
+	return items
+}
+
+// profileInstruction returns the instruction that closes the packet.
+//
+// Parameters:
+//   - profile: Agent profile, or nil for the default packet
+//
+// Returns:
+//   - string: The profile's instruction, or defaultInstruction
+func profileInstruction(profile *rc.AgentProfile) string {
+	if profile != nil && profile.Instruction != "" {
+		return profile.Instruction
+	}
+	return defaultInstruction
 }
 
 // fitItemsInBudget returns items that fit within a token budget.
@@ -276,7 +681,7 @@ func fitItemsInBudget(items []string, budget int) []string {
 	used := 0
 	var result []string
 	for _, item := range items {
-		tokens := context.EstimateTokensString(item)
+		tokens := context.CountTokensString(item)
 		if used+tokens > budget {
 			break
 		}
@@ -300,7 +705,7 @@ func fitItemsInBudget(items []string, budget int) []string {
 func estimateSliceTokens(items []string) int {
 	total := 0
 	for _, item := range items {
-		total += context.EstimateTokensString(item)
+		total += context.CountTokensString(item)
 	}
 	return total
 }
@@ -331,7 +736,11 @@ func renderMarkdownPacket(pkt *assembledPacket) string {
 	var sb strings.Builder
 	nl := config.NewlineLF
 
-	sb.WriteString("# Context Packet" + nl)
+	if pkt.Delta {
+		sb.WriteString("# Context Packet (changes since last packet)" + nl)
+	} else {
+		sb.WriteString("# Context Packet" + nl)
+	}
 	sb.WriteString(
 		fmt.Sprintf(
 			"Generated: %s | Budget: %d tokens | Used: ~%d",
@@ -339,12 +748,24 @@ func renderMarkdownPacket(pkt *assembledPacket) string {
 		) + nl + nl,
 	)
 
+	if pkt.Profile != nil {
+		sb.WriteString("Profile: " + pkt.Profile.Name + nl + nl)
+	}
+	if pkt.Query != "" {
+		sb.WriteString(fmt.Sprintf("Query: %q", pkt.Query) + nl + nl)
+	}
+	if len(pkt.Layers) > 0 {
+		sb.WriteString("Layers: " + strings.Join(pkt.Layers, " > ") + nl + nl)
+	}
+
 	// Read order
-	sb.WriteString("## Read These Files (in order)" + nl)
-	for i, path := range pkt.ReadOrder {
-		sb.WriteString(fmt.Sprintf("%d. %s", i+1, path) + nl)
+	if len(pkt.ReadOrder) > 0 {
+		sb.WriteString("## Read These Files (in order)" + nl)
+		for i, path := range pkt.ReadOrder {
+			sb.WriteString(fmt.Sprintf("%d. %s", i+1, path) + nl)
+		}
+		sb.WriteString(nl)
 	}
-	sb.WriteString(nl)
 
 	// Constitution
 	if len(pkt.Constitution) > 0 {
@@ -356,8 +777,11 @@ func renderMarkdownPacket(pkt *assembledPacket) string {
 	}
 
 	// Tasks
-	if len(pkt.Tasks) > 0 {
+	if len(pkt.Tasks) > 0 || pkt.Next != "" {
 		sb.WriteString("## Current Tasks" + nl)
+		if pkt.Next != "" {
+			sb.WriteString("**Next:** " + pkt.Next + nl)
+		}
 		for _, t := range pkt.Tasks {
 			sb.WriteString(t + nl)
 		}
@@ -389,6 +813,23 @@ func renderMarkdownPacket(pkt *assembledPacket) string {
 		}
 	}
 
+	// Architecture sections
+	if len(pkt.Architecture) > 0 {
+		sb.WriteString("## Relevant Architecture" + nl)
+		for _, section := range pkt.Architecture {
+			sb.WriteString(section + nl + nl)
+		}
+	}
+
+	// Glossary terms
+	if len(pkt.Glossary) > 0 {
+		sb.WriteString("## Glossary" + nl)
+		for _, term := range pkt.Glossary {
+			sb.WriteString(fmt.Sprintf("- %s", term) + nl)
+		}
+		sb.WriteString(nl)
+	}
+
 	// Summaries
 	if len(pkt.Summaries) > 0 {
 		sb.WriteString("## Also Noted" + nl)
@@ -398,7 +839,9 @@ func renderMarkdownPacket(pkt *assembledPacket) string {
 		sb.WriteString(nl)
 	}
 
-	sb.WriteString(pkt.Instruction + nl)
+	if pkt.Instruction != "" {
+		sb.WriteString(pkt.Instruction + nl)
+	}
 
 	return sb.String()
 }
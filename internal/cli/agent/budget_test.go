@@ -7,10 +7,14 @@
 package agent
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
 	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 func TestFitItemsInBudget(t *testing.T) {
@@ -48,6 +52,7 @@ func TestSplitBudget(t *testing.T) {
 		wantAExact int
 		wantBExact int
 		exact      bool
+		weightA    float64
 	}{
 		{
 			name:       "both empty",
@@ -94,10 +99,27 @@ func TestSplitBudget(t *testing.T) {
 			wantAMin: 30, wantAMax: 70,
 			wantBMin: 30, wantBMax: 70,
 		},
+		{
+			name:  "weighted section gets a larger share",
+			total: 100,
+			aEntries: []ScoredEntry{
+				{EntryBlock: makeBlock("2026-02-19", "A", "body"), Tokens: 500},
+			},
+			bEntries: []ScoredEntry{
+				{EntryBlock: makeBlock("2026-02-19", "B", "body"), Tokens: 500},
+			},
+			weightA: 3,
+			// 45 minimum + 3/4 of the 40 flex tokens
+			wantAExact: 75, wantBExact: 25, exact: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotA, gotB := splitBudget(tt.total, tt.aEntries, tt.bEntries)
+			weightA := tt.weightA
+			if weightA == 0 {
+				weightA = 1
+			}
+			gotA, gotB := splitBudget(tt.total, tt.aEntries, tt.bEntries, weightA, 1)
 			if tt.exact {
 				if gotA != tt.wantAExact || gotB != tt.wantBExact {
 					t.Errorf("splitBudget() = (%d, %d), want (%d, %d)",
@@ -120,6 +142,12 @@ func TestSplitBudget(t *testing.T) {
 	}
 }
 
+// fillSection budgets one section and renders it, as fillEntries does.
+func fillSection(entries []ScoredEntry, budget int) ([]string, []string) {
+	budgetSection(entries, budget)
+	return renderSection(entries)
+}
+
 func TestFillSection(t *testing.T) {
 	entries := []ScoredEntry{
 		{
@@ -150,9 +178,9 @@ func TestFillSection(t *testing.T) {
 	})
 
 	t.Run("partial fit with summaries", func(t *testing.T) {
-		// Budget allows ~80% = 16 tokens for full entries
-		// First entry (10 tokens) fits, second (8 tokens) = 18 total > 16
-		full, summaries := fillSection(entries, 20)
+		// Titles (2 + 2 + 2 tokens) are reserved first; only the first
+		// entry's full body (10 tokens) fits on top of them
+		full, summaries := fillSection(entries, 18)
 		if len(full) != 1 {
 			t.Errorf("expected 1 full entry, got %d", len(full))
 		}
@@ -161,6 +189,17 @@ func TestFillSection(t *testing.T) {
 		}
 	})
 
+	t.Run("titles that do not fit are excluded", func(t *testing.T) {
+		full, summaries := fillSection(entries, 5)
+		if len(full) != 0 || len(summaries) != 2 {
+			t.Errorf("got %d full, %v summaries; want 0 full, 2 titles",
+				len(full), summaries)
+		}
+		if entries[2].Result != ResultExcluded {
+			t.Errorf("lowest entry Result = %s, want excluded", entries[2].Result)
+		}
+	})
+
 	t.Run("empty entries", func(t *testing.T) {
 		full, summaries := fillSection(nil, 1000)
 		if full != nil || summaries != nil {
@@ -274,3 +313,122 @@ func TestRenderMarkdownPacket_Empty(t *testing.T) {
 		t.Error("should not render empty tasks section")
 	}
 }
+
+func TestAssembleBudgetPacket_Layered(t *testing.T) {
+	parent := filepath.Join("..", ".context")
+	ctx := &context.Context{
+		Dir:    ".context",
+		Layers: []string{parent, ".context"},
+		Files: []context.FileInfo{
+			{
+				Name: config.FileTask, Layer: ".context",
+				Path:    filepath.Join(".context", config.FileTask),
+				Content: []byte("# Tasks\n\n- [ ] Child task\n"),
+			},
+			{
+				Name: config.FileTask, Layer: parent,
+				Path:    filepath.Join(parent, config.FileTask),
+				Content: []byte("# Tasks\n\n- [ ] Parent task\n"),
+			},
+			{
+				Name: config.FileConvention, Layer: parent,
+				Path:    filepath.Join(parent, config.FileConvention),
+				Content: []byte("# Conventions\n\n- Parent convention\n"),
+			},
+		},
+	}
+
+	pkt := assembleBudgetPacket(ctx, 8000, "", nil)
+
+	if len(pkt.Layers) != 2 {
+		t.Errorf("Layers = %v, want both layers", pkt.Layers)
+	}
+	wantTasks := []string{"- [ ] Child task", "- [ ] Parent task"}
+	if strings.Join(pkt.Tasks, "|") != strings.Join(wantTasks, "|") {
+		t.Errorf("Tasks = %v, want %v", pkt.Tasks, wantTasks)
+	}
+	if len(pkt.Conventions) != 1 || pkt.Conventions[0] != "Parent convention" {
+		t.Errorf("Conventions = %v, want inherited convention", pkt.Conventions)
+	}
+
+	// Parent files are read before the child's, which override them.
+	wantOrder := []string{
+		filepath.Join(parent, config.FileTask),
+		filepath.Join(".context", config.FileTask),
+		filepath.Join(parent, config.FileConvention),
+	}
+	if strings.Join(pkt.ReadOrder, "|") != strings.Join(wantOrder, "|") {
+		t.Errorf("ReadOrder = %v, want %v", pkt.ReadOrder, wantOrder)
+	}
+}
+
+func TestAssembleBudgetPacket_Profile(t *testing.T) {
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{Name: config.FileTask, Content: []byte("# Tasks\n\n- [ ] Fix hook parsing\n")},
+			{Name: config.FileConvention, Content: []byte("# Conventions\n\n- Use fatih/color\n")},
+			{
+				Name: config.FileDecision,
+				Content: []byte("# Decisions\n\n## [2026-02-18-120000] Parse hook stdin once\n\n" +
+					"Hook parsing reads stdin a single time.\n"),
+			},
+		},
+	}
+	profile := &rc.AgentProfile{
+		Name:        "reviewer",
+		Sections:    []string{config.PacketSectionConventions, config.PacketSectionDecisions},
+		Instruction: "Review the diff against the conventions.",
+	}
+
+	pkt := assembleBudgetPacket(ctx, 8000, "", profile)
+
+	if len(pkt.Tasks) != 0 || len(pkt.ReadOrder) != 0 {
+		t.Errorf("excluded sections present: Tasks = %v, ReadOrder = %v",
+			pkt.Tasks, pkt.ReadOrder)
+	}
+	if len(pkt.Conventions) != 1 || len(pkt.Decisions) != 1 {
+		t.Errorf("Conventions = %v, Decisions = %v", pkt.Conventions, pkt.Decisions)
+	}
+	// Excluded tasks still drive relevance
+	if len(pkt.Keywords) == 0 || pkt.ScoredDecisions[0].Relevance == 0 {
+		t.Errorf("Keywords = %v, want task keywords", pkt.Keywords)
+	}
+	if pkt.Instruction != profile.Instruction {
+		t.Errorf("Instruction = %q", pkt.Instruction)
+	}
+
+	md := renderMarkdownPacket(pkt)
+	if !strings.Contains(md, "Profile: reviewer") ||
+		strings.Contains(md, "## Current Tasks") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+}
+
+func TestAssembleBudgetPacket_NextTask(t *testing.T) {
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name: config.FileTask,
+				Content: []byte("# Tasks\n\n- [ ] Write docs #id:d0c5\n" +
+					"- [ ] Fix login #id:k3f9 #priority:high\n"),
+			},
+		},
+	}
+
+	pkt := assembleBudgetPacket(ctx, 8000, "", nil)
+	if pkt.Next != "Fix login #id:k3f9 #priority:high" {
+		t.Errorf("Next = %q", pkt.Next)
+	}
+	md := renderMarkdownPacket(pkt)
+	if !strings.Contains(md, "## Current Tasks\n**Next:** Fix login") {
+		t.Errorf("next task should head the Tasks section:\n%s", md)
+	}
+
+	// Nothing is highlighted when every task is blocked
+	ctx.Files[0].Content = []byte("# Tasks\n\n- [ ] Fix login #blocked\n")
+	if pkt = assembleBudgetPacket(ctx, 8000, "", nil); pkt.Next != "" {
+		t.Errorf("Next = %q, want empty", pkt.Next)
+	}
+}
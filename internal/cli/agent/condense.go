@@ -0,0 +1,118 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// sentenceAbbreviations end in a period without ending a sentence.
+var sentenceAbbreviations = map[string]bool{
+	"e.g": true, "i.e": true, "etc": true, "vs": true, "cf": true,
+}
+
+// condenseEntry renders an entry as its header plus the first sentence
+// of each field.
+//
+// Fields are "**Label**: text" paragraphs (Context, Rationale, Lesson,
+// ...). An entry without labeled fields keeps the first sentence of its
+// first paragraph.
+//
+// Parameters:
+//   - eb: Entry block to condense
+//
+// Returns:
+//   - string: Condensed entry; just the header if the body is empty
+func condenseEntry(eb *index.EntryBlock) string {
+	if len(eb.Lines) == 0 {
+		return ""
+	}
+
+	nl := config.NewlineLF
+	var fields, plain []string
+	for _, p := range paragraphs(eb.Lines[1:]) {
+		if m := config.RegExEntryField.FindStringSubmatch(p); m != nil {
+			fields = append(fields, "**"+m[1]+"**: "+firstSentence(m[2]))
+			continue
+		}
+		if p != "---" && !strings.HasPrefix(p, "<!--") {
+			plain = append(plain, p)
+		}
+	}
+	if len(fields) == 0 && len(plain) > 0 {
+		fields = []string{firstSentence(plain[0])}
+	}
+
+	parts := append([]string{eb.Lines[0]}, fields...)
+	return strings.Join(parts, nl+nl)
+}
+
+// paragraphs groups lines into blank-line separated paragraphs.
+//
+// Lines within a paragraph are trimmed and joined with spaces.
+//
+// Parameters:
+//   - lines: Lines to group
+//
+// Returns:
+//   - []string: Non-empty paragraphs in order
+func paragraphs(lines []string) []string {
+	var result, current []string
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+		current = append(current, trimmed)
+	}
+	flush()
+	return result
+}
+
+// firstSentence returns text up to the end of its first sentence.
+//
+// A sentence ends at ".", "!" or "?" followed by whitespace or the end
+// of the text; common abbreviations such as "e.g." do not end one.
+//
+// Parameters:
+//   - text: Text to shorten
+//
+// Returns:
+//   - string: First sentence, or the whole text if it has only one
+func firstSentence(text string) string {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '.', '!', '?':
+		default:
+			continue
+		}
+		if i+1 < len(text) && text[i+1] != ' ' && text[i+1] != '\t' {
+			continue
+		}
+		if text[i] == '.' {
+			words := strings.Fields(text[:i])
+			if len(words) > 0 {
+				last := strings.TrimLeft(words[len(words)-1], "(")
+				if sentenceAbbreviations[strings.ToLower(last)] {
+					continue
+				}
+			}
+		}
+		return text[:i+1]
+	}
+	return text
+}
@@ -0,0 +1,109 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+func makeFieldBlock(title string) index.EntryBlock {
+	body := "**Context**: Hooks read stdin. They used to read it twice.\n\n" +
+		"**Rationale**: Reading once avoids a hang (e.g. on Windows). " +
+		"The second read blocks forever.\n\n" +
+		"**Consequences**: All hooks share one parser. " +
+		strings.Repeat("More detail follows here. ", 20)
+	content := "## [2026-02-19-120000] " + title + "\n\n" + body + "\n"
+	return index.ParseEntryBlocks(content)[0]
+}
+
+func TestFirstSentence(t *testing.T) {
+	tests := map[string]string{
+		"One. Two.":                      "One.",
+		"Use v0.6.0 today. Later more.":  "Use v0.6.0 today.",
+		"Tools (e.g. grep) work. Next.":  "Tools (e.g. grep) work.",
+		"Really? Yes.":                   "Really?",
+		"No terminator at all":           "No terminator at all",
+		"Ends with period.":              "Ends with period.",
+		"See config.go for details. Ok.": "See config.go for details.",
+	}
+	for in, want := range tests {
+		if got := firstSentence(in); got != want {
+			t.Errorf("firstSentence(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCondenseEntry(t *testing.T) {
+	eb := makeFieldBlock("Read stdin once")
+	got := condenseEntry(&eb)
+	want := "## [2026-02-19-120000] Read stdin once\n\n" +
+		"**Context**: Hooks read stdin.\n\n" +
+		"**Rationale**: Reading once avoids a hang (e.g. on Windows).\n\n" +
+		"**Consequences**: All hooks share one parser."
+	if got != want {
+		t.Errorf("condenseEntry() =\n%s\nwant\n%s", got, want)
+	}
+
+	plain := makeBlock("2026-02-19", "Plain", "First sentence. Second one.")
+	if got := condenseEntry(&plain); !strings.HasSuffix(got, "\n\nFirst sentence.") {
+		t.Errorf("condenseEntry(plain) = %q", got)
+	}
+}
+
+func TestFillSection_Tiers(t *testing.T) {
+	var entries []ScoredEntry
+	for i := 0; i < 5; i++ {
+		entries = append(entries,
+			newScoredEntry(makeFieldBlock("Entry "+string(rune('A'+i))), 1.0-float64(i)*0.1),
+		)
+	}
+	full := entries[0].Tokens
+	condensed := context.CountTokensString(condenseEntry(&entries[0].EntryBlock))
+	title := context.CountTokensString(entries[0].Entry.Title)
+
+	results := func(budget int) []Result {
+		bodies, summaries := fillSection(entries, budget)
+		var got []Result
+		for _, e := range entries {
+			got = append(got, e.Result)
+		}
+		if len(bodies)+len(summaries) > len(entries) {
+			t.Errorf("budget %d: more output than entries", budget)
+		}
+		return got
+	}
+
+	// Everything fits in full
+	for _, r := range results(5 * full) {
+		if r != ResultFull {
+			t.Fatalf("generous budget: got %s, want all full", r)
+		}
+	}
+
+	// Enough for all condensed but not for any full body on top
+	for _, r := range results(5 * condensed) {
+		if r != ResultCondensed {
+			t.Fatalf("condensed budget: got %s, want all condensed", r)
+		}
+	}
+
+	// Condensed for the best entry, titles for the rest
+	got := results(condensed + 4*title)
+	if got[0] != ResultCondensed || got[4] != ResultSummary {
+		t.Errorf("tight budget: got %v", got)
+	}
+
+	// Only some titles fit
+	got = results(2 * title)
+	if got[1] != ResultSummary || got[2] != ResultExcluded {
+		t.Errorf("tiny budget: got %v", got)
+	}
+}
@@ -7,6 +7,7 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -55,15 +56,44 @@ func cooldownActive(session string, cooldown time.Duration) bool {
 }
 
 // touchTombstone creates or updates the tombstone file for the given
-// session, marking the current time as the last emission.
+// session, marking the current time as the last emission and storing
+// the snapshot of the emitted context for --delta.
 //
 // Parameters:
 //   - session: session identifier (typically the caller's PID)
-func touchTombstone(session string) {
+//   - snap: snapshot of what the session's packets delivered
+func touchTombstone(session string, snap *snapshot) {
 	if session == "" {
 		return
 	}
-	_ = os.WriteFile(tombstonePath(session), nil, 0o600)
+	data, err := json.Marshal(snap)
+	if err != nil {
+		data = nil
+	}
+	_ = os.WriteFile(tombstonePath(session), data, 0o600)
+}
+
+// readSnapshot loads the snapshot stored in a session's tombstone.
+//
+// Parameters:
+//   - session: session identifier (typically the caller's PID)
+//
+// Returns:
+//   - *snapshot: Stored snapshot; nil if there is no tombstone or it
+//     holds no snapshot (e.g., written by an older version)
+func readSnapshot(session string) *snapshot {
+	if session == "" {
+		return nil
+	}
+	data, err := os.ReadFile(tombstonePath(session))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil
+	}
+	return &snap
 }
 
 // tombstonePath returns the filesystem path for a session's tombstone.
@@ -0,0 +1,325 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// contentHashLen is the number of hex characters kept from each hash.
+const contentHashLen = 16
+
+// snapshot records what the packets of a session delivered, as one
+// content hash per constitution rule, task, convention, decision,
+// learning, architecture section and glossary term. It is stored in
+// the session tombstone so the next --delta packet can leave out
+// everything already sent.
+//
+// Fields:
+//   - Hashes: "section:hash" keys of the units delivered in full
+//   - Partial: Tier of the entries delivered only as a title or
+//     condensed, by key; a later delta can still send them richer
+type snapshot struct {
+	Hashes  []string          `json:"hashes"`
+	Partial map[string]Result `json:"partial,omitempty"`
+}
+
+// tierRank orders the tiers an entry can be delivered at.
+var tierRank = map[Result]int{
+	ResultSummary:   1,
+	ResultCondensed: 2,
+	ResultFull:      3,
+	ResultPinned:    3,
+}
+
+// contentHash returns a short, stable hash of a packet unit.
+//
+// Parameters:
+//   - section: Section the unit belongs to (e.g., sectionTasks)
+//   - text: Unit content
+//
+// Returns:
+//   - string: "section:hash" key
+func contentHash(section, text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return section + ":" + hex.EncodeToString(sum[:])[:contentHashLen]
+}
+
+// entryHash returns the key of a decision or learning.
+//
+// Entries are hashed by their full source block, so an entry counts as
+// changed when its text changes, not when it is rendered at another
+// tier.
+//
+// Parameters:
+//   - section: sectionDecisions or sectionLearnings
+//   - eb: Entry block
+//
+// Returns:
+//   - string: "section:hash" key
+func entryHash(section string, eb *index.EntryBlock) string {
+	return contentHash(section, eb.BlockContent())
+}
+
+// newSnapshot records the units a packet delivered.
+//
+// Only what the packet rendered counts: tasks, conventions and entries
+// the budget left out are not recorded, so a later delta still sends
+// them. Entries rendered as a title or condensed are recorded with
+// that tier. A delta packet adds to the snapshot of the packets before
+// it.
+//
+// Parameters:
+//   - pkt: Packet that was emitted
+//   - prev: Snapshot of the session's earlier packets; nil unless pkt
+//     is a delta packet
+//
+// Returns:
+//   - *snapshot: Hashes of the delivered units
+func newSnapshot(pkt *assembledPacket, prev *snapshot) *snapshot {
+	s := &snapshot{Partial: make(map[string]Result)}
+	sent := make(map[string]bool)
+	if prev != nil {
+		s.Hashes = slices.Clone(prev.Hashes)
+		sent = prev.set()
+		maps.Copy(s.Partial, prev.Partial)
+	}
+
+	full := func(key string) {
+		if !sent[key] {
+			sent[key] = true
+			s.Hashes = append(s.Hashes, key)
+		}
+		delete(s.Partial, key)
+	}
+	add := func(section string, items []string) {
+		for _, item := range items {
+			full(contentHash(section, item))
+		}
+	}
+	addEntries := func(section string, entries []ScoredEntry) {
+		for i := range entries {
+			key := entryHash(section, &entries[i].EntryBlock)
+			switch r := entries[i].Result; r {
+			case ResultPinned, ResultFull:
+				full(key)
+			case ResultCondensed, ResultSummary:
+				if !sent[key] && tierRank[r] > tierRank[s.Partial[key]] {
+					s.Partial[key] = r
+				}
+			}
+		}
+	}
+
+	add(sectionConstitution, pkt.Constitution)
+	add(sectionTasks, pkt.Tasks)
+	add(sectionConventions, pkt.Conventions)
+	addEntries(sectionDecisions, pkt.ScoredDecisions)
+	addEntries(sectionLearnings, pkt.ScoredLearnings)
+	add(sectionArchitecture, pkt.Architecture)
+	add(sectionGlossary, pkt.Glossary)
+
+	if len(s.Partial) == 0 {
+		s.Partial = nil
+	}
+	return s
+}
+
+// set returns the snapshot's hashes as a lookup set.
+//
+// Returns:
+//   - map[string]bool: Set of "section:hash" keys
+func (s *snapshot) set() map[string]bool {
+	seen := make(map[string]bool, len(s.Hashes))
+	for _, h := range s.Hashes {
+		seen[h] = true
+	}
+	return seen
+}
+
+// skipResent excludes entries a delta would send no richer than an
+// earlier packet did.
+//
+// Parameters:
+//   - section: sectionDecisions or sectionLearnings
+//   - entries: Scored entries of a delta packet (annotated in place)
+func (s *snapshot) skipResent(section string, entries []ScoredEntry) {
+	for i := range entries {
+		e := &entries[i]
+		was, ok := s.Partial[entryHash(section, &e.EntryBlock)]
+		if ok && e.Result != ResultExcluded && tierRank[e.Result] <= tierRank[was] {
+			e.Result = ResultExcluded
+			e.Reason = fmt.Sprintf("already sent as %s", was)
+		}
+	}
+}
+
+// assembleDeltaPacket builds a packet of the units that were added or
+// changed since a previous snapshot, or that earlier packets left out.
+//
+// Sections follow the same budget tiers as assembleBudgetPacket, but
+// only units not yet delivered compete for the budget. Entries sent
+// earlier as a title or condensed compete again and are included only
+// at a richer tier. Read order and the
+// instruction are left out: the agent already has them from the
+// first packet of the session. Sections the profile leaves out stay
+// out.
+//
+// Parameters:
+//   - ctx: Loaded context
+//   - budget: Token budget to respect
+//   - query: Free-text query, or empty for task-keyword ranking
+//   - profile: Agent profile from .ctxrc, or nil for the default packet
+//   - prev: Snapshot of the session's earlier packets
+//
+// Returns:
+//   - *assembledPacket: Delta packet; see isEmpty for "nothing new"
+func assembleDeltaPacket(
+	ctx *context.Context, budget int, query string,
+	profile *rc.AgentProfile, prev *snapshot,
+) *assembledPacket {
+	seen := prev.set()
+	pkt := &assembledPacket{
+		Budget: budget, Query: query, Profile: profile, Delta: true,
+	}
+	if ctx.IsLayered() {
+		pkt.Layers = ctx.Layers
+	}
+
+	fresh := func(section string, items []string) []string {
+		var result []string
+		for _, item := range keep(profile, section, items) {
+			if !seen[contentHash(section, item)] {
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+	freshBlocks := func(section string, blocks []index.EntryBlock) []index.EntryBlock {
+		var result []index.EntryBlock
+		blocks = keep(profile, section, blocks)
+		for i := range blocks {
+			if !seen[entryHash(section, &blocks[i])] {
+				result = append(result, blocks[i])
+			}
+		}
+		return result
+	}
+
+	decisions := freshBlocks(
+		sectionDecisions, parseEntryBlocks(ctx, config.FileDecision),
+	)
+	learnings := freshBlocks(
+		sectionLearnings, parseEntryBlocks(ctx, config.FileLearning),
+	)
+	pinnedConventions, conventions := splitConventions(
+		keep(profile, sectionConventions, extractAllConventions(ctx)),
+	)
+	pinnedConventions = fresh(sectionConventions, pinnedConventions)
+	conventions = fresh(sectionConventions, conventions)
+
+	// Changed rules and pins are always included, like in a full packet
+	pkt.Constitution = fresh(sectionConstitution, extractConstitutionRules(ctx))
+	pkt.Conventions = pinnedConventions
+	pkt.PinnedConventions = pinnedConventions
+	pkt.Decisions = pinnedBodies(decisions)
+	pkt.Learnings = pinnedBodies(learnings)
+	remaining := budget - estimateSliceTokens(pkt.Constitution) -
+		estimateSliceTokens(pkt.Conventions) -
+		estimateSliceTokens(pkt.Decisions) -
+		estimateSliceTokens(pkt.Learnings)
+
+	allTasks := extractActiveTasks(ctx)
+	pkt.Tasks = fitItemsInBudget(
+		fresh(sectionTasks, allTasks), int(float64(budget)*taskBudgetPct),
+	)
+	remaining -= estimateSliceTokens(pkt.Tasks)
+
+	fitConventions := fitItemsInBudget(
+		conventions, int(float64(budget)*conventionBudgetPct),
+	)
+	pkt.Conventions = append(pkt.Conventions, fitConventions...)
+	remaining -= estimateSliceTokens(fitConventions)
+
+	// New entries are ranked against all active tasks, not just new ones
+	now := time.Now()
+	if query != "" {
+		qs := scoreQuery(query, decisions, learnings, nil, nil)
+		pkt.ScoredDecisions = scoreEntriesByQuery(
+			decisions, qs.Decisions, qs.DecisionTerms, now,
+		)
+		pkt.ScoredLearnings = scoreEntriesByQuery(
+			learnings, qs.Learnings, qs.LearningTerms, now,
+		)
+	} else {
+		pkt.Keywords = extractTaskKeywords(allTasks)
+		pkt.ScoredDecisions = scoreEntries(decisions, pkt.Keywords, now)
+		pkt.ScoredLearnings = scoreEntries(learnings, pkt.Keywords, now)
+	}
+	pkt.Changes = readWorkTree(context.LayerRoot(ctx.Dir))
+	addWorkTreeScores(pkt.ScoredDecisions, pkt.Changes)
+	addWorkTreeScores(pkt.ScoredLearnings, pkt.Changes)
+	markPinned(pkt.ScoredDecisions)
+	markPinned(pkt.ScoredLearnings)
+	pkt.budgetEntries(max(remaining, 0))
+	prev.skipResent(sectionDecisions, pkt.ScoredDecisions)
+	prev.skipResent(sectionLearnings, pkt.ScoredLearnings)
+	remaining -= pkt.renderEntries()
+
+	// New glossary terms and architecture sections use what is left
+	archSections := fresh(sectionArchitecture, extractArchitectureSections(ctx))
+	var archRelevance []float64
+	if query != "" {
+		archRelevance = scoreQuery(query, nil, nil, nil, archSections).Architecture
+	} else {
+		archRelevance = keywordRelevance(archSections, pkt.Keywords)
+	}
+	addWorkTreeRelevance(archSections, archRelevance, pkt.Changes)
+	var glossary []glossaryTerm
+	for _, g := range keep(profile, sectionGlossary, extractGlossaryTerms(ctx)) {
+		if !seen[contentHash(sectionGlossary, g.String())] {
+			glossary = append(glossary, g)
+		}
+	}
+	pkt.fillReference(
+		max(remaining, 0), glossary, append(allTasks, query),
+		archSections, archRelevance,
+	)
+
+	pkt.TokensUsed = estimateSliceTokens(pkt.Constitution) +
+		estimateSliceTokens(pkt.Tasks) +
+		estimateSliceTokens(pkt.Conventions) +
+		estimateSliceTokens(pkt.Decisions) +
+		estimateSliceTokens(pkt.Learnings) +
+		estimateSliceTokens(pkt.Architecture) +
+		estimateSliceTokens(pkt.Glossary) +
+		estimateSliceTokens(pkt.Summaries)
+
+	return pkt
+}
+
+// isEmpty reports whether a packet has no content sections.
+//
+// Returns:
+//   - bool: True if there is nothing to emit
+func (pkt *assembledPacket) isEmpty() bool {
+	return len(pkt.ReadOrder) == 0 && len(pkt.Constitution) == 0 &&
+		len(pkt.Tasks) == 0 && len(pkt.Conventions) == 0 &&
+		len(pkt.Decisions) == 0 && len(pkt.Learnings) == 0 &&
+		len(pkt.Architecture) == 0 && len(pkt.Glossary) == 0 &&
+		len(pkt.Summaries) == 0
+}
@@ -0,0 +1,209 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// sentSnapshot returns the snapshot of a full packet of ctx.
+func sentSnapshot(ctx *context.Context) *snapshot {
+	return newSnapshot(assembleBudgetPacket(ctx, 4000, "", nil), nil)
+}
+
+func deltaTestContext(tasks, decisions string) *context.Context {
+	return &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{Name: config.FileTask, Content: []byte("# Tasks\n\n" + tasks)},
+			{Name: config.FileDecision, Content: []byte("# Decisions\n\n" + decisions)},
+		},
+	}
+}
+
+const deltaDecision = "## [2026-02-18-120000] Parse hook stdin once\n\n" +
+	"Hooks read stdin a single time.\n"
+
+func TestAssembleDeltaPacket(t *testing.T) {
+	before := deltaTestContext("- [ ] Fix hook parsing\n", deltaDecision)
+	prev := sentSnapshot(before)
+
+	if pkt := assembleDeltaPacket(before, 4000, "", nil, prev); !pkt.isEmpty() {
+		t.Errorf("unchanged context should give an empty delta: %+v", pkt)
+	}
+
+	after := deltaTestContext(
+		"- [ ] Fix hook parsing\n- [ ] Add delta mode\n",
+		"## [2026-02-19-120000] Tombstone stores hashes\n\n"+
+			"So deltas can be computed.\n\n"+deltaDecision,
+	)
+	pkt := assembleDeltaPacket(after, 4000, "", nil, prev)
+
+	if len(pkt.Tasks) != 1 || !strings.Contains(pkt.Tasks[0], "Add delta mode") {
+		t.Errorf("Tasks = %v, want only the new task", pkt.Tasks)
+	}
+	if len(pkt.Decisions) != 1 ||
+		!strings.Contains(pkt.Decisions[0], "Tombstone stores hashes") {
+		t.Errorf("Decisions = %v, want only the new decision", pkt.Decisions)
+	}
+	if len(pkt.ReadOrder) != 0 || pkt.Instruction != "" {
+		t.Error("delta packet should not repeat read order or instruction")
+	}
+
+	md := renderMarkdownPacket(pkt)
+	if !strings.Contains(md, "changes since last packet") ||
+		strings.Contains(md, "Read These Files") {
+		t.Errorf("unexpected delta markdown:\n%s", md)
+	}
+}
+
+func TestAssembleDeltaPacket_ChangedEntry(t *testing.T) {
+	prev := sentSnapshot(deltaTestContext("", deltaDecision))
+	edited := strings.Replace(deltaDecision, "single time", "single time, ever", 1)
+
+	pkt := assembleDeltaPacket(deltaTestContext("", edited), 4000, "", nil, prev)
+	if len(pkt.Decisions) != 1 || !strings.Contains(pkt.Decisions[0], "ever") {
+		t.Errorf("Decisions = %v, want the edited entry", pkt.Decisions)
+	}
+}
+
+func TestAssembleDeltaPacket_Truncated(t *testing.T) {
+	var decisions strings.Builder
+	for _, title := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		decisions.WriteString("## [2026-02-18-120000] Decide " + title + "\n\n" +
+			"**Rationale**: " + strings.Repeat(title+" matters for hook parsing. ", 12) + "\n\n")
+	}
+	ctx := deltaTestContext("- [ ] Fix hook parsing\n", decisions.String())
+
+	first := assembleBudgetPacket(ctx, 300, "", nil)
+	var sent, rest []string
+	tier := make(map[string]Result)
+	for _, e := range first.ScoredDecisions {
+		tier[e.Entry.Title] = e.Result
+		if e.Result == ResultFull {
+			sent = append(sent, e.Entry.Title)
+		} else {
+			rest = append(rest, e.Entry.Title)
+		}
+	}
+	if len(sent) == 0 || len(rest) == 0 {
+		t.Fatalf("the budget should truncate the first packet: full %v, rest %v", sent, rest)
+	}
+	prev := newSnapshot(first, nil)
+
+	// A tight budget: nothing is sent again at the tier it was before
+	resent := 0
+	for _, e := range assembleDeltaPacket(ctx, 150, "", nil, prev).ScoredDecisions {
+		switch {
+		case e.Result == ResultExcluded && strings.HasPrefix(e.Reason, "already sent"):
+			resent++
+		case e.Result != ResultExcluded && tierRank[e.Result] <= tierRank[tier[e.Entry.Title]]:
+			t.Errorf("%q sent as %s again", e.Entry.Title, e.Result)
+		}
+	}
+	if resent == 0 {
+		t.Error("entries sent condensed should not be sent condensed again")
+	}
+
+	pkt := assembleDeltaPacket(ctx, 4000, "", nil, prev)
+	body := strings.Join(pkt.Decisions, "\n")
+	for _, title := range sent {
+		if strings.Contains(body, title) {
+			t.Errorf("%q was sent in full already:\n%s", title, body)
+		}
+	}
+	for _, title := range rest {
+		if !strings.Contains(body, title) {
+			t.Errorf("%q should be delivered now:\n%s", title, body)
+		}
+	}
+	if len(pkt.Tasks) != 0 || len(pkt.Summaries) != 0 {
+		t.Errorf("Tasks = %v, Summaries = %v; want nothing else", pkt.Tasks, pkt.Summaries)
+	}
+
+	if last := assembleDeltaPacket(ctx, 4000, "", nil, newSnapshot(pkt, prev)); !last.isEmpty() {
+		t.Errorf("everything was delivered, want an empty delta: %+v", last)
+	}
+}
+
+func TestRunAgent_Delta(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(config.DirContext, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(config.DirContext, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(config.FileTask, "# Tasks\n\n- [ ] Fix hook parsing\n")
+	writeFile(config.FileDecision, "# Decisions\n\n"+deltaDecision)
+
+	run := func() string {
+		t.Helper()
+		cmd := Cmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--delta", "--session", "test-delta"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("agent --delta failed: %v", err)
+		}
+		return out.String()
+	}
+
+	if first := run(); !strings.Contains(first, "# Context Packet\n") {
+		t.Errorf("first call should print the full packet:\n%s", first)
+	}
+	if second := run(); second != "" {
+		t.Errorf("unchanged context should print nothing, got:\n%s", second)
+	}
+
+	writeFile(config.FileDecision, "# Decisions\n\n"+
+		"## [2026-02-19-120000] Tombstone stores hashes\n\nWhy.\n\n"+deltaDecision)
+	third := run()
+	if !strings.Contains(third, "Tombstone stores hashes") ||
+		strings.Contains(third, "Parse hook stdin once") ||
+		strings.Contains(third, "Fix hook parsing") {
+		t.Errorf("third call should print only the new decision:\n%s", third)
+	}
+}
+
+func TestRunAgent_DeltaRequiresSession(t *testing.T) {
+	cmd := Cmd()
+	cmd.SetArgs([]string{"--delta"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected an error for --delta without --session")
+	}
+}
+
+func TestAssembleDeltaPacket_Glossary(t *testing.T) {
+	withGlossary := func(glossary string) *context.Context {
+		ctx := deltaTestContext("- [ ] Fix hook parsing\n", deltaDecision)
+		ctx.Files = append(ctx.Files, context.FileInfo{
+			Name: config.FileGlossary, Content: []byte("# Glossary\n\n" + glossary),
+		})
+		return ctx
+	}
+	hook := "- **Hook**: A lifecycle script.\n"
+	prev := sentSnapshot(withGlossary(hook))
+
+	pkt := assembleDeltaPacket(withGlossary(hook+"- **Parsing**: Reading hook input.\n"), 4000, "", nil, prev)
+	if len(pkt.Glossary) != 1 || !strings.HasPrefix(pkt.Glossary[0], "**Parsing**") {
+		t.Errorf("Glossary = %v, want only the new parsing term", pkt.Glossary)
+	}
+}
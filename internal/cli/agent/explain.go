@@ -0,0 +1,164 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Result is the outcome of the budget pass for a candidate entry.
+type Result string
+
+const (
+	// ResultPinned means the entry's full body is in the packet because
+	// of a #pin marker, ahead of all scored entries.
+	ResultPinned Result = "pinned"
+	// ResultFull means the entry's full body is in the packet.
+	ResultFull Result = "full"
+	// ResultCondensed means the entry's title and the first sentence of
+	// each field are in the packet.
+	ResultCondensed Result = "condensed"
+	// ResultSummary means only the entry's title is in the packet.
+	ResultSummary Result = "summary"
+	// ResultExcluded means the entry is not in the packet at all.
+	ResultExcluded Result = "excluded"
+)
+
+// Packet section names used in explain output, delta snapshots and
+// profiles.
+const (
+	sectionReadOrder    = config.PacketSectionReadOrder
+	sectionConstitution = config.PacketSectionConstitution
+	sectionTasks        = config.PacketSectionTasks
+	sectionConventions  = config.PacketSectionConventions
+	sectionDecisions    = config.PacketSectionDecisions
+	sectionLearnings    = config.PacketSectionLearnings
+	sectionArchitecture = config.PacketSectionArchitecture
+	sectionGlossary     = config.PacketSectionGlossary
+)
+
+// explainPacket describes how every candidate entry was handled.
+//
+// Parameters:
+//   - pkt: Assembled packet (with scored candidates)
+//
+// Returns:
+//   - *Explanation: Per-entry scores, budgets and outcomes
+func explainPacket(pkt *assembledPacket) *Explanation {
+	exp := &Explanation{
+		Generated:      time.Now().UTC().Format(time.RFC3339),
+		Budget:         pkt.Budget,
+		TokensUsed:     pkt.TokensUsed,
+		Query:          pkt.Query,
+		Keywords:       pkt.Keywords,
+		DecisionBudget: pkt.DecisionBudget,
+		LearningBudget: pkt.LearningBudget,
+		Pinned:         pinnedTitles(pkt),
+		ChangedFiles:   pkt.Changes.count(),
+		Entries:        []ExplainEntry{},
+	}
+
+	add := func(section string, entries []ScoredEntry, budget int) {
+		for i := range entries {
+			e := &entries[i]
+			exp.Entries = append(exp.Entries, ExplainEntry{
+				Section:       section,
+				Timestamp:     e.Entry.Timestamp,
+				Title:         e.Entry.Title,
+				Score:         e.Score,
+				Recency:       e.Recency,
+				Relevance:     e.Relevance,
+				Matched:       e.Matched,
+				WorkTree:      e.WorkTree,
+				Paths:         e.Paths,
+				Superseded:    e.IsSuperseded(),
+				Pinned:        e.Entry.Pinned,
+				NoPacket:      e.Entry.NoPacket,
+				Tokens:        e.Tokens,
+				SectionBudget: budget,
+				Result:        e.Result,
+				Reason:        e.Reason,
+			})
+		}
+	}
+	add(sectionDecisions, pkt.ScoredDecisions, pkt.DecisionBudget)
+	add(sectionLearnings, pkt.ScoredLearnings, pkt.LearningBudget)
+
+	return exp
+}
+
+// renderMarkdownExplain renders an explanation as a Markdown table.
+//
+// Parameters:
+//   - exp: Explanation to render
+//
+// Returns:
+//   - string: Formatted Markdown output
+func renderMarkdownExplain(exp *Explanation) string {
+	var sb strings.Builder
+	nl := config.NewlineLF
+
+	sb.WriteString("# Context Packet Explain" + nl)
+	sb.WriteString(fmt.Sprintf(
+		"Budget: %d tokens | Used: ~%d | Decisions budget: %d | "+
+			"Learnings budget: %d",
+		exp.Budget, exp.TokensUsed, exp.DecisionBudget, exp.LearningBudget,
+	) + nl)
+	if exp.Query != "" {
+		sb.WriteString(fmt.Sprintf("Relevance: BM25 against query %q", exp.Query) + nl)
+	} else {
+		sb.WriteString(fmt.Sprintf(
+			"Relevance: task keywords (%s)", strings.Join(exp.Keywords, ", "),
+		) + nl)
+	}
+	sb.WriteString(fmt.Sprintf(
+		"Working tree: %d changed files", exp.ChangedFiles,
+	) + nl)
+	if len(exp.Pinned) > 0 {
+		sb.WriteString("Pinned: " + strings.Join(exp.Pinned, "; ") + nl)
+	}
+	sb.WriteString(nl)
+
+	if len(exp.Entries) == 0 {
+		sb.WriteString("No candidate entries in DECISIONS.md or LEARNINGS.md." + nl)
+		return sb.String()
+	}
+
+	sb.WriteString("| Section | Entry | Recency | Relevance | Git | Score | " +
+		"Matched | Superseded | Tokens | Budget | Result | Reason |" + nl)
+	sb.WriteString("|---|---|---|---|---|---|---|---|---|---|---|---|" + nl)
+	for _, e := range exp.Entries {
+		superseded := "no"
+		if e.Superseded {
+			superseded = "yes"
+		}
+		sb.WriteString(fmt.Sprintf(
+			"| %s | %s | %.2f | %.2f | %.2f | %.2f | %s | %s | %d | %d | %s | %s |",
+			e.Section, escapeCell(e.Title), e.Recency, e.Relevance, e.WorkTree,
+			e.Score, escapeCell(strings.Join(append(e.Matched, e.Paths...), ", ")),
+			superseded, e.Tokens,
+			e.SectionBudget, e.Result, escapeCell(e.Reason),
+		) + nl)
+	}
+
+	return sb.String()
+}
+
+// escapeCell makes text safe for a Markdown table cell.
+//
+// Parameters:
+//   - s: Cell text
+//
+// Returns:
+//   - string: Text with pipe characters escaped
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,129 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+func explainTestContext() *context.Context {
+	return &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name:    config.FileTask,
+				Content: []byte("# Tasks\n\n- [ ] Fix hook stdin parsing\n"),
+			},
+			{
+				Name: config.FileDecision,
+				Content: []byte("# Decisions\n\n" +
+					"## [2026-02-18-120000] Parse hook stdin once\n\n" +
+					"Hooks read stdin a single time.\n\n" +
+					"## [2026-02-17-120000] Old approach\n\n" +
+					"~~Superseded by newer entry~~\n"),
+			},
+			{
+				Name: config.FileLearning,
+				Content: []byte("# Learnings\n\n" +
+					"## [2026-02-16-120000] Journal export\n\n" +
+					"Site export is slow.\n"),
+			},
+		},
+	}
+}
+
+func TestExplainPacket(t *testing.T) {
+	exp := explainPacket(assembleBudgetPacket(explainTestContext(), 8000, "", nil))
+
+	if len(exp.Entries) != 3 {
+		t.Fatalf("Entries = %d, want 3", len(exp.Entries))
+	}
+	if exp.DecisionBudget == 0 || exp.LearningBudget == 0 {
+		t.Errorf("section budgets = %d/%d, want non-zero",
+			exp.DecisionBudget, exp.LearningBudget)
+	}
+
+	byTitle := make(map[string]ExplainEntry)
+	for _, e := range exp.Entries {
+		byTitle[e.Title] = e
+	}
+
+	parse := byTitle["Parse hook stdin once"]
+	if parse.Section != sectionDecisions || parse.Result != ResultFull {
+		t.Errorf("parse entry = %+v, want full decision", parse)
+	}
+	if len(parse.Matched) == 0 || parse.Relevance == 0 {
+		t.Errorf("parse entry should match task keywords: %+v", parse)
+	}
+	if parse.SectionBudget != exp.DecisionBudget {
+		t.Errorf("SectionBudget = %d, want %d",
+			parse.SectionBudget, exp.DecisionBudget)
+	}
+
+	old := byTitle["Old approach"]
+	if !old.Superseded || old.Result != ResultExcluded || old.Reason != "superseded" {
+		t.Errorf("superseded entry = %+v", old)
+	}
+
+	if byTitle["Journal export"].Section != sectionLearnings {
+		t.Errorf("learning section = %q", byTitle["Journal export"].Section)
+	}
+}
+
+func TestExplainPacket_BudgetExhausted(t *testing.T) {
+	exp := explainPacket(assembleBudgetPacket(explainTestContext(), 1, "", nil))
+
+	for _, e := range exp.Entries {
+		if e.Superseded {
+			// Superseded entries keep their own reason
+			continue
+		}
+		if e.Result != ResultExcluded || !strings.HasPrefix(e.Reason, "budget exhausted") {
+			t.Errorf("entry %q = %s (%s), want excluded by exhausted budget",
+				e.Title, e.Result, e.Reason)
+		}
+	}
+}
+
+func TestExplainJSON(t *testing.T) {
+	exp := explainPacket(assembleBudgetPacket(explainTestContext(), 8000, "stdin", nil))
+
+	data, err := json.Marshal(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, key := range []string{
+		`"query":"stdin"`, `"decision_budget"`, `"section_budget"`,
+		`"superseded":true`, `"result":"full"`, `"matched":["stdin"]`,
+	} {
+		if !strings.Contains(out, key) {
+			t.Errorf("JSON missing %s: %s", key, out)
+		}
+	}
+}
+
+func TestRenderMarkdownExplain(t *testing.T) {
+	exp := explainPacket(assembleBudgetPacket(explainTestContext(), 8000, "", nil))
+	md := renderMarkdownExplain(exp)
+
+	for _, want := range []string{
+		"# Context Packet Explain",
+		"| Section | Entry |",
+		"| decisions | Parse hook stdin once |",
+		"superseded",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
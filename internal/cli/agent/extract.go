@@ -7,6 +7,7 @@
 package agent
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -95,6 +96,9 @@ func extractUncheckedTasks(content string) []string {
 
 // extractActiveTasks extracts unchecked task items from TASKS.md.
 //
+// In a layered context, tasks from every layer are included, nearest
+// layer first.
+//
 // Parameters:
 //   - ctx: Loaded context containing the files
 //
@@ -102,8 +106,120 @@ func extractUncheckedTasks(content string) []string {
 //   - []string: List of active tasks with "- [ ]" prefix; nil if
 //     the file is not found
 func extractActiveTasks(ctx *context.Context) []string {
-	if f := ctx.File(config.FileTask); f != nil {
-		return extractUncheckedTasks(string(f.Content))
+	var items []string
+	for _, f := range ctx.FileLayers(config.FileTask) {
+		items = append(items, extractUncheckedTasks(string(f.Content))...)
 	}
-	return nil
+	return items
+}
+
+// extractNextTask returns the task "ctx tasks next" would pick.
+//
+// Only the nearest layer's TASKS.md is ranked, like "ctx tasks next".
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//
+// Returns:
+//   - string: Content of the best ready task; empty if there is none
+func extractNextTask(ctx *context.Context) string {
+	f := ctx.File(config.FileTask)
+	if f == nil {
+		return ""
+	}
+	dir := f.Layer
+	if dir == "" {
+		dir = ctx.Dir
+	}
+	// An unreadable archive only means fewer dependencies count as done
+	archived, _ := task.ParseArchive(filepath.Join(dir, config.DirArchive))
+
+	ranked := task.Rank(
+		task.Parse(strings.Split(string(f.Content), config.NewlineLF)),
+		task.IDs(archived),
+	)
+	if len(ranked) == 0 || !ranked[0].Ready() {
+		return ""
+	}
+	return ranked[0].Task.Content
+}
+
+// extractSections splits a Markdown document into sections at level-2
+// and deeper headings.
+//
+// Headings inside fenced code blocks are ignored. Text before the first
+// such heading (typically the H1 title and intro) is not returned.
+//
+// Parameters:
+//   - content: Markdown content to split
+//
+// Returns:
+//   - []string: Section texts, each starting with its heading line
+func extractSections(content string) []string {
+	var sections []string
+	var current []string
+	inFence := false
+
+	flush := func() {
+		if len(current) > 0 {
+			text := strings.TrimSpace(strings.Join(current, config.NewlineLF))
+			if text != "" {
+				sections = append(sections, text)
+			}
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(content, config.NewlineLF) {
+		if config.RegExFenceLine.MatchString(line) {
+			inFence = !inFence
+		}
+		m := config.RegExMarkdownHeading.FindStringSubmatch(line)
+		if !inFence && m != nil && len(m[1]) >= 2 {
+			flush()
+			current = []string{line}
+			continue
+		}
+		if current != nil {
+			current = append(current, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// extractArchitectureSections splits ARCHITECTURE.md into sections.
+//
+// In a layered context, sections from every layer are included, nearest
+// layer first.
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//
+// Returns:
+//   - []string: Section texts; nil if the file is not found
+func extractArchitectureSections(ctx *context.Context) []string {
+	var sections []string
+	for _, f := range ctx.FileLayers(config.FileArchitecture) {
+		sections = append(sections, extractSections(string(f.Content))...)
+	}
+	return sections
+}
+
+// extractGlossaryTerms parses GLOSSARY.md into terms.
+//
+// In a layered context, terms from every layer are included, nearest
+// layer first.
+//
+// Parameters:
+//   - ctx: Loaded context containing the files
+//
+// Returns:
+//   - []glossaryTerm: Parsed terms; nil if the file is not found
+func extractGlossaryTerms(ctx *context.Context) []glossaryTerm {
+	var terms []glossaryTerm
+	for _, f := range ctx.FileLayers(config.FileGlossary) {
+		terms = append(terms, parseGlossary(string(f.Content))...)
+	}
+	return terms
 }
@@ -0,0 +1,212 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"os/exec"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// mergeBaseRefs are tried in order to find the branch point of the
+// current work.
+var mergeBaseRefs = []string{"@{upstream}", "origin/HEAD", "main", "master"}
+
+// workTree is what the user is currently changing in git.
+//
+// Fields:
+//   - Paths: Changed file paths, relative to the repository root
+//   - Dirs: Directories containing changed files
+//   - Packages: Package names (last element of each directory)
+type workTree struct {
+	Paths    map[string]bool
+	Dirs     map[string]bool
+	Packages map[string]bool
+}
+
+// readWorkTree collects files changed in the working tree and on the
+// current branch.
+//
+// Combines `git status` (staged, unstaged and untracked files) with
+// `git diff` against the merge base of HEAD and its upstream (or the
+// default branch). Errors are swallowed: outside a git repository, or
+// without git, there is simply no working-tree boost.
+//
+// Parameters:
+//   - dir: Directory inside the repository
+//
+// Returns:
+//   - *workTree: Changed paths; nil if nothing changed or git failed
+func readWorkTree(dir string) *workTree {
+	var changed []string
+
+	status, err := gitOutput(
+		dir, "status", "--porcelain", "-z", "--untracked-files=all",
+	)
+	if err != nil {
+		return nil
+	}
+	changed = append(changed, parsePorcelain(status)...)
+
+	for _, ref := range mergeBaseRefs {
+		base, baseErr := gitOutput(dir, "merge-base", "HEAD", ref)
+		if baseErr != nil {
+			continue
+		}
+		diff, diffErr := gitOutput(
+			dir, "diff", "--name-only", "-z", strings.TrimSpace(base),
+		)
+		if diffErr == nil {
+			changed = append(changed, splitNull(diff)...)
+		}
+		break
+	}
+
+	return newWorkTree(changed)
+}
+
+// gitOutput runs a git command in a directory.
+//
+// Parameters:
+//   - dir: Directory to run git in
+//   - args: git arguments
+//
+// Returns:
+//   - string: Standard output
+//   - error: Non-nil if git is missing or the command fails
+func gitOutput(dir string, args ...string) (string, error) {
+	out, err := exec.Command(
+		"git", append([]string{"-C", dir}, args...)...,
+	).Output()
+	return string(out), err
+}
+
+// parsePorcelain extracts paths from `git status --porcelain -z`.
+//
+// Each record is "XY path"; renames and copies are followed by a
+// second record holding the original path, which is skipped.
+//
+// Parameters:
+//   - out: Command output
+//
+// Returns:
+//   - []string: Changed paths
+func parsePorcelain(out string) []string {
+	var paths []string
+	records := splitNull(out)
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if len(rec) < 4 {
+			continue
+		}
+		paths = append(paths, rec[3:])
+		if rec[0] == 'R' || rec[0] == 'C' {
+			i++
+		}
+	}
+	return paths
+}
+
+// splitNull splits NUL-separated command output.
+//
+// Parameters:
+//   - out: Command output
+//
+// Returns:
+//   - []string: Non-empty fields
+func splitNull(out string) []string {
+	var fields []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// newWorkTree indexes changed paths by path, directory and package.
+//
+// Parameters:
+//   - paths: Changed file paths (slash-separated)
+//
+// Returns:
+//   - *workTree: Index of the changes; nil if paths is empty
+func newWorkTree(paths []string) *workTree {
+	if len(paths) == 0 {
+		return nil
+	}
+	wt := &workTree{
+		Paths:    make(map[string]bool),
+		Dirs:     make(map[string]bool),
+		Packages: make(map[string]bool),
+	}
+	for _, p := range paths {
+		p = path.Clean(p)
+		wt.Paths[p] = true
+		if dir := path.Dir(p); dir != "." {
+			wt.Dirs[dir] = true
+			wt.Packages[path.Base(dir)] = true
+		}
+	}
+	return wt
+}
+
+// count returns the number of changed files.
+//
+// Returns:
+//   - int: Changed file count; 0 for a nil workTree
+func (wt *workTree) count() int {
+	if wt == nil {
+		return 0
+	}
+	return len(wt.Paths)
+}
+
+// matches reports whether a backticked reference points at a change.
+//
+// A reference matches when it is a changed path (or a trailing part of
+// one), a directory with changes, a file in such a directory, a
+// changed package name, or an exported symbol of one ("parser.Parse").
+//
+// Parameters:
+//   - ref: Text inside backticks
+//
+// Returns:
+//   - bool: True if the reference touches the working tree changes
+func (wt *workTree) matches(ref string) bool {
+	ref = strings.TrimSuffix(strings.TrimPrefix(ref, "./"), "/")
+	if ref == "" || strings.ContainsAny(ref, " \t") {
+		return false
+	}
+
+	if wt.Paths[ref] || wt.Dirs[ref] || wt.Packages[ref] {
+		return true
+	}
+	for p := range wt.Paths {
+		if strings.HasSuffix(p, "/"+ref) {
+			return true
+		}
+	}
+	if strings.Contains(ref, "/") {
+		if wt.Dirs[path.Dir(ref)] {
+			return true
+		}
+		for d := range wt.Dirs {
+			if strings.HasSuffix(d, "/"+ref) {
+				return true
+			}
+		}
+		return false
+	}
+
+	pkg, symbol, ok := strings.Cut(ref, ".")
+	if !ok || symbol == "" || !wt.Packages[pkg] {
+		return false
+	}
+	return unicode.IsUpper([]rune(symbol)[0])
+}
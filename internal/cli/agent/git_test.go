@@ -0,0 +1,138 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePorcelain(t *testing.T) {
+	out := " M internal/cli/agent/score.go\x00" +
+		"R  internal/new.go\x00internal/old.go\x00" +
+		"?? docs/new.md\x00"
+	got := parsePorcelain(out)
+	want := "internal/cli/agent/score.go,internal/new.go,docs/new.md"
+	if strings.Join(got, ",") != want {
+		t.Errorf("parsePorcelain() = %v, want %s", got, want)
+	}
+}
+
+func TestWorkTree_Matches(t *testing.T) {
+	wt := newWorkTree([]string{"internal/recall/parser/git.go", "README.md"})
+
+	tests := map[string]bool{
+		"internal/recall/parser/git.go":   true,
+		"internal/recall/parser":          true,
+		"internal/recall/parser/":         true,
+		"internal/recall/parser/parse.go": true,
+		"recall/parser":                   true,
+		"parser/git.go":                   true,
+		"git.go":                          true,
+		"parser":                          true,
+		"parser.ParseFile":                true,
+		"README.md":                       true,
+		"parser.unexported":               false,
+		"internal/recall":                 false,
+		"internal":                        false,
+		"ctx agent --budget":              false,
+		"drift":                           false,
+	}
+	for ref, want := range tests {
+		if got := wt.matches(ref); got != want {
+			t.Errorf("matches(%q) = %v, want %v", ref, got, want)
+		}
+	}
+
+	if newWorkTree(nil) != nil {
+		t.Error("newWorkTree(nil) should be nil")
+	}
+}
+
+func TestAddWorkTreeScores(t *testing.T) {
+	blocks := []ScoredEntry{
+		newScoredEntry(makeBlock("2026-02-19", "Unrelated", "token budget"), 1.0),
+		newScoredEntry(makeBlock("2025-10-01", "Parser learning",
+			"In `internal/recall/parser`, `parser.Parse` skips empty lines."), 0.2),
+		newScoredEntry(makeBlock("2025-10-01", "Superseded",
+			"`parser` ~~Superseded by newer~~"), 0.0),
+	}
+	addWorkTreeScores(blocks, newWorkTree([]string{"internal/recall/parser/git.go"}))
+
+	if blocks[0].Entry.Title != "Parser learning" {
+		t.Fatalf("expected the parser learning first, got %q", blocks[0].Entry.Title)
+	}
+	if blocks[0].WorkTree != 1.0 || len(blocks[0].Paths) != 2 {
+		t.Errorf("WorkTree = %v, Paths = %v", blocks[0].WorkTree, blocks[0].Paths)
+	}
+	for _, b := range blocks {
+		if b.Entry.Title == "Superseded" && b.Score != 0 {
+			t.Errorf("superseded entry score = %v, want 0", b.Score)
+		}
+	}
+}
+
+func TestReadWorkTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{
+			"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q", "-b", "main")
+	write("pkg/a/a.go")
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+
+	if wt := readWorkTree(dir); wt != nil {
+		t.Errorf("clean tree should give nil, got %+v", wt.Paths)
+	}
+
+	git("checkout", "-q", "-b", "feature")
+	write("pkg/b/b.go")
+	git("add", ".")
+	git("commit", "-q", "-m", "feature")
+	write("pkg/c/c.go")
+
+	wt := readWorkTree(dir)
+	if wt == nil {
+		t.Fatal("readWorkTree() = nil")
+	}
+	for _, p := range []string{"pkg/b/b.go", "pkg/c/c.go"} {
+		if !wt.Paths[p] {
+			t.Errorf("missing changed path %s in %v", p, wt.Paths)
+		}
+	}
+	if wt.Paths["pkg/a/a.go"] {
+		t.Error("unchanged file reported as changed")
+	}
+
+	if readWorkTree(t.TempDir()) != nil {
+		t.Error("non-repository should give nil")
+	}
+}
@@ -0,0 +1,211 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// glossaryTerm is one definition from GLOSSARY.md.
+//
+// Fields:
+//   - Term: Term as written (e.g., "Claim (JWT)")
+//   - Definition: Definition text, joined onto one line
+type glossaryTerm struct {
+	Term       string
+	Definition string
+}
+
+// String renders the term the way GLOSSARY.md bullets are written.
+//
+// Returns:
+//   - string: "**Term**: Definition"
+func (g glossaryTerm) String() string {
+	if g.Definition == "" {
+		return "**" + g.Term + "**"
+	}
+	return "**" + g.Term + "**: " + g.Definition
+}
+
+// names returns the spellings that count as a mention of the term.
+//
+// A parenthesized part is treated as an alias: "Claim (JWT)" is
+// mentioned by "claim" and by "JWT".
+//
+// Returns:
+//   - []string: Non-empty spellings
+func (g glossaryTerm) names() []string {
+	base, alias, ok := strings.Cut(g.Term, "(")
+	if !ok {
+		return []string{strings.TrimSpace(g.Term)}
+	}
+	names := []string{strings.TrimSpace(base)}
+	if alias = strings.TrimSpace(strings.TrimSuffix(
+		strings.TrimSpace(alias), ")",
+	)); alias != "" {
+		names = append(names, alias)
+	}
+	return names
+}
+
+// mentionPattern builds a pattern matching any of the term's names as a
+// whole word, case-insensitively, with an optional plural suffix.
+//
+// Returns:
+//   - *regexp.Regexp: Compiled pattern; nil if the term has no name
+func (g glossaryTerm) mentionPattern() *regexp.Regexp {
+	var alts []string
+	for _, n := range g.names() {
+		if n != "" {
+			alts = append(alts, regexp.QuoteMeta(n))
+		}
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(
+		`(?i)(?:^|[^\w])(?:` + strings.Join(alts, "|") + `)(?:e?s)?(?:[^\w]|$)`,
+	)
+}
+
+// parseGlossary splits GLOSSARY.md into terms.
+//
+// Two layouts are recognized, and may be mixed:
+//   - Bullets: "- **Term**: definition", continued on indented lines
+//   - Tables: "| Term | Definition |" rows; a header row (the row
+//     above a separator) is skipped
+//
+// Headings, comments and other prose are ignored.
+//
+// Parameters:
+//   - content: GLOSSARY.md content
+//
+// Returns:
+//   - []glossaryTerm: Terms in file order
+func parseGlossary(content string) []glossaryTerm {
+	var terms []glossaryTerm
+	var current *glossaryTerm
+	inComment := false
+
+	flush := func() {
+		if current != nil && current.Term != "" {
+			current.Definition = strings.TrimSpace(current.Definition)
+			terms = append(terms, *current)
+		}
+		current = nil
+	}
+
+	lines := strings.Split(content, config.NewlineLF)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if inComment {
+			inComment = !strings.Contains(trimmed, "-->")
+			continue
+		}
+		if strings.HasPrefix(trimmed, "<!--") {
+			flush()
+			inComment = !strings.Contains(trimmed, "-->")
+			continue
+		}
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			flush()
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			if config.RegExTableSeparator.MatchString(trimmed) {
+				continue
+			}
+			if i+1 < len(lines) &&
+				config.RegExTableSeparator.MatchString(lines[i+1]) {
+				continue
+			}
+			if term, ok := parseGlossaryRow(trimmed); ok {
+				terms = append(terms, term)
+			}
+		case config.RegExGlossaryTerm.MatchString(line):
+			flush()
+			m := config.RegExGlossaryTerm.FindStringSubmatch(line)
+			current = &glossaryTerm{
+				Term:       strings.TrimSpace(strings.TrimSuffix(m[1], ":")),
+				Definition: m[2],
+			}
+		case current != nil:
+			current.Definition += " " + trimmed
+		}
+	}
+	flush()
+
+	return terms
+}
+
+// parseGlossaryRow reads a term from a Markdown table row.
+//
+// The first cell is the term; the remaining cells are joined into the
+// definition. Bold markers around the term are removed.
+//
+// Parameters:
+//   - row: Trimmed table row starting with "|"
+//
+// Returns:
+//   - glossaryTerm: Parsed term
+//   - bool: False if the row has no term or no definition
+func parseGlossaryRow(row string) (glossaryTerm, bool) {
+	cells := strings.Split(strings.Trim(row, "|"), "|")
+	if len(cells) < 2 {
+		return glossaryTerm{}, false
+	}
+	term := strings.Trim(strings.TrimSpace(cells[0]), "*")
+	var def []string
+	for _, c := range cells[1:] {
+		if c = strings.TrimSpace(c); c != "" {
+			def = append(def, c)
+		}
+	}
+	if term == "" || len(def) == 0 {
+		return glossaryTerm{}, false
+	}
+	return glossaryTerm{Term: term, Definition: strings.Join(def, " ")}, true
+}
+
+// glossaryStrings renders terms with glossaryTerm.String.
+//
+// Parameters:
+//   - terms: Terms to render
+//
+// Returns:
+//   - []string: One "**Term**: Definition" string per term
+func glossaryStrings(terms []glossaryTerm) []string {
+	items := make([]string, 0, len(terms))
+	for _, g := range terms {
+		items = append(items, g.String())
+	}
+	return items
+}
+
+// mentionedTerms returns the glossary terms that appear in any text.
+//
+// Parameters:
+//   - terms: Candidate terms
+//   - texts: Texts to search (tasks, packet entries, the query)
+//
+// Returns:
+//   - []glossaryTerm: Mentioned terms, in glossary order
+func mentionedTerms(terms []glossaryTerm, texts []string) []glossaryTerm {
+	joined := strings.Join(texts, config.NewlineLF)
+	var found []glossaryTerm
+	for _, g := range terms {
+		if re := g.mentionPattern(); re != nil && re.MatchString(joined) {
+			found = append(found, g)
+		}
+	}
+	return found
+}
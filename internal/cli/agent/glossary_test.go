@@ -0,0 +1,128 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+const testGlossary = `# Glossary
+
+<!--
+- **Commented**: Not a term.
+-->
+
+## Terms
+
+- **Claim (JWT)**: A key-value pair embedded in a JWT token
+  that carries user identity.
+
+- **Handler**: A function that processes a request.
+
+## Abbreviations
+
+| Abbreviation | Expansion                  |
+|--------------|----------------------------|
+| rc           | Runtime configuration      |
+| **CWD**      | Current working directory  |
+`
+
+func TestParseGlossary(t *testing.T) {
+	got := parseGlossary(testGlossary)
+
+	want := []glossaryTerm{
+		{Term: "Claim (JWT)", Definition: "A key-value pair embedded in a JWT token that carries user identity."},
+		{Term: "Handler", Definition: "A function that processes a request."},
+		{Term: "rc", Definition: "Runtime configuration"},
+		{Term: "CWD", Definition: "Current working directory"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseGlossary() = %+v, want %d terms", got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("term %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if s := got[1].String(); s != "**Handler**: A function that processes a request." {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestMentionedTerms(t *testing.T) {
+	terms := parseGlossary(testGlossary)
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plural", "- [ ] Split the handlers", "Handler"},
+		{"alias", "Validate the JWT expiry", "Claim (JWT)"},
+		{"case-insensitive", "log the cwd", "CWD"},
+		{"whole word only", "the source tree", ""},
+		{"no mention", "nothing relevant", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, g := range mentionedTerms(terms, []string{tt.text}) {
+				names = append(names, g.Term)
+			}
+			if got := strings.Join(names, ","); got != tt.want {
+				t.Errorf("mentionedTerms(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssembleBudgetPacket_Reference(t *testing.T) {
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name:    config.FileTask,
+				Content: []byte("# Tasks\n\n- [ ] Add rate limiting to the handler\n"),
+			},
+			{Name: config.FileGlossary, Content: []byte(testGlossary)},
+			{
+				Name: config.FileArchitecture,
+				Content: []byte("# Architecture\n\n## Request Flow\n\n" +
+					"Middleware applies rate limiting before each handler.\n\n" +
+					"## Journal\n\nSite export.\n"),
+			},
+		},
+	}
+
+	pkt := assembleBudgetPacket(ctx, 8000, "", nil)
+
+	if len(pkt.Glossary) != 1 || !strings.HasPrefix(pkt.Glossary[0], "**Handler**") {
+		t.Errorf("Glossary = %v, want only the handler term", pkt.Glossary)
+	}
+	if len(pkt.Architecture) != 1 ||
+		!strings.HasPrefix(pkt.Architecture[0], "## Request Flow") {
+		t.Errorf("Architecture = %v, want only the request flow section", pkt.Architecture)
+	}
+
+	md := renderMarkdownPacket(pkt)
+	if !strings.Contains(md, "## Glossary\n- **Handler**: ") ||
+		!strings.Contains(md, "## Relevant Architecture\n## Request Flow") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+
+	// A budget spent by earlier tiers leaves nothing for either section
+	tight := assembleBudgetPacket(ctx, 30, "", nil)
+	if len(tight.Glossary) != 0 || len(tight.Architecture) != 0 {
+		t.Errorf("tight budget: Glossary = %v, Architecture = %v",
+			tight.Glossary, tight.Architecture)
+	}
+}
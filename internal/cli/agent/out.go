@@ -12,35 +12,37 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 // outputAgentJSON writes the context packet as pretty-printed JSON.
 //
-// Uses budget-aware assembly to score entries and respect the token budget.
-//
 // Parameters:
 //   - cmd: Cobra command for output stream
-//   - ctx: Loaded context containing the files
-//   - budget: Token budget for content selection
+//   - pkt: Assembled packet (full or delta)
 //
 // Returns:
 //   - error: Non-nil if JSON encoding fails
-func outputAgentJSON(
-	cmd *cobra.Command, ctx *context.Context, budget int,
-) error {
-	pkt := assembleBudgetPacket(ctx, budget)
-
+func outputAgentJSON(cmd *cobra.Command, pkt *assembledPacket) error {
 	packet := Packet{
 		Generated:    time.Now().UTC().Format(time.RFC3339),
+		Delta:        pkt.Delta,
 		Budget:       pkt.Budget,
 		TokensUsed:   pkt.TokensUsed,
+		Query:        pkt.Query,
+		Profile:      profileName(pkt.Profile),
+		Layers:       pkt.Layers,
 		ReadOrder:    pkt.ReadOrder,
 		Constitution: pkt.Constitution,
 		Tasks:        pkt.Tasks,
+		Next:         pkt.Next,
 		Conventions:  pkt.Conventions,
 		Decisions:    pkt.Decisions,
 		Learnings:    pkt.Learnings,
+		Architecture: pkt.Architecture,
+		Glossary:     pkt.Glossary,
 		Summaries:    pkt.Summaries,
 		Instruction:  pkt.Instruction,
 	}
@@ -52,21 +54,62 @@ func outputAgentJSON(
 
 // outputAgentMarkdown writes the context packet as formatted Markdown.
 //
-// Uses budget-aware assembly to score entries and respect the token budget.
 // Output includes sections for constitution, tasks, conventions,
-// decisions (full body), learnings (full body), and title-only summaries.
+// decisions and learnings (full or condensed), and title-only summaries.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - pkt: Assembled packet (full or delta)
+//
+// Returns:
+//   - error: Always nil (included for interface consistency)
+func outputAgentMarkdown(cmd *cobra.Command, pkt *assembledPacket) error {
+	cmd.Print(renderMarkdownPacket(pkt))
+	return nil
+}
+
+// outputExplain writes the --explain report instead of the packet.
 //
 // Parameters:
 //   - cmd: Cobra command for output stream
 //   - ctx: Loaded context containing the files
 //   - budget: Token budget for content selection
+//   - query: Free-text query for ranking (empty for task keywords)
+//   - profile: Agent profile from .ctxrc (nil for the default packet)
+//   - format: "json" for JSON, anything else for a Markdown table
 //
 // Returns:
-//   - error: Always nil (included for interface consistency)
-func outputAgentMarkdown(
-	cmd *cobra.Command, ctx *context.Context, budget int,
+//   - error: Non-nil if JSON encoding fails
+func outputExplain(
+	cmd *cobra.Command,
+	ctx *context.Context,
+	budget int,
+	query string,
+	profile *rc.AgentProfile,
+	format string,
 ) error {
-	pkt := assembleBudgetPacket(ctx, budget)
-	cmd.Print(renderMarkdownPacket(pkt))
+	exp := explainPacket(assembleBudgetPacket(ctx, budget, query, profile))
+
+	if format == config.FormatJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(exp)
+	}
+
+	cmd.Print(renderMarkdownExplain(exp))
 	return nil
 }
+
+// profileName returns the name of an agent profile.
+//
+// Parameters:
+//   - profile: Agent profile, or nil
+//
+// Returns:
+//   - string: Profile name; empty for nil
+func profileName(profile *rc.AgentProfile) string {
+	if profile == nil {
+		return ""
+	}
+	return profile.Name
+}
@@ -0,0 +1,103 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// isPinned reports whether an entry is always included in packets.
+//
+// #nopacket wins over #pin when an entry carries both.
+//
+// Parameters:
+//   - eb: Entry block to check
+//
+// Returns:
+//   - bool: True if the header has #pin and not #nopacket
+func isPinned(eb *index.EntryBlock) bool {
+	return eb.Entry.Pinned && !eb.Entry.NoPacket
+}
+
+// pinnedBodies returns the full bodies of pinned entries.
+//
+// Parameters:
+//   - blocks: Parsed entry blocks
+//
+// Returns:
+//   - []string: Bodies of pinned entries, in file order
+func pinnedBodies(blocks []index.EntryBlock) []string {
+	var bodies []string
+	for i := range blocks {
+		if isPinned(&blocks[i]) {
+			bodies = append(bodies, blocks[i].BlockContent())
+		}
+	}
+	return bodies
+}
+
+// markPinned records the outcome of pinned candidates, which are
+// reserved before section budgets and skipped by budgetSection.
+//
+// Parameters:
+//   - entries: Scored entries to annotate in place
+func markPinned(entries []ScoredEntry) {
+	for i := range entries {
+		if isPinned(&entries[i].EntryBlock) {
+			entries[i].Result = ResultPinned
+			entries[i].Reason = "#pin: reserved before section budgets"
+		}
+	}
+}
+
+// splitConventions separates pinned convention bullets from the rest.
+//
+// Packet markers are stripped from the text; #nopacket bullets are
+// dropped.
+//
+// Parameters:
+//   - items: Convention bullet items
+//
+// Returns:
+//   - []string: Pinned conventions
+//   - []string: Conventions that compete for the budget
+func splitConventions(items []string) ([]string, []string) {
+	var pinned, rest []string
+	for _, item := range items {
+		text, pin, noPacket := index.ParsePacketMarkers(item)
+		switch {
+		case noPacket:
+		case pin:
+			pinned = append(pinned, text)
+		default:
+			rest = append(rest, text)
+		}
+	}
+	return pinned, rest
+}
+
+// pinnedTitles lists everything pinned in a packet.
+//
+// Parameters:
+//   - pkt: Assembled packet with scored candidates
+//
+// Returns:
+//   - []string: Titles of pinned decisions, then learnings, then the
+//     text of pinned conventions
+func pinnedTitles(pkt *assembledPacket) []string {
+	var titles []string
+	for _, entries := range [][]ScoredEntry{
+		pkt.ScoredDecisions, pkt.ScoredLearnings,
+	} {
+		for i := range entries {
+			if entries[i].Result == ResultPinned {
+				titles = append(titles, entries[i].Entry.Title)
+			}
+		}
+	}
+	return append(titles, pkt.PinnedConventions...)
+}
@@ -0,0 +1,84 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+func pinTestContext() *context.Context {
+	return &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name: config.FileConvention,
+				Content: []byte("# Conventions\n\n- Use tabs #pin\n" +
+					"- Old rule #nopacket\n- Use fatih/color\n"),
+			},
+			{
+				Name: config.FileDecision,
+				Content: []byte("# Decisions\n\n" +
+					"## [2026-02-18-120000] Recent decision\n\nBody.\n\n" +
+					"## [2025-01-01-120000] Core invariant #pin\n\n" +
+					strings.Repeat("Never break this. ", 30) + "\n"),
+			},
+			{
+				Name: config.FileLearning,
+				Content: []byte("# Learnings\n\n" +
+					"## [2026-02-18-120000] Misleading gotcha #nopacket\n\nBody.\n"),
+			},
+		},
+	}
+}
+
+func TestAssembleBudgetPacket_Pinned(t *testing.T) {
+	pkt := assembleBudgetPacket(pinTestContext(), 8000, "", nil)
+
+	if len(pkt.Decisions) != 2 || !strings.Contains(pkt.Decisions[0], "Core invariant") {
+		t.Errorf("pinned decision should come first: %v", pkt.Decisions)
+	}
+	if len(pkt.Learnings) != 0 || len(pkt.Summaries) != 0 {
+		t.Errorf("#nopacket learning leaked: %v %v", pkt.Learnings, pkt.Summaries)
+	}
+	if strings.Join(pkt.Conventions, "|") != "Use tabs|Use fatih/color" {
+		t.Errorf("Conventions = %q", pkt.Conventions)
+	}
+
+	exp := explainPacket(pkt)
+	if strings.Join(exp.Pinned, "|") != "Core invariant|Use tabs" {
+		t.Errorf("Pinned = %q", exp.Pinned)
+	}
+	for _, e := range exp.Entries {
+		switch e.Title {
+		case "Core invariant":
+			if e.Result != ResultPinned {
+				t.Errorf("pinned entry Result = %s", e.Result)
+			}
+		case "Misleading gotcha":
+			if e.Result != ResultExcluded || e.Reason != "#nopacket marker" {
+				t.Errorf("nopacket entry = %s (%s)", e.Result, e.Reason)
+			}
+		}
+	}
+}
+
+func TestAssembleBudgetPacket_PinnedSurvivesTinyBudget(t *testing.T) {
+	pkt := assembleBudgetPacket(pinTestContext(), 10, "", nil)
+
+	if len(pkt.Decisions) != 1 || !strings.Contains(pkt.Decisions[0], "Core invariant") {
+		t.Errorf("pinned decision missing from exhausted packet: %v", pkt.Decisions)
+	}
+	for _, e := range pkt.ScoredDecisions {
+		if e.Entry.Title == "Recent decision" && e.Result != ResultExcluded {
+			t.Errorf("unpinned decision Result = %s, want excluded", e.Result)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/search"
+)
+
+// queryRelevanceWeight scales BM25 relevance in --query mode so that
+// entries matching the query outrank recent but unrelated ones.
+const queryRelevanceWeight = 2.0
+
+// queryScores holds normalized BM25 relevance for every packet unit.
+//
+// All scores share one index, so term rarity is measured across
+// decisions, learnings, conventions and architecture together. Scores
+// are divided by the best match, giving a 0.0–1.0 range.
+//
+// Fields:
+//   - Decisions: Relevance per DECISIONS.md entry block
+//   - Learnings: Relevance per LEARNINGS.md entry block
+//   - Conventions: Relevance per convention bullet
+//   - Architecture: Relevance per ARCHITECTURE.md section
+//   - DecisionTerms: Query terms matched per decision
+//   - LearningTerms: Query terms matched per learning
+type queryScores struct {
+	Decisions     []float64
+	Learnings     []float64
+	Conventions   []float64
+	Architecture  []float64
+	DecisionTerms [][]string
+	LearningTerms [][]string
+}
+
+// scoreQuery indexes all packet units and ranks them against a query.
+//
+// Parameters:
+//   - query: Free-text query (e.g., "hook stdin parsing")
+//   - decisions: Decision entry blocks
+//   - learnings: Learning entry blocks
+//   - conventions: Convention bullet items
+//   - architecture: ARCHITECTURE.md sections
+//
+// Returns:
+//   - *queryScores: Normalized relevance per unit, in input order
+func scoreQuery(
+	query string,
+	decisions, learnings []index.EntryBlock,
+	conventions, architecture []string,
+) *queryScores {
+	ix := search.NewIndex()
+	for i := range decisions {
+		ix.Add(decisions[i].BlockContent())
+	}
+	for i := range learnings {
+		ix.Add(learnings[i].BlockContent())
+	}
+	for _, c := range conventions {
+		ix.Add(c)
+	}
+	for _, a := range architecture {
+		ix.Add(a)
+	}
+
+	scores := ix.Score(query)
+	best := 0.0
+	for _, s := range scores {
+		best = max(best, s)
+	}
+	if best > 0 {
+		for i := range scores {
+			scores[i] /= best
+		}
+	}
+
+	qs := &queryScores{}
+	rest := scores
+	qs.Decisions, rest = rest[:len(decisions)], rest[len(decisions):]
+	qs.Learnings, rest = rest[:len(learnings)], rest[len(learnings):]
+	qs.Conventions, rest = rest[:len(conventions)], rest[len(conventions):]
+	qs.Architecture = rest
+
+	for i := range decisions {
+		qs.DecisionTerms = append(qs.DecisionTerms, ix.Matches(i, query))
+	}
+	for i := range learnings {
+		qs.LearningTerms = append(
+			qs.LearningTerms, ix.Matches(len(decisions)+i, query),
+		)
+	}
+	return qs
+}
+
+// scoreEntriesByQuery scores entry blocks by recency and query relevance.
+//
+// Superseded and #nopacket entries always get score 0.0. All other
+// entries get recency + queryRelevanceWeight × relevance (range 0.2–3.0).
+//
+// Parameters:
+//   - blocks: Parsed entry blocks
+//   - relevance: Normalized BM25 relevance per block
+//   - matched: Query terms matched per block
+//   - now: Current time for recency scoring
+//
+// Returns:
+//   - []ScoredEntry: Entries sorted by score descending, with token estimates
+func scoreEntriesByQuery(
+	blocks []index.EntryBlock,
+	relevance []float64,
+	matched [][]string,
+	now time.Time,
+) []ScoredEntry {
+	scored := make([]ScoredEntry, 0, len(blocks))
+	for i := range blocks {
+		recency := recencyScore(&blocks[i], now)
+		s := 0.0
+		if !isExcluded(&blocks[i]) {
+			s = recency + queryRelevanceWeight*relevance[i]
+		}
+		se := newScoredEntry(blocks[i], s)
+		se.Recency = recency
+		se.Relevance = relevance[i]
+		se.Matched = matched[i]
+		scored = append(scored, se)
+	}
+	sortScored(scored)
+	return scored
+}
+
+// keywordRelevance ranks items against the active task keywords.
+//
+// The keywords are used as one BM25 query over the items. Scores are
+// divided by the best match, giving a 0.0–1.0 range.
+//
+// Parameters:
+//   - items: Items to rank (e.g., architecture sections)
+//   - keywords: Task keywords (see extractTaskKeywords)
+//
+// Returns:
+//   - []float64: Normalized relevance per item, in input order
+func keywordRelevance(items, keywords []string) []float64 {
+	ix := search.NewIndex()
+	for _, item := range items {
+		ix.Add(item)
+	}
+	scores := ix.Score(strings.Join(keywords, " "))
+	best := 0.0
+	for _, s := range scores {
+		best = max(best, s)
+	}
+	if best > 0 {
+		for i := range scores {
+			scores[i] /= best
+		}
+	}
+	return scores
+}
+
+// addWorkTreeRelevance adds the working-tree score to item relevance.
+//
+// Parameters:
+//   - items: Items to check for references to changed files
+//   - relevance: Relevance per item, updated in place
+//   - changes: Working tree changes (nil for none)
+func addWorkTreeRelevance(items []string, relevance []float64, changes *workTree) {
+	for i, item := range items {
+		relevance[i] += workTreeScore(item, changes)
+	}
+}
+
+// relevanceOrder returns item indices ordered by relevance, best first.
+//
+// Items with equal relevance (including non-matching ones) keep their
+// original order.
+//
+// Parameters:
+//   - relevance: Relevance per item
+//
+// Returns:
+//   - []int: Indices into relevance in ranked order
+func relevanceOrder(relevance []float64) []int {
+	order := make([]int, len(relevance))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return relevance[order[a]] > relevance[order[b]]
+	})
+	return order
+}
+
+// rankByRelevance orders items by query relevance, best first.
+//
+// Parameters:
+//   - items: Items to rank
+//   - relevance: Relevance per item
+//
+// Returns:
+//   - []string: New slice in ranked order (see relevanceOrder)
+func rankByRelevance(items []string, relevance []float64) []string {
+	ranked := make([]string, 0, len(items))
+	for _, i := range relevanceOrder(relevance) {
+		ranked = append(ranked, items[i])
+	}
+	return ranked
+}
+
+// fitRelevant selects matching items by relevance until the budget is
+// used.
+//
+// Items with zero relevance are never included; an item that does not
+// fit is skipped so smaller, less relevant items can still use the
+// remaining budget.
+//
+// Parameters:
+//   - items: Candidate items
+//   - relevance: Relevance per item
+//   - budget: Token budget
+//
+// Returns:
+//   - []string: Selected items, most relevant first
+func fitRelevant(items []string, relevance []float64, budget int) []string {
+	used := 0
+	var selected []string
+	for _, i := range relevanceOrder(relevance) {
+		if relevance[i] <= 0 {
+			break
+		}
+		tokens := context.CountTokensString(items[i])
+		if used+tokens > budget {
+			continue
+		}
+		selected = append(selected, items[i])
+		used += tokens
+	}
+	return selected
+}
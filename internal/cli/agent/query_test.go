@@ -0,0 +1,122 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+func TestScoreEntriesByQuery(t *testing.T) {
+	now := time.Date(2026, 2, 19, 12, 0, 0, 0, time.Local)
+	blocks := []index.EntryBlock{
+		makeBlock("2026-02-19", "Recent unrelated", "token budget tuning"),
+		makeBlock("2025-10-01", "Old but relevant", "hooks parse JSON from stdin"),
+		makeBlock("2026-02-18", "Superseded hook", "~~Superseded by newer entry~~"),
+	}
+	qs := scoreQuery("hook stdin parsing", blocks, nil, nil, nil)
+	scored := scoreEntriesByQuery(blocks, qs.Decisions, qs.DecisionTerms, now)
+
+	if scored[0].Entry.Title != "Old but relevant" {
+		t.Errorf("expected query match first, got %q", scored[0].Entry.Title)
+	}
+	for _, s := range scored {
+		if s.Entry.Title == "Superseded hook" && s.Score != 0 {
+			t.Errorf("superseded entry score = %v, want 0", s.Score)
+		}
+	}
+}
+
+func TestScoreQuery_Normalized(t *testing.T) {
+	qs := scoreQuery(
+		"stdin",
+		nil, nil,
+		[]string{"read stdin once", "unrelated convention"},
+		[]string{"## Hooks\n\nHooks read stdin."},
+	)
+	if len(qs.Conventions) != 2 || len(qs.Architecture) != 1 {
+		t.Fatalf("unexpected split: %+v", qs)
+	}
+	best := max(qs.Conventions[0], qs.Architecture[0])
+	if best != 1.0 {
+		t.Errorf("best score = %v, want 1.0", best)
+	}
+	if qs.Conventions[1] != 0 {
+		t.Errorf("non-matching convention = %v, want 0", qs.Conventions[1])
+	}
+}
+
+func TestFitRelevant(t *testing.T) {
+	items := []string{"no match", "big " + strings.Repeat("word ", 500), "small"}
+	relevance := []float64{0, 1.0, 0.5}
+
+	got := fitRelevant(items, relevance, 50)
+	if len(got) != 1 || got[0] != "small" {
+		t.Errorf("fitRelevant() = %v, want [small]", got)
+	}
+}
+
+func TestExtractSections(t *testing.T) {
+	content := "# Architecture\n\nIntro.\n\n## Hooks\n\nRead stdin.\n\n" +
+		"```bash\n## not a heading\n```\n\n### Parsing\n\nDetails.\n"
+	got := extractSections(content)
+	if len(got) != 2 {
+		t.Fatalf("extractSections() = %d sections, want 2: %q", len(got), got)
+	}
+	if !strings.HasPrefix(got[0], "## Hooks") ||
+		!strings.Contains(got[0], "## not a heading") {
+		t.Errorf("section 0 = %q", got[0])
+	}
+	if !strings.HasPrefix(got[1], "### Parsing") {
+		t.Errorf("section 1 = %q", got[1])
+	}
+}
+
+func TestAssembleBudgetPacket_Query(t *testing.T) {
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name: config.FileConvention,
+				Content: []byte("# Conventions\n\n- Use fatih/color\n" +
+					"- Parse hook input from stdin once\n"),
+			},
+			{
+				Name: config.FileArchitecture,
+				Content: []byte("# Architecture\n\n## Hook Pipeline\n\n" +
+					"Hooks receive JSON on stdin.\n\n## Journal\n\nSite export.\n"),
+			},
+		},
+	}
+
+	pkt := assembleBudgetPacket(ctx, 8000, "hook stdin", nil)
+
+	if pkt.Query != "hook stdin" {
+		t.Errorf("Query = %q", pkt.Query)
+	}
+	if len(pkt.Conventions) != 2 || !strings.Contains(pkt.Conventions[0], "stdin") {
+		t.Errorf("Conventions = %v, want stdin convention first", pkt.Conventions)
+	}
+	if len(pkt.Architecture) != 1 || !strings.HasPrefix(pkt.Architecture[0], "## Hook Pipeline") {
+		t.Errorf("Architecture = %v, want only the hook section", pkt.Architecture)
+	}
+
+	md := renderMarkdownPacket(pkt)
+	if !strings.Contains(md, "## Relevant Architecture") {
+		t.Error("markdown should include the architecture section")
+	}
+
+	// Without a query or active tasks, no section is relevant.
+	if def := assembleBudgetPacket(ctx, 8000, "", nil); len(def.Architecture) != 0 {
+		t.Errorf("default packet Architecture = %v, want none", def.Architecture)
+	}
+}
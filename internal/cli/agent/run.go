@@ -9,12 +9,14 @@ package agent
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 // runAgent executes the agent command logic.
@@ -24,6 +26,10 @@ import (
 // invocation (or after cooldown expires), it loads context from .context/
 // and outputs a context packet in the specified format.
 //
+// With delta, cooldown does not apply: after the first packet of a
+// session, only content added or changed since the previous packet is
+// emitted, and nothing at all when nothing changed.
+//
 // Parameters:
 //   - cmd: Cobra command for output stream
 //   - budget: Token budget to include in the output
@@ -32,6 +38,13 @@ import (
 //   - cooldown: duration to suppress repeated output (0 to disable)
 //   - session: session identifier for tombstone isolation (empty to
 //     disable cooldown)
+//   - query: free-text query to rank content against (empty for
+//     task-keyword ranking)
+//   - explain: print the scoring report instead of the packet; never
+//     suppressed by cooldown and never touches the tombstone
+//   - delta: emit only content new since the session's last packet
+//     (requires session)
+//   - profile: agent profile from .ctxrc (nil for the default packet)
 //
 // Returns:
 //   - error: Non-nil if context loading fails or .context/ is not found
@@ -41,8 +54,15 @@ func runAgent(
 	format string,
 	cooldown time.Duration,
 	session string,
+	query string,
+	explain bool,
+	delta bool,
+	profile *rc.AgentProfile,
 ) error {
-	if cooldownActive(session, cooldown) {
+	if delta && session == "" {
+		return fmt.Errorf("--delta requires --session")
+	}
+	if !explain && !delta && cooldownActive(session, cooldown) {
 		return nil
 	}
 
@@ -57,16 +77,60 @@ func runAgent(
 		return err
 	}
 
+	if explain {
+		return outputExplain(cmd, ctx, budget, query, profile, format)
+	}
+
+	var pkt *assembledPacket
+	prev := readSnapshot(session)
+	if delta && prev != nil {
+		pkt = assembleDeltaPacket(ctx, budget, query, profile, prev)
+		if pkt.isEmpty() {
+			touchTombstone(session, prev)
+			return nil
+		}
+	} else {
+		pkt = assembleBudgetPacket(ctx, budget, query, profile)
+		prev = nil
+	}
+
 	var outputErr error
 	if format == config.FormatJSON {
-		outputErr = outputAgentJSON(cmd, ctx, budget)
+		outputErr = outputAgentJSON(cmd, pkt)
 	} else {
-		outputErr = outputAgentMarkdown(cmd, ctx, budget)
+		outputErr = outputAgentMarkdown(cmd, pkt)
 	}
 
 	if outputErr == nil {
-		touchTombstone(session)
+		touchTombstone(session, newSnapshot(pkt, prev))
 	}
 
 	return outputErr
 }
+
+// resolveProfile looks up the agent profile named by --profile.
+//
+// Parameters:
+//   - name: Profile name; empty for the default packet
+//
+// Returns:
+//   - *rc.AgentProfile: The profile; nil when name is empty
+//   - error: Non-nil if no valid profile has that name
+func resolveProfile(name string) (*rc.AgentProfile, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if p := rc.Profile(name); p != nil {
+		return p, nil
+	}
+	names := rc.ProfileNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf(
+			"unknown profile %q: no profiles defined in %s",
+			name, config.FileContextRC,
+		)
+	}
+	return nil, fmt.Errorf(
+		"unknown profile %q (defined: %s)", name, strings.Join(names, ", "),
+	)
+}
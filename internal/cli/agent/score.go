@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
 	"github.com/ActiveMemory/ctx/internal/index"
 )
@@ -18,12 +19,27 @@ import (
 //
 // Fields:
 //   - EntryBlock: The parsed entry block from a knowledge file
-//   - Score: Combined recency + relevance score (0.0–2.0)
+//   - Score: Combined recency + relevance + working-tree score (0.0–3.0
+//     for task keywords, 0.0–4.0 with --query)
 //   - Tokens: Pre-computed token estimate of the full body
+//   - Recency: Recency component of Score
+//   - Relevance: Relevance component of Score, before weighting
+//   - Matched: Task keywords or query terms found in the entry
+//   - WorkTree: Working-tree component of Score
+//   - Paths: Backticked references to files changed in git
+//   - Result: What the budget pass did with the entry (set by budgetSection)
+//   - Reason: Human-readable explanation of Result
 type ScoredEntry struct {
 	index.EntryBlock
-	Score  float64
-	Tokens int
+	Score     float64
+	Tokens    int
+	Recency   float64
+	Relevance float64
+	Matched   []string
+	WorkTree  float64
+	Paths     []string
+	Result    Result
+	Reason    string
 }
 
 // recencyScore returns a score based on the entry's age.
@@ -70,26 +86,107 @@ func recencyScore(eb *index.EntryBlock, now time.Time) float64 {
 // Returns:
 //   - float64: Relevance score between 0.0 and 1.0
 func relevanceScore(eb *index.EntryBlock, keywords []string) float64 {
+	matches := len(matchedKeywords(eb, keywords))
+	if matches >= 3 {
+		return 1.0
+	}
+	return float64(matches) / 3.0
+}
+
+// matchedKeywords returns the task keywords that appear in an entry.
+//
+// Parameters:
+//   - eb: Entry block to search
+//   - keywords: Lowercase keywords extracted from active tasks
+//
+// Returns:
+//   - []string: Keywords found in the entry's title or body
+func matchedKeywords(eb *index.EntryBlock, keywords []string) []string {
 	if len(keywords) == 0 {
-		return 0.0
+		return nil
 	}
 	text := strings.ToLower(eb.BlockContent())
-	matches := 0
+	var matched []string
 	for _, kw := range keywords {
 		if strings.Contains(text, kw) {
-			matches++
+			matched = append(matched, kw)
 		}
 	}
-	if matches >= 3 {
-		return 1.0
+	return matched
+}
+
+// workTreeScore scores how much an entry is about the files being
+// changed.
+//
+// Counts backticked references to changed paths, their directories or
+// package names (see workTree.matches). Normalized to 1.0 at 2+
+// references.
+//
+// Parameters:
+//   - text: Entry or section text to score
+//   - changes: Working tree changes (nil for none)
+//
+// Returns:
+//   - float64: Working-tree score between 0.0 and 1.0
+func workTreeScore(text string, changes *workTree) float64 {
+	return min(float64(len(workTreeRefs(text, changes)))/2.0, 1.0)
+}
+
+// workTreeRefs returns the backticked references in a text that point
+// at working tree changes.
+//
+// Parameters:
+//   - text: Entry or section text to search
+//   - changes: Working tree changes (nil for none)
+//
+// Returns:
+//   - []string: Distinct matching references, in text order
+func workTreeRefs(text string, changes *workTree) []string {
+	if changes == nil {
+		return nil
 	}
-	return float64(matches) / 3.0
+	seen := make(map[string]bool)
+	var refs []string
+	matches := config.RegExCodeSpan.FindAllStringSubmatch(text, -1)
+	for _, m := range matches {
+		ref := m[1]
+		if !seen[ref] && changes.matches(ref) {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// addWorkTreeScores adds the working-tree component to scored entries
+// and re-sorts them.
+//
+// Excluded entries (score 0.0) stay at 0.0.
+//
+// Parameters:
+//   - entries: Scored entries, updated in place
+//   - changes: Working tree changes (nil for none)
+func addWorkTreeScores(entries []ScoredEntry, changes *workTree) {
+	if changes == nil {
+		return
+	}
+	for i := range entries {
+		e := &entries[i]
+		text := e.BlockContent()
+		e.Paths = workTreeRefs(text, changes)
+		e.WorkTree = workTreeScore(text, changes)
+		if e.Score > 0 {
+			e.Score += e.WorkTree
+		}
+	}
+	sortScored(entries)
 }
 
 // scoreEntry computes the combined relevance score for an entry block.
 //
-// Superseded entries always get score 0.0.
+// Superseded entries and entries marked #nopacket always get score 0.0.
 // All other entries get recency + task relevance (range 0.0–2.0).
+// Pinned entries are scored too, but never compete for the budget.
 //
 // Parameters:
 //   - eb: Entry block to score
@@ -97,14 +194,25 @@ func relevanceScore(eb *index.EntryBlock, keywords []string) float64 {
 //   - now: Current time for recency calculation
 //
 // Returns:
-//   - float64: Combined score (0.0–2.0), or 0.0 if superseded
+//   - float64: Combined score (0.0–2.0), or 0.0 if excluded
 func scoreEntry(eb *index.EntryBlock, keywords []string, now time.Time) float64 {
-	if eb.IsSuperseded() {
+	if isExcluded(eb) {
 		return 0.0
 	}
 	return recencyScore(eb, now) + relevanceScore(eb, keywords)
 }
 
+// isExcluded reports whether an entry never competes for the packet.
+//
+// Parameters:
+//   - eb: Entry block to check
+//
+// Returns:
+//   - bool: True if the entry is superseded or marked #nopacket
+func isExcluded(eb *index.EntryBlock) bool {
+	return eb.Entry.NoPacket || eb.IsSuperseded()
+}
+
 // stopWords is a set of common English words to exclude from keyword extraction.
 var stopWords = map[string]bool{
 	"the": true, "and": true, "for": true, "that": true, "this": true,
@@ -164,19 +272,42 @@ func extractTaskKeywords(tasks []string) []string {
 func scoreEntries(blocks []index.EntryBlock, keywords []string, now time.Time) []ScoredEntry {
 	scored := make([]ScoredEntry, 0, len(blocks))
 	for i := range blocks {
-		s := scoreEntry(&blocks[i], keywords, now)
-		tokens := context.EstimateTokensString(blocks[i].BlockContent())
-		scored = append(scored, ScoredEntry{
-			EntryBlock: blocks[i],
-			Score:      s,
-			Tokens:     tokens,
-		})
+		se := newScoredEntry(blocks[i], scoreEntry(&blocks[i], keywords, now))
+		se.Recency = recencyScore(&blocks[i], now)
+		se.Relevance = relevanceScore(&blocks[i], keywords)
+		se.Matched = matchedKeywords(&blocks[i], keywords)
+		scored = append(scored, se)
 	}
-	// Sort by score descending (stable for equal scores — preserves file order)
+	sortScored(scored)
+	return scored
+}
+
+// newScoredEntry pairs an entry block with its score and token cost.
+//
+// Parameters:
+//   - eb: Entry block
+//   - score: Computed score
+//
+// Returns:
+//   - ScoredEntry: Entry with Tokens estimated from the full body
+func newScoredEntry(eb index.EntryBlock, score float64) ScoredEntry {
+	return ScoredEntry{
+		EntryBlock: eb,
+		Score:      score,
+		Tokens:     context.CountTokensString(eb.BlockContent()),
+	}
+}
+
+// sortScored sorts entries by score descending.
+//
+// The sort is stable, so entries with equal scores keep file order.
+//
+// Parameters:
+//   - scored: Entries to sort in place
+func sortScored(scored []ScoredEntry) {
 	for i := 1; i < len(scored); i++ {
 		for j := i; j > 0 && scored[j].Score > scored[j-1].Score; j-- {
 			scored[j], scored[j-1] = scored[j-1], scored[j]
 		}
 	}
-	return scored
 }
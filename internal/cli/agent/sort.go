@@ -7,8 +7,6 @@
 package agent
 
 import (
-	"path/filepath"
-
 	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
 )
@@ -17,7 +15,9 @@ import (
 //
 // Files are ordered according to [config.FileReadOrder] and filtered to
 // exclude empty files. Paths are returned as full paths relative to the
-// context directory.
+// context directory. In a layered context, each file is listed once per
+// layer, parent before child, so the child's content is read last and
+// takes precedence.
 //
 // Parameters:
 //   - ctx: Loaded context containing the files
@@ -27,8 +27,11 @@ import (
 func getReadOrder(ctx *context.Context) []string {
 	var order []string
 	for _, name := range config.FileReadOrder {
-		if f := ctx.File(name); f != nil && !f.IsEmpty {
-			order = append(order, filepath.Join(ctx.Dir, f.Name))
+		files := ctx.FileLayers(name)
+		for i := len(files) - 1; i >= 0; i-- {
+			if !files[i].IsEmpty {
+				order = append(order, files[i].Path)
+			}
 		}
 	}
 	return order
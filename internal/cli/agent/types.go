@@ -13,26 +13,116 @@ package agent
 //
 // Fields:
 //   - Generated: RFC3339 timestamp of when the packet was created
+//   - Delta: True if the packet only holds content added or changed
+//     since the session's previous packet (--delta)
 //   - Budget: Token budget specified by the user
 //   - TokensUsed: Estimated token count consumed by the packet
+//   - Query: Query the packet was ranked against (omitted by default)
+//   - Profile: Agent profile the packet was built for (omitted by
+//     default)
+//   - Layers: Merged context directories, outermost first (omitted
+//     unless the context is layered)
 //   - ReadOrder: File paths in recommended reading order
 //   - Constitution: Rules from CONSTITUTION.md
 //   - Tasks: Active (unchecked) tasks from TASKS.md
+//   - Next: The task "ctx tasks next" picks (omitted if none is ready)
 //   - Conventions: Key conventions from CONVENTIONS.md
-//   - Decisions: Decision entries from DECISIONS.md (full body, scored)
-//   - Learnings: Learning entries from LEARNINGS.md (full body, scored)
+//   - Decisions: Decision entries from DECISIONS.md (full or condensed,
+//     scored)
+//   - Learnings: Learning entries from LEARNINGS.md (full or condensed,
+//     scored)
+//   - Architecture: ARCHITECTURE.md sections relevant to the query or
+//     the active tasks
+//   - Glossary: GLOSSARY.md terms mentioned in the packet, as
+//     "**Term**: Definition"
 //   - Summaries: Title-only summaries for entries that exceeded budget
 //   - Instruction: Behavioral instruction for the agent
 type Packet struct {
 	Generated    string   `json:"generated"`
+	Delta        bool     `json:"delta,omitempty"`
 	Budget       int      `json:"budget"`
 	TokensUsed   int      `json:"tokens_used"`
+	Query        string   `json:"query,omitempty"`
+	Profile      string   `json:"profile,omitempty"`
+	Layers       []string `json:"layers,omitempty"`
 	ReadOrder    []string `json:"read_order"`
 	Constitution []string `json:"constitution"`
 	Tasks        []string `json:"tasks"`
+	Next         string   `json:"next,omitempty"`
 	Conventions  []string `json:"conventions"`
 	Decisions    []string `json:"decisions"`
 	Learnings    []string `json:"learnings,omitempty"`
+	Architecture []string `json:"architecture,omitempty"`
+	Glossary     []string `json:"glossary,omitempty"`
 	Summaries    []string `json:"summaries,omitempty"`
 	Instruction  string   `json:"instruction"`
 }
+
+// Explanation is the --explain report for a context packet.
+//
+// It lists every candidate decision and learning with the inputs and
+// outcome of scoring and budgeting, so scoring changes can be reviewed
+// and regression-tested.
+//
+// Fields:
+//   - Generated: RFC3339 timestamp of when the report was created
+//   - Budget: Token budget specified by the user
+//   - TokensUsed: Estimated token count consumed by the packet
+//   - Query: Query used for BM25 relevance (omitted in task-keyword mode)
+//   - Keywords: Task keywords used for relevance (task-keyword mode)
+//   - DecisionBudget: Section budget allocated to decisions
+//   - LearningBudget: Section budget allocated to learnings
+//   - Pinned: Titles of #pin entries and text of #pin conventions
+//   - ChangedFiles: Number of files changed in git (working-tree boost)
+//   - Entries: One row per candidate entry, in score order per section
+type Explanation struct {
+	Generated      string         `json:"generated"`
+	Budget         int            `json:"budget"`
+	TokensUsed     int            `json:"tokens_used"`
+	Query          string         `json:"query,omitempty"`
+	Keywords       []string       `json:"keywords,omitempty"`
+	DecisionBudget int            `json:"decision_budget"`
+	LearningBudget int            `json:"learning_budget"`
+	Pinned         []string       `json:"pinned,omitempty"`
+	ChangedFiles   int            `json:"changed_files"`
+	Entries        []ExplainEntry `json:"entries"`
+}
+
+// ExplainEntry describes how one candidate entry was scored and handled.
+//
+// Fields:
+//   - Section: "decisions" or "learnings"
+//   - Timestamp: Entry timestamp (YYYY-MM-DD-HHMMSS)
+//   - Title: Entry title
+//   - Score: Combined score used for ranking
+//   - Recency: Recency component of the score
+//   - Relevance: Relevance component, before weighting
+//   - Matched: Task keywords or query terms found in the entry
+//   - WorkTree: Working-tree component of the score
+//   - Paths: Backticked references to files changed in git
+//   - Superseded: True if the entry is marked superseded
+//   - Pinned: True if the entry header has a #pin marker
+//   - NoPacket: True if the entry header has a #nopacket marker
+//   - Tokens: Token cost of the full body
+//   - SectionBudget: Budget of the section the entry competed in
+//   - Result: "pinned", "full", "condensed", "summary" (title only), or
+//     "excluded"
+//   - Reason: Why the entry got that result
+type ExplainEntry struct {
+	Section       string   `json:"section"`
+	Timestamp     string   `json:"timestamp"`
+	Title         string   `json:"title"`
+	Score         float64  `json:"score"`
+	Recency       float64  `json:"recency"`
+	Relevance     float64  `json:"relevance"`
+	Matched       []string `json:"matched,omitempty"`
+	WorkTree      float64  `json:"work_tree"`
+	Paths         []string `json:"paths,omitempty"`
+	Superseded    bool     `json:"superseded"`
+	Pinned        bool     `json:"pinned,omitempty"`
+	NoPacket      bool     `json:"nopacket,omitempty"`
+	Tokens        int      `json:"tokens"`
+	SectionBudget int      `json:"section_budget"`
+	Result        Result   `json:"result"`
+	Reason        string   `json:"reason"`
+}
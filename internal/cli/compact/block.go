@@ -7,6 +7,7 @@
 package compact
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -26,56 +27,65 @@ import (
 // are part of their parent block and are not collected as independent blocks.
 //
 // A block is archivable if:
-// - The parent task is checked [x]
+// - The parent task is checked [x] or skipped [-]
 // - No nested lines contain unchecked tasks [ ]
 //
 // Parameters:
 //   - lines: Slice of lines from the tasks file
 //
 // Returns:
-//   - []TaskBlock: All completed top-level task blocks found
+//   - []TaskBlock: All completed or skipped top-level task blocks found
 //     (outside the Completed section)
 func ParseTaskBlocks(lines []string) []TaskBlock {
 	var blocks []TaskBlock
-	inCompletedSection := false
-	i := 0
 
-	for i < len(lines) {
-		line := lines[i]
-
-		// Track if we're in the Completed section
-		if strings.HasPrefix(line, config.HeadingCompleted) {
-			inCompletedSection = true
-			i++
-			continue
-		}
-		if strings.HasPrefix(line, config.HeadingLevelTwoStart) && inCompletedSection {
-			inCompletedSection = false
-		}
-
-		// Skip if in the Completed section or not a checked task
-		match := config.RegExTask.FindStringSubmatch(line)
-		if inCompletedSection || match == nil || !task.Completed(match) {
-			i++
-			continue
-		}
-
-		// Only consider top-level tasks (no indentation) for archiving
-		// Nested subtasks are part of their parent block, not independent
-		if indentLevel(line) > 0 {
-			i++
+	for _, t := range task.Parse(lines) {
+		// Skip pending, nested and already-completed-section tasks
+		if t.Pending() || t.Indent > 0 || inCompletedSection(t) {
 			continue
 		}
+		blocks = append(blocks, newTaskBlock(lines, t))
+	}
 
-		// Found a completed top-level task - parse the block
-		block := parseBlockAt(lines, i)
-		blocks = append(blocks, block)
+	return blocks
+}
 
-		// Skip past this block
-		i = block.EndIndex
+// newTaskBlock builds the block for a parsed task.
+//
+// Parameters:
+//   - lines: Lines the task was parsed from
+//   - t: Completed or skipped top-level task
+//
+// Returns:
+//   - TaskBlock: Block covering the task's lines
+func newTaskBlock(lines []string, t *task.Task) TaskBlock {
+	block := TaskBlock{
+		Lines:        slices.Clone(lines[t.Line:t.End]),
+		StartIndex:   t.Line,
+		EndIndex:     t.End,
+		IsCompleted:  !t.Pending(),
+		IsArchivable: !t.HasPending(),
+		Task:         t,
+	}
+	if !t.Completed.IsZero() {
+		done := t.Completed
+		block.DoneTime = &done
 	}
+	return block
+}
 
-	return blocks
+// inCompletedSection reports whether a task sits under the Completed
+// heading (or a sub-heading of it).
+//
+// Parameters:
+//   - t: Parsed task
+//
+// Returns:
+//   - bool: True if the task is already in the Completed section
+func inCompletedSection(t *task.Task) bool {
+	return t.Section != "" && strings.HasPrefix(
+		config.HeadingLevelTwoStart+t.Section, config.HeadingCompleted,
+	)
 }
 
 // BlockContent returns the full content of a block as a single string.
@@ -91,12 +101,11 @@ func (b *TaskBlock) BlockContent() string {
 // Returns:
 //   - string: Task text without the checkbox prefix, empty if no lines
 func (b *TaskBlock) ParentTaskText() string {
-	if len(b.Lines) == 0 {
-		return ""
+	if b.Task != nil {
+		return b.Task.Content
 	}
-	match := config.RegExTask.FindStringSubmatch(b.Lines[0])
-	if match != nil {
-		return task.Content(match)
+	if tasks := task.Parse(b.Lines); len(tasks) > 0 && tasks[0].Line == 0 {
+		return tasks[0].Content
 	}
 	return ""
 }
@@ -12,29 +12,6 @@ import (
 	"time"
 )
 
-func TestGetIndentLevel(t *testing.T) {
-	tests := []struct {
-		line     string
-		expected int
-	}{
-		{"no indent", 0},
-		{" one space", 1},
-		{"  two spaces", 2},
-		{"\ttab", 1},
-		{"    four spaces", 4},
-		{"", 0},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.line, func(t *testing.T) {
-			got := indentLevel(tt.line)
-			if got != tt.expected {
-				t.Errorf("indentLevel(%q) = %d, want %d", tt.line, got, tt.expected)
-			}
-		})
-	}
-}
-
 func TestParseTaskBlocks_SimpleTask(t *testing.T) {
 	lines := strings.Split(`# Tasks
 
@@ -228,14 +205,15 @@ func TestParseTaskBlocks_MultipleCompletedTasks(t *testing.T) {
   With metadata
 - [ ] Pending task
 - [x] Second completed
+- [-] Skipped task
 
 ## Completed
 `, "\n")
 
 	blocks := ParseTaskBlocks(lines)
 
-	if len(blocks) != 2 {
-		t.Fatalf("expected 2 blocks, got %d", len(blocks))
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
 	}
 
 	if blocks[0].ParentTaskText() != "First completed" {
@@ -251,6 +229,10 @@ func TestParseTaskBlocks_MultipleCompletedTasks(t *testing.T) {
 	if len(blocks[1].Lines) != 1 {
 		t.Errorf("second block should have 1 line, got %d", len(blocks[1].Lines))
 	}
+
+	if blocks[2].ParentTaskText() != "Skipped task" || !blocks[2].IsArchivable {
+		t.Errorf("skipped task should be archivable: %+v", blocks[2])
+	}
 }
 
 func TestParseTaskBlocks_EmptyLinesInBlock(t *testing.T) {
@@ -429,55 +411,6 @@ func TestParseTaskBlocks_IncompleteDeepChild(t *testing.T) {
 	}
 }
 
-func TestParseDoneTimestamp(t *testing.T) {
-	tests := []struct {
-		name     string
-		line     string
-		wantNil  bool
-		wantYear int
-	}{
-		{
-			name:    "no timestamp",
-			line:    "- [x] Simple completed task",
-			wantNil: true,
-		},
-		{
-			name:     "with done timestamp",
-			line:     "- [x] Task with timestamp #added:2026-01-15-100000 #done:2026-01-20-143000",
-			wantNil:  false,
-			wantYear: 2026,
-		},
-		{
-			name:     "only done timestamp",
-			line:     "- [x] Task #done:2025-12-25-120000",
-			wantNil:  false,
-			wantYear: 2025,
-		},
-		{
-			name:    "invalid timestamp format",
-			line:    "- [x] Task #done:2026-01-20",
-			wantNil: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseDoneTimestamp(tt.line)
-			if tt.wantNil {
-				if result != nil {
-					t.Errorf("expected nil, got %v", result)
-				}
-			} else {
-				if result == nil {
-					t.Error("expected non-nil time")
-				} else if result.Year() != tt.wantYear {
-					t.Errorf("year = %d, want %d", result.Year(), tt.wantYear)
-				}
-			}
-		})
-	}
-}
-
 func TestTaskBlockIsOlderThan(t *testing.T) {
 	now := time.Now()
 
@@ -22,7 +22,9 @@ import (
 // runCompact executes the compact command logic.
 //
 // Loads context, processes TASKS.md for completed tasks, and removes
-// empty sections from all context files.
+// empty sections from all context files. Only the local context
+// directory is loaded: parent layers of a nested context are never
+// rewritten.
 //
 // Parameters:
 //   - cmd: Cobra command for output messages
@@ -31,7 +33,7 @@ import (
 // Returns:
 //   - error: Non-nil if context loading fails or .context/ is not found
 func runCompact(cmd *cobra.Command, archive bool) error {
-	ctx, err := context.Load("")
+	ctx, err := context.Load(rc.ContextDir())
 	if err != nil {
 		var notFoundError *context.NotFoundError
 		if errors.As(err, &notFoundError) {
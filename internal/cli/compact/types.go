@@ -6,7 +6,11 @@
 
 package compact
 
-import "time"
+import (
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/task"
+)
 
 // TaskBlock represents a task and its nested content.
 //
@@ -14,10 +18,11 @@ import "time"
 //   - Lines: All lines in the block (parent and children)
 //   - StartIndex: Index of first line in original content
 //   - EndIndex: Index of last line (exclusive)
-//   - IsCompleted: The parent task is checked
+//   - IsCompleted: The parent task is checked or skipped
 //   - IsArchivable: Completed and no unchecked children
 //   - DoneTime: When the task was marked done (from #done: timestamp),
 //     nil if not present
+//   - Task: Parsed parent task; nil for blocks built by hand
 type TaskBlock struct {
 	Lines        []string
 	StartIndex   int
@@ -25,4 +30,5 @@ type TaskBlock struct {
 	IsCompleted  bool
 	IsArchivable bool
 	DoneTime     *time.Time
+	Task         *task.Task
 }
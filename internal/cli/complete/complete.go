@@ -12,7 +12,7 @@ import (
 
 // Cmd returns the "ctx complete" command for marking tasks as done.
 //
-// Tasks can be specified by number, partial text match, or full text.
+// Tasks can be specified by ID, number, partial text match, or full text.
 // The command updates TASKS.md by changing "- [ ]" to "- [x]".
 //
 // Returns:
@@ -24,10 +24,15 @@ func Cmd() *cobra.Command {
 		Long: `Mark a task as completed in TASKS.md.
 
 You can specify a task by:
-  - Task number (e.g., "ctx complete 3")
+  - Task ID from its #id: tag (e.g., "ctx complete k3f9")
+  - Task number among pending tasks (e.g., "ctx complete 3")
   - Partial text match (e.g., "ctx complete auth")
   - Full task text (e.g., "ctx complete 'Implement user authentication'")
 
+IDs never change; numbers shift as tasks are added and completed.
+Tasks added with "ctx add task" get an ID automatically; run
+"ctx tasks migrate" to give IDs to existing tasks.
+
 The task will be marked with [x] 
 and optionally moved to the Completed section.`,
 		Args: cobra.ExactArgs(1),
@@ -7,6 +7,7 @@
 package complete
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/ActiveMemory/ctx/internal/cli/add"
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
+	"github.com/ActiveMemory/ctx/internal/task"
 )
 
 // TestCompleteCommand tests the complete command.
@@ -62,3 +64,191 @@ func TestCompleteCommand(t *testing.T) {
 		t.Errorf("task was not marked as complete")
 	}
 }
+
+// TestFindTask tests resolving task IDs, numbers and text.
+func TestFindTask(t *testing.T) {
+	tasks := task.Parse(strings.Split(`# Tasks
+
+- [ ] Write parser #id:p4rs
+  - [ ] Parser tests #id:t3st
+- [ ] Write printer #id:pr1n
+- [x] Set up repo #id:r3p0
+- [ ] Untagged cleanup
+`, "\n"))
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr string
+	}{
+		{name: "bare ID", query: "t3st", want: "Parser tests"},
+		{name: "hash ID", query: "#pr1n", want: "Write printer"},
+		{name: "tag ID", query: "#id:P4RS", want: "Write parser"},
+		{name: "number counts subtasks", query: "3", want: "Write printer"},
+		{name: "text", query: "cleanup", want: "Untagged cleanup"},
+		{name: "completed ID", query: "r3p0", wantErr: "already completed"},
+		{name: "unknown ID", query: "#zzzz", wantErr: "no task with ID"},
+		{name: "number out of range", query: "9", wantErr: "task #9 not found"},
+		{name: "ambiguous text", query: "write", wantErr: "(p4rs, pr1n)"},
+		{name: "no match", query: "deploy", wantErr: "no task matching"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findTask(tasks, tt.query)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("findTask(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findTask(%q) error: %v", tt.query, err)
+			}
+			if got.Title != tt.want {
+				t.Errorf("findTask(%q) = %q, want %q", tt.query, got.Title, tt.want)
+			}
+		})
+	}
+}
+
+// TestCompleteByID tests that IDs from "ctx add task" complete the task.
+func TestCompleteByID(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	addCmd := add.Cmd()
+	addCmd.SetArgs([]string{"task", "Task with an ID"})
+	if err := addCmd.Execute(); err != nil {
+		t.Fatalf("add task command failed: %v", err)
+	}
+
+	tasksPath := filepath.Join(".context", "TASKS.md")
+	content, err := os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	var added *task.Task
+	for _, tk := range task.All(task.Parse(strings.Split(string(content), "\n"))) {
+		if tk.Title == "Task with an ID" {
+			added = tk
+		}
+	}
+	if added == nil || added.ID == "" {
+		t.Fatalf("added task has no ID:\n%s", content)
+	}
+
+	completeCmd := Cmd()
+	completeCmd.SetArgs([]string{added.ID})
+	if err = completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+
+	content, err = os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	if !strings.Contains(string(content), "- [x] Task with an ID") {
+		t.Errorf("task was not marked as complete:\n%s", content)
+	}
+	if !strings.Contains(string(content), "#id:"+added.ID+" #done:") {
+		t.Errorf("completed task should get a #done: timestamp:\n%s", content)
+	}
+}
+
+func TestCompleteWarnsAboutOpenDependencies(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasksPath := filepath.Join(".context", "TASKS.md")
+	tasksContent := `# Tasks
+
+- [ ] Set up auth service #id:k3f9
+- [x] Design schema #id:d3s1
+- [ ] Add login form #id:a1b2 #depends:k3f9,d3s1,g0n3,arc1
+`
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	archived := "# Archived Tasks\n\n- [x] Old task #id:arc1\n"
+	if err := os.MkdirAll(filepath.Join(".context", "archive"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(
+		filepath.Join(".context", "archive", "tasks-2026-01-10.md"),
+		[]byte(archived), 0600,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	completeCmd := Cmd()
+	completeCmd.SetArgs([]string{"a1b2"})
+	var buf bytes.Buffer
+	completeCmd.SetOut(&buf)
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Depends on 1 open task(s)") ||
+		!strings.Contains(out, "k3f9  Set up auth service") {
+		t.Errorf("expected open dependency warning:\n%s", out)
+	}
+	if strings.Contains(out, "- d3s1") {
+		t.Errorf("completed dependency should not be listed:\n%s", out)
+	}
+	if !strings.Contains(out, "Depends on unknown task ID(s): g0n3\n") {
+		t.Errorf("expected unknown dependency warning, archived IDs excluded:\n%s", out)
+	}
+
+	content, err := os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	if !strings.Contains(string(content), "- [x] Add login form") {
+		t.Errorf("task should be completed despite open dependencies:\n%s", content)
+	}
+}
+
+func TestCompleteDropsInProgress(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	initCmd := initialize.Cmd()
+	initCmd.SetArgs([]string{})
+	if err := initCmd.Execute(); err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	tasksPath := filepath.Join(".context", "TASKS.md")
+	tasksContent := "# Tasks\n\n" +
+		"- [ ] Fix login #id:k3f9 #in-progress #started:2026-01-10-090000\n"
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	completeCmd := Cmd()
+	completeCmd.SetArgs([]string{"k3f9"})
+	if err := completeCmd.Execute(); err != nil {
+		t.Fatalf("complete command failed: %v", err)
+	}
+
+	content, err := os.ReadFile(tasksPath)
+	if err != nil {
+		t.Fatalf("failed to read TASKS.md: %v", err)
+	}
+	want := "- [x] Fix login #id:k3f9 #started:2026-01-10-090000 #done:"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("TASKS.md missing %q:\n%s", want, content)
+	}
+}
@@ -7,6 +7,6 @@
 // Package complete implements the "ctx complete" command for marking
 // tasks as done in TASKS.md.
 //
-// Tasks can be identified by number or partial text match. The command
+// Tasks can be identified by ID, number or partial text match. The command
 // updates TASKS.md by changing "- [ ]" to "- [x]" for the matched task.
 package complete
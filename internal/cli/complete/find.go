@@ -0,0 +1,94 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package complete
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// findTask resolves a query to a single pending task.
+//
+// The query is tried, in order, as:
+//   - A task ID ("k3f9", "#k3f9" or "#id:k3f9"); IDs never change
+//   - A number: the position among pending tasks, which shifts as
+//     tasks are added or completed
+//   - Text: a case-insensitive substring of the task description
+//
+// Parameters:
+//   - tasks: Top-level tasks from task.Parse
+//   - query: Task ID, number or search text
+//
+// Returns:
+//   - *task.Task: Matched task
+//   - error: Non-nil if nothing matches, the match is ambiguous, or the
+//     task with that ID is already completed
+func findTask(tasks []*task.Task, query string) (*task.Task, error) {
+	if t := task.Find(tasks, query); t != nil {
+		if t.Done {
+			return nil, fmt.Errorf("task %s is already completed: %s", t.ID, t.Content)
+		}
+		return t, nil
+	}
+	if strings.HasPrefix(query, "#") {
+		return nil, fmt.Errorf(
+			"no task with ID %q. Use 'ctx status' to see tasks",
+			task.NormalizeID(query),
+		)
+	}
+
+	pending := task.PendingTasks(tasks)
+
+	// Match by number
+	if num, parseErr := strconv.Atoi(query); parseErr == nil {
+		if num < 1 || num > len(pending) {
+			return nil, fmt.Errorf(
+				"task #%d not found. Use 'ctx status' to see tasks", num,
+			)
+		}
+		return pending[num-1], nil
+	}
+
+	// Match by text (case-insensitive partial match)
+	var matches []*task.Task
+	for _, t := range pending {
+		if strings.Contains(
+			strings.ToLower(t.Text), strings.ToLower(query),
+		) {
+			matches = append(matches, t)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf(
+			"no task matching %q found. Use 'ctx status' to see tasks", query,
+		)
+	case 1:
+		return matches[0], nil
+	}
+
+	// Multiple matches - be more specific
+	var ids []string
+	for _, t := range matches {
+		if t.ID != "" {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == len(matches) {
+		return nil, fmt.Errorf(
+			"multiple tasks match %q (%s). Be more specific or use a task ID",
+			query, strings.Join(ids, ", "),
+		)
+	}
+	return nil, fmt.Errorf(
+		"multiple tasks match %q. Be more specific or use task number", query,
+	)
+}
@@ -10,8 +10,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -23,12 +23,15 @@ import (
 
 // runComplete executes the complete command logic.
 //
-// Finds a task in TASKS.md by number or text match and marks it complete
-// by changing "- [ ]" to "- [x]".
+// Finds a task in TASKS.md by ID, number or text match and marks it
+// complete by changing "- [ ]" to "- [x]", adding a #done: timestamp
+// and dropping #in-progress. Warns when the task has "#depends:"
+// prerequisites that are still open or name no known task.
 //
 // Parameters:
 //   - cmd: Cobra command for output messages
-//   - args: Command arguments; args[0] is the task number or search text
+//   - args: Command arguments; args[0] is the task ID, number or search
+//     text
 //
 // Returns:
 //   - error: Non-nil if the task is not found, multiple matches, or file
@@ -49,66 +52,37 @@ func runComplete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read TASKS.md: %w", err)
 	}
 
-	// Parse tasks and find matching one
+	// Parse tasks and find the matching one
 	lines := strings.Split(string(content), config.NewlineLF)
-
-	var taskNumber int
-	isNumber := false
-	if num, parseErr := strconv.Atoi(query); parseErr == nil {
-		taskNumber = num
-		isNumber = true
+	tasks := task.Parse(lines)
+	matched, findErr := findTask(tasks, query)
+	if findErr != nil {
+		return findErr
 	}
 
-	currentTaskNum := 0
-	matchedLine := -1
-	matchedTask := ""
-
-	for i, line := range lines {
-		match := config.RegExTask.FindStringSubmatch(line)
-		if match != nil && task.Pending(match) {
-			currentTaskNum++
-			taskText := task.Content(match)
-
-			// Match by number
-			if isNumber && currentTaskNum == taskNumber {
-				matchedLine = i
-				matchedTask = taskText
-				break
-			}
+	// Dependencies that are still open do not stop completion; they are
+	// reported so the user can tell whether the order was intentional.
+	// Archived tasks count as done, as in "ctx tasks next".
+	archived, _ := task.ParseArchive(
+		filepath.Join(rc.ContextDir(), config.DirArchive),
+	)
+	g := task.NewGraph(tasks, task.IDs(archived))
+	open, unknown := g.Open(matched), g.Unknown(matched)
 
-			// Match by text (case-insensitive partial match)
-			if !isNumber && strings.Contains(
-				strings.ToLower(taskText), strings.ToLower(query),
-			) {
-				if matchedLine != -1 {
-					// Multiple matches - be more specific
-					return fmt.Errorf(
-						"multiple tasks match %q. Be more specific or use task number",
-						query,
-					)
-				}
-				matchedLine = i
-				matchedTask = taskText
-			}
-		}
+	// Mark the task as complete
+	lines[matched.Line] = task.Check(lines[matched.Line])
+	if matched.HasLabel(config.TaskLabelInProgress) {
+		lines[matched.Line] = task.WithoutLabel(
+			lines[matched.Line], config.TaskLabelInProgress,
+		)
 	}
-
-	if matchedLine == -1 {
-		if isNumber {
-			return fmt.Errorf(
-				"task #%d not found. Use 'ctx status' to see tasks", taskNumber,
-			)
-		}
-		return fmt.Errorf(
-			"no task matching %q found. Use 'ctx status' to see tasks", query,
+	if matched.Completed.IsZero() {
+		lines[matched.Line] = task.WithTag(
+			lines[matched.Line], config.TaskTagDone,
+			time.Now().Format("2006-01-02-150405"),
 		)
 	}
 
-	// Mark the task as complete
-	lines[matchedLine] = config.RegExTask.ReplaceAllString(
-		lines[matchedLine], "$1- [x] $3",
-	)
-
 	// Write back
 	newContent := strings.Join(lines, config.NewlineLF)
 	if writeErr := os.WriteFile(filePath, []byte(newContent), config.PermFile); writeErr != nil {
@@ -116,7 +90,24 @@ func runComplete(cmd *cobra.Command, args []string) error {
 	}
 
 	green := color.New(color.FgGreen).SprintFunc()
-	cmd.Println(fmt.Sprintf("%s Completed: %s", green("✓"), matchedTask))
+	cmd.Println(fmt.Sprintf("%s Completed: %s", green("✓"), matched.Content))
+
+	if len(open) > 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cmd.Println(fmt.Sprintf(
+			"%s Depends on %d open task(s):", yellow("⚠"), len(open),
+		))
+		for _, dep := range open {
+			cmd.Println(fmt.Sprintf("  - %s  %s", task.Key(dep), dep.Title))
+		}
+	}
+	if len(unknown) > 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		cmd.Println(fmt.Sprintf(
+			"%s Depends on unknown task ID(s): %s",
+			yellow("⚠"), strings.Join(unknown, ", "),
+		))
+	}
 
 	return nil
 }
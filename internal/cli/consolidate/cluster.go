@@ -0,0 +1,194 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/search"
+)
+
+// findGroups clusters overlapping entries.
+//
+// Pairs of entries are joined most similar first (see similarity). Two
+// groups merge only if the average similarity across them stays at
+// config.ConsolidateThreshold and the result has at most
+// config.MaxConsolidateGroup entries, so one broad entry cannot chain
+// unrelated topics together. Superseded decisions are left out: they
+// are history, not redundancy.
+//
+// Parameters:
+//   - blocks: Entries from index.ParseEntryBlocks
+//
+// Returns:
+//   - []group: Groups of two or more entries, in file order
+func findGroups(blocks []index.EntryBlock) []group {
+	var entries []index.EntryBlock
+	for _, eb := range blocks {
+		if !eb.IsSuperseded() {
+			entries = append(entries, eb)
+		}
+	}
+
+	n := len(entries)
+	features := make([]entryFeatures, n)
+	for i, eb := range entries {
+		features[i] = newEntryFeatures(eb)
+	}
+
+	type pair struct {
+		a, b  int
+		score float64
+	}
+	scores := make([][]float64, n)
+	var pairs []pair
+	for i := range entries {
+		scores[i] = make([]float64, n)
+	}
+	for i := range entries {
+		for j := i + 1; j < n; j++ {
+			s := similarity(features[i], features[j])
+			scores[i][j], scores[j][i] = s, s
+			if s >= config.ConsolidateThreshold {
+				pairs = append(pairs, pair{a: i, b: j, score: s})
+			}
+		}
+	}
+	slices.SortStableFunc(pairs, func(x, y pair) int {
+		switch {
+		case x.score > y.score:
+			return -1
+		case x.score < y.score:
+			return 1
+		}
+		return 0
+	})
+
+	members := make([][]int, n)
+	owner := make([]int, n)
+	for i := range entries {
+		members[i], owner[i] = []int{i}, i
+	}
+	for _, p := range pairs {
+		ga, gb := owner[p.a], owner[p.b]
+		if ga == gb ||
+			len(members[ga])+len(members[gb]) > config.MaxConsolidateGroup {
+			continue
+		}
+		total := 0.0
+		for _, x := range members[ga] {
+			for _, y := range members[gb] {
+				total += scores[x][y]
+			}
+		}
+		cross := float64(len(members[ga]) * len(members[gb]))
+		if total/cross < config.ConsolidateThreshold {
+			continue
+		}
+		for _, y := range members[gb] {
+			owner[y] = ga
+		}
+		members[ga] = append(members[ga], members[gb]...)
+		members[gb] = nil
+	}
+
+	var groups []group
+	for i := range entries {
+		m := members[i]
+		if len(m) < 2 {
+			continue
+		}
+		slices.Sort(m)
+		g := group{}
+		best := -1.0
+		for _, x := range m {
+			g.Entries = append(g.Entries, entries[x])
+			centrality := 0.0
+			for _, y := range m {
+				centrality += scores[x][y]
+			}
+			if centrality > best {
+				best, g.Title = centrality, entries[x].Entry.Title
+			}
+		}
+		groups = append(groups, g)
+	}
+	slices.SortStableFunc(groups, func(a, b group) int {
+		return a.Entries[0].StartIndex - b.Entries[0].StartIndex
+	})
+	return groups
+}
+
+// entryFeatures holds what two entries are compared on.
+//
+// Fields:
+//   - shingles: Character shingles of the entry text
+//   - terms: Set of stemmed terms of the entry text
+//   - refs: Backticked paths and qualified symbols, lower case
+type entryFeatures struct {
+	shingles map[string]bool
+	terms    map[string]bool
+	refs     map[string]bool
+}
+
+// newEntryFeatures extracts the features of an entry.
+//
+// Parameters:
+//   - eb: Entry to read
+//
+// Returns:
+//   - entryFeatures: Shingles, terms and references of the entry
+func newEntryFeatures(eb index.EntryBlock) entryFeatures {
+	text := eb.Text()
+	f := entryFeatures{
+		shingles: search.Shingles(text),
+		terms:    make(map[string]bool),
+		refs:     make(map[string]bool),
+	}
+	for _, t := range search.Terms(text) {
+		f.terms[t] = true
+	}
+	for _, line := range eb.Lines {
+		for _, m := range config.RegExCodeSpan.FindAllStringSubmatch(line, -1) {
+			// Paths ("internal/rc/rc.go") and symbols ("rc.ContextDir")
+			// name the same thing wherever they appear; plain words in
+			// backticks do not.
+			if strings.ContainsAny(m[1], "/.") {
+				f.refs[strings.ToLower(m[1])] = true
+			}
+		}
+	}
+	return f
+}
+
+// similarity scores how much two entries overlap.
+//
+// The score is the larger of the shingle and term Jaccard similarities
+// (shingles catch rewording, terms catch the same topic in other
+// words), plus config.ConsolidateRefBonus for each of up to two shared
+// references.
+//
+// Parameters:
+//   - a: Features of the first entry
+//   - b: Features of the second entry
+//
+// Returns:
+//   - float64: Similarity, from 0 upwards
+func similarity(a, b entryFeatures) float64 {
+	shared := 0
+	for r := range a.refs {
+		if b.refs[r] {
+			shared++
+		}
+	}
+	return max(search.Jaccard(a.shingles, b.shingles),
+		search.Jaccard(a.terms, b.terms)) +
+		config.ConsolidateRefBonus*float64(min(shared, 2))
+}
@@ -0,0 +1,77 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Cmd returns the "ctx consolidate" command.
+//
+// Flags:
+//   - --group, -g: Number of the listed group to merge
+//   - --entries: Timestamps of the entries to merge, instead of a group
+//   - --from-file: Read the merged entry from a file instead of an editor
+//   - --yes, -y: Merge from --from-file without asking
+//
+// Returns:
+//   - *cobra.Command: Configured consolidate command with flags registered
+func Cmd() *cobra.Command {
+	var flags consolidateConfig
+
+	cmd := &cobra.Command{
+		Use:   "consolidate [learnings|decisions]",
+		Short: "Merge overlapping learnings or decisions",
+		Long: `Find overlapping entries in LEARNINGS.md and DECISIONS.md and
+merge them into denser ones.
+
+Without flags, lists groups of entries that overlap: similar wording,
+shared keywords, or the same backticked paths and symbols. Each group
+shows the tokens it takes and the tokens a merged entry would save.
+
+With --group (or --entries), merges one group: the merged entry is
+written in your editor ($VISUAL or $EDITOR), starting from a draft, or
+read from --from-file. With --from-file, the entries to replace are
+listed and the merge waits for confirmation (skip it with --yes). The
+merged entry takes the place of the group's first entry, and the
+originals move to .context/archive/<type>-consolidated-<date>.md.
+The merged entry's "Consolidated from" field points to that file, and
+the archive points back to the merged entry.
+
+Superseded decisions are never grouped.
+
+Examples:
+  ctx consolidate
+  ctx consolidate learnings --group 2
+  ctx consolidate learnings --group 2 --from-file merged.md
+  ctx consolidate learnings --entries 2026-01-15,2026-02-03 --from-file merged.md --yes
+  ctx consolidate decisions --entries 2026-01-15-120000,2026-02-03-091500`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"learnings", "decisions"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConsolidate(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().IntVarP(
+		&flags.group, "group", "g", 0, "Number of the listed group to merge",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.entries, "entries", nil,
+		"Timestamps of the entries to merge, instead of a listed group",
+	)
+	cmd.Flags().StringVar(
+		&flags.fromFile, "from-file", "",
+		"Read the merged entry from a file instead of opening an editor",
+	)
+	cmd.Flags().BoolVarP(
+		&flags.yes, "yes", "y", false, "Merge from --from-file without asking",
+	)
+	cmd.MarkFlagsMutuallyExclusive("group", "entries")
+
+	return cmd
+}
@@ -0,0 +1,311 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/assets"
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+const overlappingLearnings = `# Learnings
+
+## [2026-02-03-100000] Hook scripts lose execute permission after sync
+
+**Context**: The hook in ` + "`.claude/hooks/check.sh`" + ` stopped running after a sync.
+
+**Lesson**: Syncing hook scripts can drop the execute bit.
+
+**Application**: Restore +x on hook scripts after every sync.
+
+---
+
+## [2026-01-20-090000] Go embed requires files in the same package
+
+**Context**: Embedding a template from the parent directory failed.
+
+**Lesson**: go:embed only sees files in the package directory or below.
+
+**Application**: Keep embedded assets under internal/assets.
+
+---
+
+## [2026-01-15-120000] Hook scripts need the execute bit
+
+**Context**: ` + "`.claude/hooks/check.sh`" + ` was skipped silently.
+
+**Lesson**: Hook scripts without the execute bit are skipped.
+
+**Application**: Run chmod +x on hook scripts after syncing them.
+`
+
+const mergedLearning = `## [2026-03-01-080000] Hook scripts need +x (consolidated)
+
+**Consolidated from**: edited away
+
+- Syncing drops the execute bit; hooks without it are skipped silently.
+- Restore +x after every sync.
+`
+
+// setupLearnings writes LEARNINGS.md in a temporary context directory.
+func setupLearnings(t *testing.T, content string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	rc.Reset()
+	t.Cleanup(rc.Reset)
+
+	ctxDir := filepath.Join(tempDir, config.DirContext)
+	_ = os.MkdirAll(ctxDir, 0750)
+	path := filepath.Join(ctxDir, config.FileLearning)
+	_ = os.WriteFile(path, []byte(index.UpdateLearnings(content)), 0600)
+	return path
+}
+
+// runConsolidateCmd executes "ctx consolidate" and returns its output.
+func runConsolidateCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := Cmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestFindGroups(t *testing.T) {
+	groups := findGroups(index.ParseEntryBlocks(overlappingLearnings))
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1", len(groups))
+	}
+	g := groups[0]
+	if len(g.Entries) != 2 ||
+		g.Entries[0].Entry.Timestamp != "2026-02-03-100000" ||
+		g.Entries[1].Entry.Timestamp != "2026-01-15-120000" {
+		t.Errorf("group should hold the two hook learnings, got %+v", g.Entries)
+	}
+
+	superseded := strings.Replace(overlappingLearnings,
+		"**Lesson**: Hook scripts without",
+		"~~Superseded~~\n\n**Lesson**: Hook scripts without", 1)
+	if got := findGroups(index.ParseEntryBlocks(superseded)); len(got) != 0 {
+		t.Errorf("superseded entries should not be grouped, got %d groups", len(got))
+	}
+}
+
+func TestDraft(t *testing.T) {
+	g := findGroups(index.ParseEntryBlocks(overlappingLearnings))[0]
+	got := draft(g, config.EntryLearning)
+	for _, want := range []string{
+		" (consolidated)\n\n**Consolidated from**: 2 entries (2026-01-15 to 2026-02-03)\n\n",
+		"- Hook scripts lose execute permission after sync: Syncing hook scripts can drop the execute bit.\n",
+		"- Hook scripts need the execute bit: Hook scripts without the execute bit are skipped.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("draft missing %q:\n%s", want, got)
+		}
+	}
+	if _, err := parseMerged(got); err != nil {
+		t.Errorf("draft should parse as a merged entry: %v", err)
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	path := setupLearnings(t, overlappingLearnings)
+
+	out, err := runConsolidateCmd(t)
+	if err != nil {
+		t.Fatalf("listing failed: %v", err)
+	}
+	for _, want := range []string{
+		"LEARNINGS.md: 1 groups (2 of 3 entries)",
+		"Group 1: ",
+		"[2026-02-03-100000] Hook scripts lose execute permission after sync",
+		"ctx consolidate learnings --group <n>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("listing missing %q:\n%s", want, out)
+		}
+	}
+
+	merged := filepath.Join(t.TempDir(), "merged.md")
+	_ = os.WriteFile(merged, []byte(mergedLearning), 0600)
+	out, err = runConsolidateCmd(t,
+		"learnings", "--group", "1", "--from-file", merged, "--yes",
+	)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if !strings.Contains(out, "Consolidated 2 entries into [2026-03-01-080000]") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	content := string(data)
+	blocks := index.ParseEntryBlocks(content)
+	if len(blocks) != 2 || blocks[0].Entry.Timestamp != "2026-03-01-080000" {
+		t.Fatalf("merged entry should take the place of the group:\n%s", content)
+	}
+	if last := blocks[0].Lines[len(blocks[0].Lines)-1]; last != config.Separator {
+		t.Errorf("merged entry should keep the separator before the next entry:\n%s", content)
+	}
+	from, _ := blocks[0].Field(config.EntryFieldConsolidatedFrom)
+	if !strings.HasPrefix(from, "2 entries (2026-01-15 to 2026-02-03); originals in archive/learnings-consolidated-") {
+		t.Errorf("Consolidated from = %q", from)
+	}
+	if strings.Contains(content, "Hook scripts need the execute bit") {
+		t.Error("originals should be removed from LEARNINGS.md")
+	}
+	if !strings.Contains(content, "| 2026-03-01 | Hook scripts need +x (consolidated) |") {
+		t.Errorf("index should list the merged entry:\n%s", content)
+	}
+	if strings.HasSuffix(strings.TrimSpace(content), config.Separator) {
+		t.Error("removing the last entry should not leave a trailing separator")
+	}
+
+	archives, _ := filepath.Glob(filepath.Join(
+		config.DirContext, config.DirArchive, "learnings-consolidated-*.md",
+	))
+	if len(archives) != 1 {
+		t.Fatalf("got archives %v, want one", archives)
+	}
+	data, _ = os.ReadFile(archives[0]) //nolint:gosec // test temp path
+	for _, want := range []string{
+		config.HeadingArchivedLearnings,
+		"Replaced by [2026-03-01-080000] Hook scripts need +x (consolidated) in LEARNINGS.md.",
+		"## [2026-02-03-100000] Hook scripts lose execute permission after sync",
+		"**Application**: Run chmod +x on hook scripts after syncing them.",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("archive missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestConsolidate_WholeFile(t *testing.T) {
+	tpl, err := assets.Template(config.FileLearning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The two hook learnings, added with "ctx add" to the init template.
+	content := string(tpl)
+	for _, eb := range findGroups(index.ParseEntryBlocks(overlappingLearnings))[0].Entries {
+		content = string(add.AppendEntry([]byte(content),
+			strings.Join(eb.Lines[:eb.ContentEnd()-eb.StartIndex], "\n")+"\n",
+			config.EntryLearning, ""))
+	}
+	path := setupLearnings(t, content)
+
+	merged := filepath.Join(t.TempDir(), "merged.md")
+	_ = os.WriteFile(merged, []byte(mergedLearning), 0600)
+	if _, err = runConsolidateCmd(t,
+		"learnings", "--group", "1", "--from-file", merged, "--yes",
+	); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	got := string(data)
+	for _, want := range []string{
+		"DO NOT UPDATE FOR:",
+		config.IndexStart + "\n| Date | Learning |\n|------|--------|\n" +
+			"| 2026-03-01 | Hook scripts need +x (consolidated) |\n" +
+			config.IndexEnd,
+		"<!-- Add gotchas, tips, and lessons learned here -->",
+		"## [2026-03-01-080000] Hook scripts need +x (consolidated)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("LEARNINGS.md missing %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, config.IndexStart); n != 1 {
+		t.Errorf("got %d index blocks, want 1:\n%s", n, got)
+	}
+	if strings.Index(got, "## [") < strings.Index(got, "<!-- Add gotchas") {
+		t.Errorf("merged entry should stay below the template comment:\n%s", got)
+	}
+	if blocks := index.ParseEntryBlocks(got); len(blocks) != 1 {
+		t.Errorf("got %d entries, want the merged one:\n%s", len(blocks), got)
+	}
+}
+
+func TestConsolidate_Confirm(t *testing.T) {
+	path := setupLearnings(t, overlappingLearnings)
+	merged := filepath.Join(t.TempDir(), "merged.md")
+	_ = os.WriteFile(merged, []byte(mergedLearning), 0600)
+
+	for _, answer := range []string{"n\n", "", "y\n"} {
+		cmd := Cmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetIn(strings.NewReader(answer))
+		cmd.SetArgs([]string{"learnings", "--group", "1", "--from-file", merged})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("answer %q: %v", answer, err)
+		}
+		for _, want := range []string{
+			"Replacing 2 entries:",
+			"[2026-01-15-120000] Hook scripts need the execute bit",
+			"[2026-03-01-080000] Hook scripts need +x (consolidated)",
+			"Merge them? [y/N]",
+		} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("answer %q: output missing %q:\n%s", answer, want, out.String())
+			}
+		}
+
+		data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+		written := strings.Contains(string(data), "(consolidated)")
+		if written != (answer == "y\n") {
+			t.Errorf("answer %q: merged entry written = %v:\n%s", answer, written, data)
+		}
+	}
+}
+
+func TestConsolidateErrors(t *testing.T) {
+	setupLearnings(t, overlappingLearnings)
+	invalid := filepath.Join(t.TempDir(), "invalid.md")
+	_ = os.WriteFile(invalid, []byte("just text\n"), 0600)
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no file type", []string{"--group", "1"}, "name the file"},
+		{"wrong file", []string{"tasks"}, "use learnings or decisions"},
+		{"no such group", []string{"learnings", "--group", "4"}, "has 1 groups"},
+		{"one entry", []string{"learnings", "--entries", "2026-01-15"}, "at least two"},
+		{"unknown entry", []string{"learnings", "--entries", "2026-01-15,1999"}, "no entry matches"},
+		{"from-file alone", []string{"learnings", "--from-file", invalid}, "needs --group"},
+		{
+			"no heading",
+			[]string{"learnings", "--group", "1", "--from-file", invalid},
+			"must be one entry",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runConsolidateCmd(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
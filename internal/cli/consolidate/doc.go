@@ -0,0 +1,12 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package consolidate implements the "ctx consolidate" command.
+//
+// The command groups overlapping LEARNINGS.md and DECISIONS.md entries
+// by similarity and shared references, and replaces a group with one
+// merged entry, moving the originals to .context/archive/.
+package consolidate
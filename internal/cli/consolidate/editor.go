@@ -0,0 +1,69 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// editText lets the user edit text in their editor.
+//
+// The editor is $VISUAL, else $EDITOR, else vi. Editor commands with
+// arguments (e.g., "code --wait") are split on whitespace.
+//
+// Parameters:
+//   - cmd: Cobra command whose output the editor shares
+//   - text: Initial text
+//
+// Returns:
+//   - string: Text as saved by the user
+//   - error: Non-nil if the temporary file cannot be written or read, or
+//     the editor fails
+func editText(cmd *cobra.Command, text string) (string, error) {
+	f, createErr := os.CreateTemp("", "ctx-consolidate-*.md")
+	if createErr != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", createErr)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+	_, writeErr := f.WriteString(text)
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, writeErr)
+	}
+
+	editor := os.Getenv(config.EnvVisual)
+	if editor == "" {
+		editor = os.Getenv(config.EnvEditor)
+	}
+	if editor == "" {
+		editor = config.DefaultEditor
+	}
+	args := strings.Fields(editor)
+	//nolint:gosec // G204: the editor is chosen by the user
+	c := exec.Command(args[0], append(args[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, cmd.OutOrStdout(), cmd.ErrOrStderr()
+	if runErr := c.Run(); runErr != nil {
+		return "", fmt.Errorf("editor %s failed: %w", args[0], runErr)
+	}
+
+	edited, readErr := os.ReadFile(filepath.Clean(path))
+	if readErr != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, readErr)
+	}
+	return string(edited), nil
+}
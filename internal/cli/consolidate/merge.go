@@ -0,0 +1,214 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// draft writes the proposed merged entry of a group.
+//
+// Each original becomes one bullet: its title and the first paragraph
+// of its lesson (learnings) or rationale (decisions). The draft is a
+// starting point to edit, not a finished entry.
+//
+// Parameters:
+//   - g: Group to merge
+//   - entryType: config.EntryLearning or config.EntryDecision
+//
+// Returns:
+//   - string: Merged entry, with a trailing newline
+func draft(g group, entryType string) string {
+	nl := config.NewlineLF
+	title := strings.TrimSuffix(g.Title, config.ConsolidatedSuffix)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"## [%s] %s%s%s%s", time.Now().Format("2006-01-02-150405"),
+		title, config.ConsolidatedSuffix, nl, nl,
+	))
+	summary := config.FieldLesson
+	if entryType == config.EntryDecision {
+		sb.WriteString(fmt.Sprintf(
+			"**%s**: %s%s%s", config.DecisionFieldStatus,
+			index.StatusLabel(config.DecisionStatusAccepted), nl, nl,
+		))
+		summary = config.FieldRationale
+	}
+	sb.WriteString(fmt.Sprintf(
+		"**%s**: %s%s%s", config.EntryFieldConsolidatedFrom, dateRange(g), nl, nl,
+	))
+	for _, eb := range g.Entries {
+		line := "- " + eb.Entry.Title
+		if text := firstParagraph(eb.FieldText(summary)); text != "" {
+			line += ": " + text
+		}
+		sb.WriteString(line + nl)
+	}
+	return sb.String()
+}
+
+// firstParagraph returns the opening paragraph of a field, on one line.
+//
+// Parameters:
+//   - text: Field text, from index.EntryBlock.FieldText
+//
+// Returns:
+//   - string: Lines up to the first blank line, list, fence or heading,
+//     joined with single spaces
+func firstParagraph(text string) string {
+	var words []string
+	for i, line := range strings.Split(text, config.NewlineLF) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || config.RegExFenceLine.MatchString(trimmed) ||
+			strings.HasPrefix(trimmed, "#") ||
+			(i > 0 && config.RegExListStart.MatchString(trimmed)) {
+			break
+		}
+		words = append(words, strings.Fields(trimmed)...)
+	}
+	return strings.Join(words, " ")
+}
+
+// parseMerged reads the merged entry written by the user.
+//
+// Parameters:
+//   - text: Content of the edited file
+//
+// Returns:
+//   - index.EntryBlock: The entry, trimmed of trailing separators
+//   - error: Non-nil unless text holds exactly one entry, starting with
+//     its "## [YYYY-MM-DD-HHMMSS] Title" heading
+func parseMerged(text string) (index.EntryBlock, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", config.NewlineLF))
+	if text == "" {
+		return index.EntryBlock{}, fmt.Errorf(
+			"the merged entry is empty; nothing was changed",
+		)
+	}
+	blocks := index.ParseEntryBlocks(text)
+	if len(blocks) != 1 || blocks[0].StartIndex != 0 {
+		return index.EntryBlock{}, fmt.Errorf(
+			"the merged entry must be one entry starting with a " +
+				"\"## [YYYY-MM-DD-HHMMSS] Title\" heading",
+		)
+	}
+	eb := blocks[0]
+	eb.Lines = eb.Lines[:eb.ContentEnd()]
+	return eb, nil
+}
+
+// consolidate replaces the entries of a group with a merged entry.
+//
+// The originals are removed, and the merged entry takes the place of
+// the first of them, so the header, the index and any comments around
+// the entries stay where they are. Its "**Consolidated from**:" field is
+// set to the number and dates of the originals and the archive file
+// they were moved to. The index is not regenerated.
+//
+// Parameters:
+//   - content: Content of LEARNINGS.md or DECISIONS.md
+//   - g: Group being merged
+//   - merged: Merged entry, from parseMerged
+//   - archive: Archive file, relative to the context directory
+//
+// Returns:
+//   - string: Updated content
+func consolidate(
+	content string, g group, merged index.EntryBlock, archive string,
+) string {
+	merged.Lines = merged.WithField(
+		config.EntryFieldConsolidatedFrom,
+		fmt.Sprintf("%s; originals in %s", dateRange(g), archive),
+	)
+
+	lines := strings.Split(content, config.NewlineLF)
+	originals := slices.Clone(g.Entries)
+	slices.SortFunc(originals, func(a, b index.EntryBlock) int {
+		return b.StartIndex - a.StartIndex
+	})
+	for _, eb := range originals {
+		lines = slices.Delete(lines, eb.StartIndex, eb.EndIndex)
+	}
+
+	// Originals were deleted bottom-up, so the first one's position is
+	// unchanged; an entry that follows needs a separator.
+	at := originals[len(originals)-1].StartIndex
+	entry := slices.Clone(merged.Lines)
+	for _, line := range lines[at:] {
+		if config.RegExEntryHeader.MatchString(line) {
+			entry = append(entry, "", config.Separator)
+			if strings.TrimSpace(lines[at]) != "" {
+				entry = append(entry, "")
+			}
+			break
+		}
+	}
+	lines = slices.Insert(lines, at, entry...)
+
+	// The entry before a removed last entry keeps its separator.
+	end := len(lines)
+	for end > 0 {
+		last := strings.TrimSpace(lines[end-1])
+		if last != "" && last != config.Separator {
+			break
+		}
+		end--
+	}
+	return strings.Join(lines[:end], config.NewlineLF) + config.NewlineLF
+}
+
+// archiveText formats the originals of a group for the archive file.
+//
+// Parameters:
+//   - g: Group being merged
+//   - merged: Merged entry that replaces it
+//   - fileName: Context file name (e.g., "LEARNINGS.md")
+//
+// Returns:
+//   - string: Group heading, back-reference and the original entries
+//     verbatim
+func archiveText(g group, merged index.EntryBlock, fileName string) string {
+	nl := config.NewlineLF
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(
+		"## Group: %s%s%sReplaced by [%s] %s in %s.%s",
+		merged.Entry.Title, nl, nl, merged.Entry.Timestamp, merged.Entry.Title,
+		fileName, nl,
+	))
+	for _, eb := range g.Entries {
+		sb.WriteString(nl)
+		sb.WriteString(strings.Join(
+			eb.Lines[:eb.ContentEnd()-eb.StartIndex], nl,
+		) + nl)
+	}
+	return sb.String()
+}
+
+// dateRange describes the originals of a group.
+//
+// Parameters:
+//   - g: Group to describe
+//
+// Returns:
+//   - string: "3 entries (2026-01-15 to 2026-02-16)"
+func dateRange(g group) string {
+	first, last := g.Entries[0].Entry.Date, g.Entries[0].Entry.Date
+	for _, eb := range g.Entries[1:] {
+		first, last = min(first, eb.Entry.Date), max(last, eb.Entry.Date)
+	}
+	if first == last {
+		return fmt.Sprintf("%d entries (%s)", len(g.Entries), first)
+	}
+	return fmt.Sprintf("%d entries (%s to %s)", len(g.Entries), first, last)
+}
@@ -0,0 +1,316 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/cli/compact"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// runConsolidate executes the consolidate command logic.
+//
+// Without --group or --entries, it lists the proposed groups of the
+// named file, or of both files. With them, it merges one group.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - args: Optional file type ("learnings" or "decisions")
+//   - flags: Flag values
+//
+// Returns:
+//   - error: Non-nil if the arguments are invalid or file operations fail
+func runConsolidate(
+	cmd *cobra.Command, args []string, flags consolidateConfig,
+) error {
+	types := []string{config.EntryLearning, config.EntryDecision}
+	if len(args) == 1 {
+		t := config.UserInputToEntry(args[0])
+		if t != config.EntryLearning && t != config.EntryDecision {
+			return fmt.Errorf(
+				"cannot consolidate %q; use learnings or decisions", args[0],
+			)
+		}
+		types = []string{t}
+	}
+
+	merge := flags.group > 0 || len(flags.entries) > 0
+	switch {
+	case merge && len(args) == 0:
+		return fmt.Errorf(
+			"name the file to merge in, e.g. ctx consolidate learnings --group 1",
+		)
+	case flags.fromFile != "" && !merge:
+		return fmt.Errorf("--from-file needs --group or --entries")
+	case merge:
+		return runMerge(cmd, types[0], flags)
+	}
+
+	for i, t := range types {
+		if i > 0 {
+			cmd.Println()
+		}
+		if err := listGroups(cmd, t, len(args) == 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listGroups prints the proposed groups of a file.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - entryType: config.EntryLearning or config.EntryDecision
+//   - required: If false, a missing file is skipped silently
+//
+// Returns:
+//   - error: Non-nil if the file cannot be read
+func listGroups(cmd *cobra.Command, entryType string, required bool) error {
+	if _, statErr := os.Stat(filepath.Join(
+		rc.ContextDir(), config.FileType[entryType],
+	)); os.IsNotExist(statErr) && !required {
+		return nil
+	}
+	_, name, content, err := readEntries(entryType)
+	if err != nil {
+		return err
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	blocks := index.ParseEntryBlocks(content)
+	groups := findGroups(blocks)
+	if len(groups) == 0 {
+		cmd.Println(fmt.Sprintf(
+			"%s: no overlapping entries (%d entries)", name, len(blocks),
+		))
+		return nil
+	}
+
+	grouped := 0
+	for _, g := range groups {
+		grouped += len(g.Entries)
+	}
+	cmd.Println(fmt.Sprintf(
+		"%s: %d groups (%d of %d entries)", name, len(groups), grouped,
+		len(blocks),
+	))
+	for i, g := range groups {
+		tokens := 0
+		for _, eb := range g.Entries {
+			tokens += context.CountTokensString(eb.BlockContent())
+		}
+		saved := max(tokens-context.CountTokensString(draft(g, entryType)), 0)
+		cmd.Println()
+		cmd.Println(fmt.Sprintf(
+			"Group %d: %s (%d entries, ~%d tokens, saves ~%d)",
+			i+1, g.Title, len(g.Entries), tokens, saved,
+		))
+		for _, eb := range g.Entries {
+			cmd.Println(fmt.Sprintf(
+				"  %s %s", cyan("["+eb.Entry.Timestamp+"]"), eb.Entry.Title,
+			))
+		}
+	}
+	cmd.Println()
+	cmd.Println(fmt.Sprintf(
+		"Merge a group with: ctx consolidate %s --group <n>",
+		strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name))),
+	))
+	return nil
+}
+
+// runMerge replaces a group of entries with one merged entry.
+//
+// A merged entry read from --from-file is written only after the
+// entries it replaces are listed and the merge is confirmed, since
+// group numbers follow the current file and may have shifted since
+// the listing.
+//
+// Parameters:
+//   - cmd: Cobra command for output and the editor
+//   - entryType: config.EntryLearning or config.EntryDecision
+//   - flags: Flag values; group or entries selects the group
+//
+// Returns:
+//   - error: Non-nil if the group cannot be found, the merged entry is
+//     invalid, or file operations fail
+func runMerge(
+	cmd *cobra.Command, entryType string, flags consolidateConfig,
+) error {
+	filePath, name, content, err := readEntries(entryType)
+	if err != nil {
+		return err
+	}
+	plural := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+
+	var g group
+	if flags.group > 0 {
+		groups := findGroups(index.ParseEntryBlocks(content))
+		if flags.group > len(groups) {
+			return fmt.Errorf(
+				"%s has %d groups; run 'ctx consolidate %s' to list them",
+				name, len(groups), plural,
+			)
+		}
+		g = groups[flags.group-1]
+	} else {
+		seen := make(map[int]bool)
+		for _, id := range flags.entries {
+			eb, findErr := add.FindEntry(content, id)
+			if findErr != nil {
+				return findErr
+			}
+			if !seen[eb.StartIndex] {
+				seen[eb.StartIndex] = true
+				g.Entries = append(g.Entries, eb)
+			}
+		}
+		if len(g.Entries) < 2 {
+			return fmt.Errorf("--entries needs at least two entries to merge")
+		}
+		slices.SortFunc(g.Entries, func(a, b index.EntryBlock) int {
+			return a.StartIndex - b.StartIndex
+		})
+		g.Title = g.Entries[0].Entry.Title
+	}
+
+	var text string
+	if flags.fromFile != "" {
+		data, readErr := os.ReadFile(filepath.Clean(flags.fromFile))
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", flags.fromFile, readErr)
+		}
+		text = string(data)
+	} else {
+		edited, editErr := editText(cmd, draft(g, entryType))
+		if editErr != nil {
+			return editErr
+		}
+		text = edited
+	}
+	merged, parseErr := parseMerged(text)
+	if parseErr != nil {
+		return parseErr
+	}
+	// Without an editor, nobody has seen which entries --group picked
+	if flags.fromFile != "" && !confirmMerge(cmd, g, merged, flags.yes) {
+		cmd.Println("No files changed.")
+		return nil
+	}
+
+	heading := config.HeadingArchivedLearnings
+	if entryType == config.EntryDecision {
+		heading = config.HeadingArchivedDecisions
+	}
+	archivePath, archiveErr := compact.WriteArchive(
+		plural+config.ArchiveConsolidated, heading,
+		archiveText(g, merged, name),
+	)
+	if archiveErr != nil {
+		return archiveErr
+	}
+
+	updated := consolidate(
+		content, g, merged,
+		filepath.Join(config.DirArchive, filepath.Base(archivePath)),
+	)
+	if entryType == config.EntryDecision {
+		updated = index.UpdateDecisions(updated)
+	} else {
+		updated = index.UpdateLearnings(updated)
+	}
+	if writeErr := os.WriteFile(
+		filePath, []byte(updated), config.PermFile,
+	); writeErr != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, writeErr)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Println(fmt.Sprintf(
+		"%s Consolidated %d entries into [%s] %s in %s",
+		green("✓"), len(g.Entries), merged.Entry.Timestamp, merged.Entry.Title,
+		name,
+	))
+	cmd.Println(fmt.Sprintf("  Originals archived to %s", archivePath))
+	return nil
+}
+
+// readEntries reads LEARNINGS.md or DECISIONS.md.
+//
+// Parameters:
+//   - entryType: config.EntryLearning or config.EntryDecision
+//
+// Returns:
+//   - string: Path of the file
+//   - string: File name
+//   - string: File content
+//   - error: Non-nil if the file is missing or cannot be read
+func readEntries(entryType string) (string, string, string, error) {
+	name := config.FileType[entryType]
+	filePath := filepath.Join(rc.ContextDir(), name)
+	content, err := os.ReadFile(filepath.Clean(filePath))
+	if os.IsNotExist(err) {
+		return filePath, name, "", fmt.Errorf(
+			"%s not found. Run 'ctx init' first", name,
+		)
+	}
+	if err != nil {
+		return filePath, name, "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return filePath, name, string(content), nil
+}
+
+// confirmMerge lists the entries a merge replaces and asks whether to
+// write it.
+//
+// Parameters:
+//   - cmd: Cobra command for output, the prompt and its input
+//   - g: Entries to replace
+//   - merged: Merged entry
+//   - yes: Confirm without asking (the entries are still listed)
+//
+// Returns:
+//   - bool: True if the merge should be written; false if the answer is
+//     not yes or cannot be read
+func confirmMerge(
+	cmd *cobra.Command, g group, merged index.EntryBlock, yes bool,
+) bool {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	cmd.Println(fmt.Sprintf("Replacing %d entries:", len(g.Entries)))
+	for _, eb := range g.Entries {
+		cmd.Println(fmt.Sprintf(
+			"  %s %s", cyan("["+eb.Entry.Timestamp+"]"), eb.Entry.Title,
+		))
+	}
+	cmd.Println(fmt.Sprintf(
+		"with: %s %s", cyan("["+merged.Entry.Timestamp+"]"), merged.Entry.Title,
+	))
+	if yes {
+		return true
+	}
+	cmd.Print("Merge them? [y/N] ")
+	response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if response == "" {
+		cmd.Println()
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes" //nolint:goconst // trivial user input check
+}
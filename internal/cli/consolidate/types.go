@@ -0,0 +1,33 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package consolidate
+
+import "github.com/ActiveMemory/ctx/internal/index"
+
+// group is a set of entries proposed for merging.
+//
+// Fields:
+//   - Entries: Entries of the group, in file order
+//   - Title: Title of the most central entry, used for the draft
+type group struct {
+	Entries []index.EntryBlock
+	Title   string
+}
+
+// consolidateConfig holds the flags of the consolidate command.
+//
+// Fields:
+//   - group: Number of the listed group to merge (1-based); 0 for none
+//   - entries: Timestamps of entries to merge, instead of a group
+//   - fromFile: File holding the merged entry; empty opens an editor
+//   - yes: Merge from fromFile without asking
+type consolidateConfig struct {
+	group    int
+	entries  []string
+	fromFile string
+	yes      bool
+}
@@ -0,0 +1,271 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// adrSections maps ADR section headings (lower case) to the record
+// fields they fill. Nygard records use the short names, MADR the long
+// ones.
+var adrSections = map[string]string{
+	"status":                        adrStatus,
+	"context":                       adrContext,
+	"context and problem statement": adrContext,
+	"decision":                      adrDecision,
+	"decision outcome":              adrDecision,
+	"consequences":                  adrConsequences,
+	"positive consequences":         adrConsequences,
+	"negative consequences":         adrConsequences,
+}
+
+// Record fields filled from ADR sections.
+const (
+	adrStatus       = "status"
+	adrContext      = "context"
+	adrDecision     = "decision"
+	adrConsequences = "consequences"
+)
+
+// readADRs reads the ADR files of a directory.
+//
+// Only files named like "0001-title.md" are read, so READMEs, indexes
+// and templates next to the records are left alone.
+//
+// Parameters:
+//   - dir: Directory holding the records (e.g., docs/adr)
+//
+// Returns:
+//   - []adrRecord: Records ordered by number
+//   - error: Non-nil if the directory or a record cannot be read
+func readADRs(dir string) ([]adrRecord, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ADR directory %s: %w", dir, err)
+	}
+
+	var records []adrRecord
+	for _, e := range entries {
+		if e.IsDir() || !config.RegExADRFile.MatchString(e.Name()) {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		info, infoErr := e.Info()
+		if infoErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, infoErr)
+		}
+		content, readErr := os.ReadFile(filepath.Clean(p))
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", p, readErr)
+		}
+		records = append(records, parseADR(e.Name(), string(content), info.ModTime()))
+	}
+
+	slices.SortStableFunc(records, func(a, b adrRecord) int {
+		return a.Number - b.Number
+	})
+	return records, nil
+}
+
+// parseADR parses an architecture decision record.
+//
+// Both common layouts are understood: Nygard records (adr-tools), with
+// a "Date:" line and Status, Context, Decision and Consequences
+// sections; and MADR records, with status and date in YAML front matter
+// (MADR 3) or a "* Status:" list under the title (MADR 2), and Context
+// and Problem Statement and Decision Outcome sections. Other sections,
+// such as Considered Options, are not imported.
+//
+// Parameters:
+//   - file: Base name of the file (e.g., "0001-use-postgres.md")
+//   - content: File content
+//   - modTime: File modification time, the date if the record has none
+//
+// Returns:
+//   - adrRecord: The parsed record
+func parseADR(file, content string, modTime time.Time) adrRecord {
+	r := adrRecord{File: file}
+	if m := config.RegExADRFile.FindStringSubmatch(file); m != nil {
+		r.Number, _ = strconv.Atoi(m[1])
+	}
+
+	lines := strings.Split(
+		strings.ReplaceAll(content, "\r\n", config.NewlineLF), config.NewlineLF,
+	)
+	var fm adrFrontMatter
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == config.Separator {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == config.Separator {
+				_ = yaml.Unmarshal(
+					[]byte(strings.Join(lines[1:i], config.NewlineLF)), &fm,
+				)
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+	status, date := fm.Status, fm.Date
+	r.CtxID = fm.CtxID
+
+	bodies := make(map[string][]string)
+	section := ""
+	inFence := false
+	for _, line := range lines {
+		if config.RegExFenceLine.MatchString(line) {
+			inFence = !inFence
+		}
+		if m := config.RegExMarkdownHeading.FindStringSubmatch(line); m != nil && !inFence {
+			text := strings.TrimSpace(m[2])
+			key := adrSections[strings.ToLower(text)]
+			switch {
+			case len(m[1]) == 1 && r.Title == "":
+				r.Title = config.RegExADRTitleNumber.ReplaceAllString(text, "")
+				section = ""
+			case key != "":
+				section = key
+				if key == adrConsequences && !strings.EqualFold(text, key) {
+					bodies[key] = append(bodies[key], "", "**"+text+"**", "")
+				}
+			case len(m[1]) == 2:
+				section = ""
+			case section != "":
+				bodies[section] = append(bodies[section], "", "**"+text+"**", "")
+			}
+			continue
+		}
+
+		if section == "" {
+			key, value := adrPreamble(line)
+			switch {
+			case key == adrStatus && status == "":
+				status = value
+			case key == "date" && date == "":
+				date = value
+			}
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, config.CommentOpen) &&
+			strings.HasSuffix(trimmed, config.CommentClose) {
+			continue
+		}
+		bodies[section] = append(bodies[section], line)
+	}
+
+	text := func(key string) string {
+		return strings.TrimSpace(strings.Join(bodies[key], config.NewlineLF))
+	}
+	if status == "" {
+		status = text(adrStatus)
+	}
+	r.Status = adrStatusOf(status)
+	if m := config.RegExADRSupersededBy.FindStringSubmatch(status); m != nil {
+		r.SupersededBy = m[2]
+		if m[1] != "" {
+			r.SupersededBy = path.Base(m[1])
+		}
+	}
+	r.Context, r.Decision, r.Consequences =
+		text(adrContext), text(adrDecision), text(adrConsequences)
+
+	r.Date = modTime.Format("2006-01-02")
+	if len(date) >= 10 {
+		if _, err := time.Parse("2006-01-02", date[:10]); err == nil {
+			r.Date = date[:10]
+		}
+	}
+	if r.Title == "" {
+		r.Title = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+
+	return r
+}
+
+// adrPreamble reads a "Key: value" line before the first section, like
+// Nygard's "Date: 2016-02-12" or MADR 2's "* Status: accepted".
+//
+// Parameters:
+//   - line: Line to read
+//
+// Returns:
+//   - string: Lower-case key; empty if the line is not a field
+//   - string: Trimmed value
+func adrPreamble(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSpace(strings.TrimLeft(line, "*-"))
+	key, value, ok := strings.Cut(line, ":")
+	if !ok || strings.Contains(key, " ") {
+		return "", ""
+	}
+	return strings.ToLower(key), strings.TrimSpace(value)
+}
+
+// adrStatusOf maps an ADR status to a ctx decision status.
+//
+// Any mention of "superseded" wins, since adr-tools appends the
+// "Superseded by" link to the old status. MADR's "rejected" maps to
+// deprecated: the decision is not in effect. Records without a
+// recognized status are accepted.
+//
+// Parameters:
+//   - status: Status text of the record
+//
+// Returns:
+//   - string: One of config.DecisionStatuses
+func adrStatusOf(status string) string {
+	lower := strings.ToLower(status)
+	if strings.Contains(lower, config.DecisionStatusSuperseded) {
+		return config.DecisionStatusSuperseded
+	}
+	for _, word := range strings.Fields(lower) {
+		switch word = strings.Trim(word, ".,;:*_"); word {
+		case config.DecisionStatusProposed, config.DecisionStatusAccepted,
+			config.DecisionStatusDeprecated:
+			return word
+		case "rejected":
+			return config.DecisionStatusDeprecated
+		case "draft":
+			return config.DecisionStatusProposed
+		}
+	}
+	return config.DecisionStatusAccepted
+}
+
+// refersTo reports whether a "Superseded by" target names a record.
+//
+// Parameters:
+//   - target: File name or number from adrRecord.SupersededBy
+//
+// Returns:
+//   - bool: True if target is r's file name or number
+func (r adrRecord) refersTo(target string) bool {
+	if target == r.File {
+		return true
+	}
+	n, err := strconv.Atoi(target)
+	return err == nil && n == r.Number
+}
+
+// sourceID returns the ID that ties an imported entry to its file.
+//
+// Returns:
+//   - string: "adr:" followed by the file name
+func (r adrRecord) sourceID() string {
+	return config.ADRSourcePrefix + r.File
+}
@@ -6,7 +6,11 @@
 
 package decision
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
 
 // reindexCmd returns the reindex subcommand.
 //
@@ -29,3 +33,152 @@ Examples:
 		RunE: runReindex,
 	}
 }
+
+// listCmd returns the list subcommand.
+//
+// Flags:
+//   - --status: Show only decisions with this status
+//   - --json: Output as JSON
+//
+// Returns:
+//   - *cobra.Command: Command for listing decisions with their status
+func listCmd() *cobra.Command {
+	var (
+		status  string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List decisions with their status",
+		Long: `List the decisions in DECISIONS.md with their status.
+
+Statuses follow the ADR lifecycle: proposed, accepted, deprecated and
+superseded. Entries that state no status are accepted.
+
+Examples:
+  ctx decisions list
+  ctx decisions list --status accepted
+  ctx decisions list --status superseded --json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(cmd, status, jsonOut)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&status, "status", "",
+		"Show only decisions with this status (proposed, accepted, deprecated, superseded)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc("status", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return config.DecisionStatuses, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// supersedeCmd returns the supersede subcommand.
+//
+// Flags:
+//   - --by: The decision that replaces the old one (required)
+//
+// Returns:
+//   - *cobra.Command: Command for marking a decision as superseded
+func supersedeCmd() *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "supersede <old> --by <new>",
+		Short: "Mark a decision as superseded by another",
+		Long: `Mark a decision as superseded by a newer one.
+
+The old decision gets "**Status**: Superseded" and a "**Superseded by**:"
+link; the new one gets a "**Supersedes**:" link back. Both entries stay
+in DECISIONS.md, and the index is regenerated.
+
+A decision is given by its timestamp (e.g., 2026-01-28-051426) or by
+a unique part of its title. Decisions added in the same second are
+given by timestamp and title, as in "[2026-01-28-051426] Use YAML".
+
+Examples:
+  ctx decisions supersede 2026-01-28-051426 --by 2026-02-10-093000
+  ctx decisions supersede "Use YAML" --by "Use TOML"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSupersede(cmd, args[0], by)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&by, "by", "", "Timestamp or title of the decision that replaces it",
+	)
+	_ = cmd.MarkFlagRequired("by")
+
+	return cmd
+}
+
+// importCmd returns the import subcommand.
+//
+// Returns:
+//   - *cobra.Command: Command for importing ADR files into DECISIONS.md
+func importCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Import ADR files (MADR, Nygard) into DECISIONS.md",
+		Long: `Import architecture decision records into DECISIONS.md.
+
+Reads the files named like "0001-title.md" in <dir>, in MADR or Nygard
+(adr-tools) format. Each record becomes a decision entry with its date
+and status; its context, decision and consequences sections fill the
+Context, Rationale and Consequences fields. "Superseded by" links become
+reciprocal Supersedes / Superseded by links.
+
+Each entry records its file as "**Source**: adr:<file>". Importing again
+updates these entries instead of adding duplicates. The index is
+regenerated afterwards.
+
+Examples:
+  ctx decisions import docs/adr`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd, args[0])
+		},
+	}
+}
+
+// exportCmd returns the export subcommand.
+//
+// Flags:
+//   - --format: Record format (only "madr")
+//
+// Returns:
+//   - *cobra.Command: Command for writing decisions as ADR files
+func exportCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "export <dir>",
+		Short: "Export decisions as ADR files",
+		Long: `Export the decisions in DECISIONS.md as architecture decision records.
+
+Writes one MADR file per decision to <dir>, numbered oldest first
+("0001-title.md"). The front matter holds the status, the date and the
+entry timestamp as ctx-id. Exporting again rewrites the same files, and
+importing them back updates the entries they came from. Only files whose
+content changed are written.
+
+Examples:
+  ctx decisions export docs/adr
+  ctx decisions export --format madr docs/decisions`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd, args[0], format)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&format, "format", config.ADRFormatMADR, "Record format (madr)",
+	)
+
+	return cmd
+}
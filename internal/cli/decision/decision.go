@@ -14,7 +14,8 @@ import (
 // Cmd returns the decisions command with subcommands.
 //
 // The decisions command provides utilities for managing the DECISIONS.md file,
-// including regenerating the quick-reference index.
+// including regenerating the quick-reference index, tracking the status
+// of each decision, and converting to and from ADR files.
 //
 // Returns:
 //   - *cobra.Command: The decisions command with subcommands
@@ -25,15 +26,27 @@ func Cmd() *cobra.Command {
 		Long: `Manage the DECISIONS.md file and its quick-reference index.
 
 The decisions file maintains an auto-generated index at the top for quick
-scanning. Use the subcommands to manage this index.
+scanning. Each decision has a status: proposed, accepted, deprecated or
+superseded. Use the subcommands to manage the index and the statuses.
 
 Subcommands:
+  list       List decisions with their status
+  supersede  Mark a decision as superseded by another
+  import     Import ADR files (MADR, Nygard) into DECISIONS.md
+  export     Export decisions as MADR files
   reindex    Regenerate the quick-reference index
 
 Examples:
+  ctx decisions list --status accepted
+  ctx decisions supersede 2026-01-28-051426 --by 2026-02-10-093000
+  ctx decisions import docs/adr
   ctx decisions reindex`,
 	}
 
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(supersedeCmd())
+	cmd.AddCommand(importCmd())
+	cmd.AddCommand(exportCmd())
 	cmd.AddCommand(reindexCmd())
 
 	return cmd
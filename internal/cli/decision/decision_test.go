@@ -7,8 +7,11 @@
 package decision
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -138,3 +141,450 @@ func TestRunReindex_EmptyFile(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+// lifecycleDecisions is a DECISIONS.md with three decisions.
+const lifecycleDecisions = `# Decisions
+
+## [2026-02-10-093000] Use TOML for config
+
+**Status**: Accepted
+
+**Context**: YAML indentation bites
+
+## [2026-01-28-051426] Use YAML for config
+
+**Status**: Accepted
+
+**Context**: Need a config format
+
+## [2026-01-20-120000] Try JSON5
+
+**Status**: Proposed
+`
+
+// setupDecisions writes DECISIONS.md into a temp project and chdirs
+// into it.
+func setupDecisions(t *testing.T, content string) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+
+	rc.Reset()
+	t.Cleanup(rc.Reset)
+
+	ctxDir := filepath.Join(tempDir, config.DirContext)
+	_ = os.MkdirAll(ctxDir, 0750)
+	path := filepath.Join(ctxDir, config.FileDecision)
+	_ = os.WriteFile(path, []byte(content), 0600)
+	return path
+}
+
+// runDecisions executes "ctx decisions" with args and returns its output.
+func runDecisions(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := Cmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestSupersedeAndList(t *testing.T) {
+	path := setupDecisions(t, lifecycleDecisions)
+
+	if _, err := runDecisions(t, "supersede", "yaml", "--by", "2026-02-10"); err != nil {
+		t.Fatalf("supersede failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	content := string(data)
+	for _, want := range []string{
+		"**Status**: Accepted\n\n**Supersedes**: [2026-01-28-051426] Use YAML for config\n\n**Context**: YAML",
+		"**Status**: Superseded\n\n**Superseded by**: [2026-02-10-093000] Use TOML for config\n\n**Context**: Need",
+		"| 2026-01-28 | Use YAML for config | superseded |",
+		"| 2026-02-10 | Use TOML for config | accepted |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("DECISIONS.md missing %q:\n%s", want, content)
+		}
+	}
+
+	// Running it again is a no-op.
+	if _, err := runDecisions(t, "supersede", "2026-01-28-051426", "--by", "TOML"); err != nil {
+		t.Fatalf("repeated supersede failed: %v", err)
+	}
+	again, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	if string(again) != content {
+		t.Errorf("repeated supersede changed the file:\n%s", again)
+	}
+
+	out, err := runDecisions(t, "list", "--status", "accepted", "--json")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var items []decisionItem
+	if jsonErr := json.Unmarshal([]byte(out), &items); jsonErr != nil {
+		t.Fatalf("invalid JSON: %v\n%s", jsonErr, out)
+	}
+	if len(items) != 1 || items[0].Title != "Use TOML for config" ||
+		items[0].Supersedes != "[2026-01-28-051426] Use YAML for config" {
+		t.Errorf("list --status accepted = %+v", items)
+	}
+
+	out, err = runDecisions(t, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(out, "superseded by [2026-02-10-093000] Use TOML") ||
+		!strings.Contains(out, "proposed") {
+		t.Errorf("list output:\n%s", out)
+	}
+}
+
+func TestSupersedeErrors(t *testing.T) {
+	setupDecisions(t, lifecycleDecisions)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown", []string{"supersede", "XML", "--by", "TOML"}, "no decision matches"},
+		{"ambiguous", []string{"supersede", "for config", "--by", "JSON5"}, "matches 2 decisions"},
+		{"self", []string{"supersede", "TOML", "--by", "TOML"}, "cannot supersede itself"},
+		{"status", []string{"list", "--status", "rejected"}, "unknown status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runDecisions(t, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := runDecisions(t, "supersede", "YAML", "--by", "TOML"); err != nil {
+		t.Fatalf("supersede failed: %v", err)
+	}
+	_, err := runDecisions(t, "supersede", "YAML", "--by", "JSON5")
+	if err == nil || !strings.Contains(err.Error(), "already superseded") {
+		t.Errorf("error = %v, want already superseded", err)
+	}
+}
+
+func TestSupersede_SameSecond(t *testing.T) {
+	path := setupDecisions(t, `# Decisions
+
+## [2026-03-01-100000] Use SQLite
+
+**Status**: Accepted
+
+## [2026-03-01-100000] Use Postgres
+
+**Status**: Accepted
+`)
+
+	_, err := runDecisions(t, "supersede", "2026-03-01-100000", "--by", "Postgres")
+	if err == nil || !strings.Contains(err.Error(), "timestamp and title") ||
+		!strings.Contains(err.Error(), "[2026-03-01-100000] Use SQLite") {
+		t.Errorf("error = %v, want a hint to add the title", err)
+	}
+
+	if _, err = runDecisions(t,
+		"supersede", "[2026-03-01-100000] use sqlite",
+		"--by", "2026-03-01-100000 Use Postgres",
+	); err != nil {
+		t.Fatalf("supersede by timestamp and title failed: %v", err)
+	}
+	data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	if !strings.Contains(string(data),
+		"**Superseded by**: [2026-03-01-100000] Use Postgres") {
+		t.Errorf("DECISIONS.md:\n%s", data)
+	}
+}
+
+func TestExport_SameSecond(t *testing.T) {
+	setupDecisions(t, `# Decisions
+
+## [2026-03-02-090000] Add a cache
+
+**Status**: Accepted
+
+## [2026-03-01-100000] Use SQLite
+
+**Status**: Superseded
+
+**Superseded by**: [2026-03-02-090000] Add a cache
+
+## [2026-03-01-100000] Use Postgres
+
+**Status**: Accepted
+
+## [2026-02-01-080000] Record decisions
+
+**Status**: Accepted
+`)
+
+	out, err := runDecisions(t, "export", "out")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if !strings.Contains(out, "4 written, 0 unchanged") {
+		t.Errorf("export output:\n%s", out)
+	}
+	files, _ := os.ReadDir("out")
+	if len(files) != 4 {
+		t.Fatalf("export wrote %d files, want 4", len(files))
+	}
+	sqlite, readErr := os.ReadFile(filepath.Join("out", "0002-use-sqlite.md"))
+	if readErr != nil {
+		t.Fatalf("same-second decisions should get their own files: %v", readErr)
+	}
+	if !strings.Contains(string(sqlite), "superseded by [Add a cache](0004-add-a-cache.md)") {
+		t.Errorf("exported record:\n%s", sqlite)
+	}
+
+	// Both records keep their files and import back onto their entries.
+	out, _ = runDecisions(t, "export", "out")
+	if !strings.Contains(out, "0 written, 4 unchanged") {
+		t.Errorf("repeated export:\n%s", out)
+	}
+	out, _ = runDecisions(t, "import", "out")
+	if !strings.Contains(out, "0 added") {
+		t.Errorf("import of exported records:\n%s", out)
+	}
+}
+
+// adrFixtures are records in the layouts "ctx decisions import" reads:
+// Nygard (adr-tools), MADR 3 with front matter and MADR 2 with a status
+// list.
+var adrFixtures = map[string]string{
+	"0001-record-architecture-decisions.md": `# 1. Record architecture decisions
+
+Date: 2016-02-12
+
+## Status
+
+Accepted
+
+## Context
+
+We need to record decisions.
+
+## Decision
+
+We will use ADRs.
+
+## Consequences
+
+See Nygard's article.
+`,
+	"0002-use-yaml.md": `# 2. Use YAML for config
+
+Date: 2016-03-01
+
+## Status
+
+Accepted
+
+Superseded by [3. Use TOML](0003-use-toml.md)
+
+## Context
+
+Config needed.
+
+## Decision
+
+YAML.
+
+` + "```yaml\n# not a heading\na: 1\n```" + `
+
+## Consequences
+
+Indentation matters.
+`,
+	"0003-use-toml.md": `---
+status: accepted
+date: 2016-04-01
+---
+# Use TOML
+
+## Context and Problem Statement
+
+YAML indentation bites.
+
+## Considered Options
+
+* TOML
+* JSON
+
+## Decision Outcome
+
+Chosen option: "TOML", because it is simple.
+
+### Consequences
+
+* Good, because flat.
+`,
+	"0004-drop-json.md": `# Drop JSON configs
+
+* Status: rejected
+* Date: 2016-05-01
+
+## Context and Problem Statement
+
+JSON has no comments.
+`,
+	"README.md": "# Decision records\n",
+}
+
+// writeADRs writes adrFixtures to a directory.
+func writeADRs(t *testing.T, dir string) {
+	t.Helper()
+	_ = os.MkdirAll(dir, 0750)
+	for name, content := range adrFixtures {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadADRs(t *testing.T) {
+	dir := t.TempDir()
+	writeADRs(t, dir)
+
+	records, err := readADRs(dir)
+	if err != nil {
+		t.Fatalf("readADRs() error: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("readADRs() = %d records, want 4 (README skipped)", len(records))
+	}
+
+	want := []adrRecord{
+		{Number: 1, Title: "Record architecture decisions", Date: "2016-02-12",
+			Status: "accepted", Context: "We need to record decisions.",
+			Decision: "We will use ADRs.", Consequences: "See Nygard's article."},
+		{Number: 2, Title: "Use YAML for config", Date: "2016-03-01",
+			Status: "superseded", SupersededBy: "0003-use-toml.md",
+			Context: "Config needed.", Consequences: "Indentation matters."},
+		{Number: 3, Title: "Use TOML", Date: "2016-04-01", Status: "accepted",
+			Context:      "YAML indentation bites.",
+			Decision:     `Chosen option: "TOML", because it is simple.`,
+			Consequences: "* Good, because flat."},
+		{Number: 4, Title: "Drop JSON configs", Date: "2016-05-01",
+			Status: "deprecated", Context: "JSON has no comments."},
+	}
+	for i, w := range want {
+		r := records[i]
+		if r.Number != w.Number || r.Title != w.Title || r.Date != w.Date ||
+			r.Status != w.Status || r.SupersededBy != w.SupersededBy ||
+			r.Context != w.Context || r.Consequences != w.Consequences {
+			t.Errorf("record %d = %+v, want %+v", i, r, w)
+		}
+		if w.Decision != "" && r.Decision != w.Decision {
+			t.Errorf("record %d decision = %q, want %q", i, r.Decision, w.Decision)
+		}
+	}
+	if !strings.Contains(records[1].Decision, "# not a heading") {
+		t.Errorf("code blocks should stay in the section: %q", records[1].Decision)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	path := setupDecisions(t, lifecycleDecisions)
+	writeADRs(t, filepath.Join("docs", "adr"))
+
+	out, err := runDecisions(t, "import", "docs/adr")
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+	if !strings.Contains(out, "4 added, 0 updated, 0 unchanged") {
+		t.Errorf("import output:\n%s", out)
+	}
+
+	data, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	content := string(data)
+	for _, want := range []string{
+		"## [2016-04-01-000003] Use TOML",
+		"**Source**: adr:0003-use-toml.md",
+		"**Supersedes**: [2016-03-01-000002] Use YAML for config",
+		"**Superseded by**: [2016-04-01-000003] Use TOML",
+		"**Rationale**: We will use ADRs.",
+		"**Consequences**:\n\n* Good, because flat.",
+		"| 2016-05-01 | Drop JSON configs | deprecated |",
+		"## [2026-02-10-093000] Use TOML for config",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("DECISIONS.md missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "## [2016-05-01-000004]") >
+		strings.Index(content, "## [2026-02-10-093000]") {
+		t.Error("imported entries should go on top, like added ones")
+	}
+
+	// A second import changes nothing; an edited record updates its entry.
+	out, _ = runDecisions(t, "import", "docs/adr")
+	again, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	if string(again) != content || !strings.Contains(out, "4 unchanged") {
+		t.Errorf("repeated import changed DECISIONS.md:\n%s", out)
+	}
+	toml := filepath.Join("docs", "adr", "0003-use-toml.md")
+	_ = os.WriteFile(toml, []byte(strings.Replace(
+		adrFixtures["0003-use-toml.md"], "bites.", "bites hard.", 1,
+	)), 0600)
+	out, _ = runDecisions(t, "import", "docs/adr")
+	if !strings.Contains(out, "0 added, 1 updated, 3 unchanged") {
+		t.Errorf("import after edit:\n%s", out)
+	}
+
+	// Export writes MADR files that import back onto the same entries.
+	if _, err := runDecisions(t, "export", "--format", "madr", "out"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	files, _ := os.ReadDir("out")
+	if len(files) != 7 {
+		t.Errorf("export wrote %d files, want 7", len(files))
+	}
+	yaml, readErr := os.ReadFile(filepath.Join("out", "0002-use-yaml-for-config.md"))
+	if readErr != nil {
+		t.Fatalf("records should be numbered oldest first: %v", readErr)
+	}
+	for _, want := range []string{
+		"status: superseded by [Use TOML](0003-use-toml.md)",
+		"ctx-id: 2016-03-01-000002",
+		"## Decision Outcome\n\nYAML.",
+	} {
+		if !strings.Contains(string(yaml), want) {
+			t.Errorf("exported record missing %q:\n%s", want, yaml)
+		}
+	}
+	if _, statErr := os.Stat(
+		filepath.Join("out", "0005-try-json5.md"),
+	); statErr != nil {
+		t.Errorf("records should be named after their title: %v", statErr)
+	}
+
+	out, _ = runDecisions(t, "export", "out")
+	if !strings.Contains(out, "0 written, 7 unchanged") {
+		t.Errorf("repeated export:\n%s", out)
+	}
+	before, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	out, _ = runDecisions(t, "import", "out")
+	if !strings.Contains(out, "0 added") {
+		t.Errorf("import of exported records added entries:\n%s", out)
+	}
+	after, _ := os.ReadFile(path) //nolint:gosec // test temp path
+	if n := strings.Count(string(after), "## [2"); n != strings.Count(string(before), "## [2") {
+		t.Errorf("entry count changed on round trip: %d", n)
+	}
+
+	if _, err := runDecisions(t, "export", "--format", "nygard", "out"); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+}
@@ -0,0 +1,209 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+	"github.com/ActiveMemory/ctx/internal/validation"
+)
+
+// exportFiles assigns an ADR file name to each decision.
+//
+// A decision keeps the file it was exported to before (found by the
+// ctx-id in the file's front matter, and the title for decisions added
+// in the same second) or imported from (found by its "**Source**:").
+// Other decisions get the next free numbers, oldest first, named after
+// their title. No two decisions get the same file.
+//
+// Parameters:
+//   - decisions: Decisions from index.ParseDecisions
+//   - existing: Records already in the export directory
+//
+// Returns:
+//   - []string: File name of each decision, in the order of decisions
+func exportFiles(decisions []index.Decision, existing []adrRecord) []string {
+	next := 1
+	for _, r := range existing {
+		next = max(next, r.Number+1)
+	}
+
+	files := make([]string, len(decisions))
+	claimed := make(map[string]bool)
+	order := oldestFirst(decisions)
+	// Each pass keeps the files of decisions its rule matches; a looser
+	// rule only sees the files left over by the stricter ones.
+	for _, matches := range []func(d index.Decision, r adrRecord) bool{
+		func(d index.Decision, r adrRecord) bool {
+			return r.CtxID == d.Entry.Timestamp && r.Title == d.Entry.Title
+		},
+		func(d index.Decision, r adrRecord) bool {
+			return r.CtxID == d.Entry.Timestamp
+		},
+		func(d index.Decision, r adrRecord) bool {
+			source, _ := d.Field(config.DecisionFieldSource)
+			return source == r.sourceID()
+		},
+	} {
+		for _, i := range order {
+			if files[i] != "" {
+				continue
+			}
+			for _, r := range existing {
+				if !claimed[r.File] && matches(decisions[i], r) {
+					files[i], claimed[r.File] = r.File, true
+					break
+				}
+			}
+		}
+	}
+
+	for _, i := range order {
+		if files[i] == "" {
+			files[i] = fmt.Sprintf(
+				"%04d-%s.md", next,
+				validation.SanitizeFilename(decisions[i].Entry.Title),
+			)
+			next++
+		}
+	}
+	return files
+}
+
+// supersedingFile finds the file of the decision a reference names.
+//
+// Parameters:
+//   - decisions: Decisions from index.ParseDecisions
+//   - files: File names from exportFiles
+//   - ref: Reference, as in a "**Superseded by**:" field
+//
+// Returns:
+//   - string: File name; empty if no decision matches
+func supersedingFile(decisions []index.Decision, files []string, ref string) string {
+	for i := range decisions {
+		if decisions[i].IsRef(ref) {
+			return files[i]
+		}
+	}
+	// The title may have been edited since the link was written
+	if ts := supersedingEntry(ref); ts != "" {
+		for i := range decisions {
+			if decisions[i].Entry.Timestamp == ts {
+				return files[i]
+			}
+		}
+	}
+	return ""
+}
+
+// renderMADR formats a decision as a MADR record.
+//
+// The front matter carries the status, the date and the entry's
+// timestamp as ctx-id, so a later export rewrites the same file and an
+// import of the file updates the entry instead of adding a new one.
+// The rationale becomes the Decision Outcome, preceded by the Decision
+// field if it says more than the title.
+//
+// Parameters:
+//   - d: Decision to format
+//   - supersededBy: File of the decision that supersedes d; empty if
+//     there is none
+//
+// Returns:
+//   - string: Content of the record file
+func renderMADR(d index.Decision, supersededBy string) string {
+	status := d.Status
+	if supersededBy != "" {
+		status = fmt.Sprintf(
+			"%s by [%s](%s)", status, refTitle(d.SupersededBy), supersededBy,
+		)
+	}
+	fm, _ := yaml.Marshal(adrFrontMatter{
+		Status: status, Date: d.Entry.Date, CtxID: d.Entry.Timestamp,
+	})
+
+	nl := config.NewlineLF
+	var sb strings.Builder
+	sb.WriteString(config.Separator + nl)
+	sb.Write(fm)
+	sb.WriteString(config.Separator + nl + nl)
+	sb.WriteString("# " + d.Entry.Title + nl)
+
+	section := func(heading, text string) {
+		if text != "" {
+			sb.WriteString(nl + heading + nl + nl + text + nl)
+		}
+	}
+	outcome := d.FieldText(config.FieldRationale)
+	if decision := d.FieldText(config.FieldDecision); decision != "" &&
+		decision != d.Entry.Title {
+		outcome = strings.TrimSpace(decision + nl + nl + outcome)
+	}
+	section("## Context and Problem Statement", d.FieldText(config.FieldContext))
+	section("## Decision Outcome", outcome)
+	section("### Consequences", d.FieldText(config.FieldConsequence))
+
+	return sb.String()
+}
+
+// supersedingEntry extracts the timestamp from a "[timestamp] Title"
+// reference.
+//
+// Parameters:
+//   - ref: Reference, as in a "**Superseded by**:" field
+//
+// Returns:
+//   - string: Timestamp; empty if ref is not a reference
+func supersedingEntry(ref string) string {
+	m := config.RegExEntryHeader.FindStringSubmatch("## " + ref)
+	if m == nil {
+		return ""
+	}
+	return m[1] + "-" + m[2]
+}
+
+// refTitle extracts the title from a "[timestamp] Title" reference.
+//
+// Parameters:
+//   - ref: Reference
+//
+// Returns:
+//   - string: Title, or ref itself if it has no timestamp
+func refTitle(ref string) string {
+	if _, title, ok := strings.Cut(ref, "] "); ok {
+		return title
+	}
+	return ref
+}
+
+// oldestFirst returns the positions of decisions in chronological order.
+//
+// Decisions with the same timestamp keep their file order.
+//
+// Parameters:
+//   - decisions: Decisions in file order (newest first)
+//
+// Returns:
+//   - []int: Indexes into decisions, oldest first
+func oldestFirst(decisions []index.Decision) []int {
+	order := make([]int, len(decisions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return strings.Compare(
+			decisions[a].Entry.Timestamp, decisions[b].Entry.Timestamp,
+		)
+	})
+	return order
+}
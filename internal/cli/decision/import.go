@@ -0,0 +1,224 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/cli/add"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// importADRs merges ADR records into DECISIONS.md content.
+//
+// A record updates the entry that came from it: the entry whose
+// "**Source**:" is the record's source ID or, for records written by
+// "ctx decisions export", whose timestamp is the record's ctx-id. Other
+// records become new entries, timestamped with the record's date and
+// number. "Superseded by" links between records become reciprocal
+// "**Supersedes**:" and "**Superseded by**:" links. The index is not
+// regenerated.
+//
+// Parameters:
+//   - content: The full content of DECISIONS.md
+//   - records: Records from readADRs
+//
+// Returns:
+//   - string: Updated content
+//   - importResult: Counts of added, updated and unchanged entries
+func importADRs(content string, records []adrRecord) (string, importResult) {
+	var result importResult
+	decisions := index.ParseDecisions(content)
+
+	used := make(map[string]bool)
+	for _, d := range decisions {
+		used[d.Entry.Timestamp] = true
+	}
+
+	claimed := make(map[int]bool)
+	matches := make([]*index.Decision, len(records))
+	timestamps := make([]string, len(records))
+	refs := make([]string, len(records))
+	for i, r := range records {
+		// Decisions added in the same second share a ctx-id; the title
+		// tells them apart unless it was edited
+		for _, sameTitle := range []bool{true, false} {
+			for j := range decisions {
+				d := &decisions[j]
+				source, _ := d.Field(config.DecisionFieldSource)
+				if matches[i] == nil && !claimed[j] && (source == r.sourceID() ||
+					(r.CtxID != "" && d.Entry.Timestamp == r.CtxID &&
+						(!sameTitle || d.Entry.Title == r.Title))) {
+					matches[i], claimed[j] = d, true
+				}
+			}
+		}
+		if matches[i] != nil {
+			timestamps[i] = matches[i].Entry.Timestamp
+		} else {
+			timestamps[i] = adrTimestamp(r, used)
+			used[timestamps[i]] = true
+		}
+		refs[i] = "[" + timestamps[i] + "] " + r.Title
+	}
+
+	supersedes := make([]string, len(records))
+	supersededBy := make([]string, len(records))
+	for i, r := range records {
+		if r.SupersededBy == "" {
+			continue
+		}
+		for j := range records {
+			if j != i && records[j].refersTo(r.SupersededBy) {
+				supersededBy[i], supersedes[j] = refs[j], refs[i]
+				break
+			}
+		}
+	}
+
+	lines := strings.Split(content, config.NewlineLF)
+	var added []int
+	order := make([]int, len(records))
+	for i := range order {
+		order[i] = i
+	}
+	// Rewrite matched entries bottom-up so earlier line indexes hold.
+	slices.SortStableFunc(order, func(a, b int) int {
+		return startOf(matches[b]) - startOf(matches[a])
+	})
+	for _, i := range order {
+		entry := adrEntry(
+			records[i], timestamps[i], supersedes[i], supersededBy[i],
+		)
+		d := matches[i]
+		if d == nil {
+			added = append(added, i)
+			continue
+		}
+		current := d.Lines[:d.ContentEnd()-d.StartIndex]
+		if strings.Join(current, config.NewlineLF) == entry {
+			result.unchanged++
+			continue
+		}
+		result.updated++
+		lines = slices.Replace(
+			lines, d.StartIndex, d.StartIndex+len(current),
+			strings.Split(entry, config.NewlineLF)...,
+		)
+	}
+
+	// New entries go on top, oldest first, so the newest ends up first.
+	updated := strings.Join(lines, config.NewlineLF)
+	slices.SortStableFunc(added, func(a, b int) int {
+		return strings.Compare(timestamps[a], timestamps[b])
+	})
+	for _, i := range added {
+		entry := adrEntry(
+			records[i], timestamps[i], supersedes[i], supersededBy[i],
+		)
+		updated = string(add.AppendEntry(
+			[]byte(updated), entry+config.NewlineLF, config.EntryDecision, "",
+		))
+		result.added++
+	}
+
+	return updated, result
+}
+
+// adrEntry formats a record as a DECISIONS.md entry.
+//
+// The record's Decision section becomes the rationale, since the
+// entry's own Decision field repeats its title.
+//
+// Parameters:
+//   - r: Record to format
+//   - timestamp: Timestamp of the entry
+//   - supersedes: Reference of the entry it supersedes, if any
+//   - supersededBy: Reference of the entry that supersedes it, if any
+//
+// Returns:
+//   - string: Entry lines, without a trailing newline
+func adrEntry(r adrRecord, timestamp, supersedes, supersededBy string) string {
+	entry := fmt.Sprintf(
+		config.TplDecision, timestamp, r.Title, index.StatusLabel(r.Status),
+		fieldValue(r.Context), r.Title, fieldValue(r.Decision),
+		fieldValue(r.Consequences),
+	)
+
+	eb := index.EntryBlock{Lines: strings.Split(
+		strings.TrimRight(entry, config.NewlineLF), config.NewlineLF,
+	)}
+	if supersededBy != "" {
+		eb.Lines = eb.WithField(config.DecisionFieldSupersededBy, supersededBy)
+	}
+	if supersedes != "" {
+		eb.Lines = eb.WithField(config.DecisionFieldSupersedes, supersedes)
+	}
+	eb.Lines = eb.WithField(config.DecisionFieldSource, r.sourceID())
+
+	for i, line := range eb.Lines {
+		eb.Lines[i] = strings.TrimRight(line, config.Whitespace)
+	}
+	return strings.Join(eb.Lines, config.NewlineLF)
+}
+
+// fieldValue prepares section text for a "**Label**: value" field.
+//
+// Text that opens with a block (a list, code fence or heading) starts
+// on its own paragraph, where Markdown renders it as such.
+//
+// Parameters:
+//   - text: Section text
+//
+// Returns:
+//   - string: Text to write after "**Label**: "
+func fieldValue(text string) string {
+	if config.RegExListStart.MatchString(text) ||
+		config.RegExFenceLine.MatchString(text) ||
+		strings.HasPrefix(text, "#") {
+		return config.NewlineLF + config.NewlineLF + text
+	}
+	return text
+}
+
+// adrTimestamp picks the timestamp of a newly imported record.
+//
+// Records carry a date but no time, so the record number stands in for
+// the time of day: ADR 7 of 2026-01-28 becomes 2026-01-28-000007. This
+// keeps records of the same day in order. Taken timestamps are skipped.
+//
+// Parameters:
+//   - r: The record
+//   - used: Timestamps already in use
+//
+// Returns:
+//   - string: Free timestamp (YYYY-MM-DD-HHMMSS)
+func adrTimestamp(r adrRecord, used map[string]bool) string {
+	for n := r.Number % 1000000; ; n = (n + 1) % 1000000 {
+		timestamp := fmt.Sprintf("%s-%06d", r.Date, n)
+		if !used[timestamp] {
+			return timestamp
+		}
+	}
+}
+
+// startOf returns the first line of a decision, or -1 for none.
+//
+// Parameters:
+//   - d: Decision, may be nil
+//
+// Returns:
+//   - int: d.StartIndex, or -1 if d is nil
+func startOf(d *index.Decision) int {
+	if d == nil {
+		return -1
+	}
+	return d.StartIndex
+}
@@ -0,0 +1,154 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// findDecision resolves a decision reference.
+//
+// A full reference ("[timestamp] Title") wins, then an exact or prefix
+// match on the timestamp; otherwise the query is matched
+// case-insensitively against titles. It must match exactly one
+// decision: decisions written in the same second are told apart by the
+// full reference.
+//
+// Parameters:
+//   - decisions: Decisions from index.ParseDecisions
+//   - query: Full reference, timestamp, timestamp prefix or part of a
+//     title
+//
+// Returns:
+//   - *index.Decision: The decision
+//   - error: Non-nil if no decision or more than one matches
+func findDecision(
+	decisions []index.Decision, query string,
+) (*index.Decision, error) {
+	var matches []*index.Decision
+	for i := range decisions {
+		if decisions[i].IsRef(query) {
+			matches = append(matches, &decisions[i])
+		}
+	}
+
+	query = strings.TrimSpace(strings.Trim(strings.TrimSpace(query), "[]"))
+	if query == "" {
+		return nil, fmt.Errorf("empty decision reference")
+	}
+
+	if len(matches) == 0 {
+		for i := range decisions {
+			if strings.HasPrefix(decisions[i].Entry.Timestamp, query) {
+				matches = append(matches, &decisions[i])
+			}
+		}
+	}
+	if len(matches) == 0 {
+		lower := strings.ToLower(query)
+		for i := range decisions {
+			if strings.Contains(strings.ToLower(decisions[i].Entry.Title), lower) {
+				matches = append(matches, &decisions[i])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf(
+			"no decision matches %q. Use 'ctx decisions list' to see decisions",
+			query,
+		)
+	case 1:
+		return matches[0], nil
+	}
+	refs := make([]string, 0, len(matches))
+	for _, d := range matches {
+		refs = append(refs, "  "+d.Ref())
+	}
+	return nil, fmt.Errorf(
+		"%q matches %d decisions; use the timestamp and title, quoted:\n%s",
+		query, len(matches), strings.Join(refs, config.NewlineLF),
+	)
+}
+
+// supersede marks one decision as superseded by another.
+//
+// The old entry's status becomes Superseded and it gets a
+// "**Superseded by**:" link; the new entry gets a "**Supersedes**:"
+// link. The index is not regenerated.
+//
+// Parameters:
+//   - content: The full content of DECISIONS.md
+//   - oldRef: Reference to the replaced decision (see findDecision)
+//   - newRef: Reference to the replacing decision
+//
+// Returns:
+//   - string: Updated content
+//   - *index.Decision: The replaced decision, as it was before
+//   - *index.Decision: The replacing decision, as it was before
+//   - error: Non-nil if a reference does not resolve, both are the same
+//     decision, or the old one is superseded by a different decision
+func supersede(
+	content, oldRef, newRef string,
+) (string, *index.Decision, *index.Decision, error) {
+	decisions := index.ParseDecisions(content)
+	older, oldErr := findDecision(decisions, oldRef)
+	if oldErr != nil {
+		return "", nil, nil, oldErr
+	}
+	newer, newErr := findDecision(decisions, newRef)
+	if newErr != nil {
+		return "", nil, nil, newErr
+	}
+
+	switch {
+	case older.StartIndex == newer.StartIndex:
+		return "", nil, nil, fmt.Errorf(
+			"a decision cannot supersede itself: %s", older.Ref(),
+		)
+	case older.SupersededBy != "" && older.SupersededBy != newer.Ref():
+		return "", nil, nil, fmt.Errorf(
+			"%s is already superseded by %s", older.Ref(), older.SupersededBy,
+		)
+	case newer.Status == config.DecisionStatusSuperseded:
+		return "", nil, nil, fmt.Errorf(
+			"%s is itself superseded; supersede with a current decision",
+			newer.Ref(),
+		)
+	}
+
+	oldBlock := older.EntryBlock
+	oldBlock.Lines = oldBlock.WithField(
+		config.DecisionFieldStatus,
+		index.StatusLabel(config.DecisionStatusSuperseded),
+	)
+	oldLines := oldBlock.WithField(config.DecisionFieldSupersededBy, newer.Ref())
+	newLines := newer.WithField(config.DecisionFieldSupersedes, older.Ref())
+
+	// Replace the later block first so the earlier one's indexes hold.
+	lines := strings.Split(content, config.NewlineLF)
+	edits := []struct {
+		block *index.Decision
+		lines []string
+	}{{older, oldLines}, {newer, newLines}}
+	if older.StartIndex < newer.StartIndex {
+		edits[0], edits[1] = edits[1], edits[0]
+	}
+	for _, e := range edits {
+		lines = slices.Replace(
+			lines, e.block.StartIndex, e.block.EndIndex, e.lines...,
+		)
+	}
+
+	return strings.Join(lines, config.NewlineLF), older, newer, nil
+}
@@ -7,8 +7,14 @@
 package decision
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -34,3 +40,219 @@ func runReindex(cmd *cobra.Command, _ []string) error {
 		config.EntryPlural[config.EntryDecision],
 	)
 }
+
+// readDecisions reads DECISIONS.md.
+//
+// Returns:
+//   - string: Path to DECISIONS.md
+//   - string: File content
+//   - error: Non-nil if the file does not exist or cannot be read
+func readDecisions() (string, string, error) {
+	filePath := filepath.Join(rc.ContextDir(), config.FileDecision)
+	content, err := os.ReadFile(filepath.Clean(filePath))
+	if os.IsNotExist(err) {
+		return "", "", fmt.Errorf(
+			"%s not found. Run 'ctx init' first", config.FileDecision,
+		)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return filePath, string(content), nil
+}
+
+// runList prints the decisions in DECISIONS.md with their status.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - status: Show only decisions with this status; empty for all
+//   - jsonOut: Output as JSON
+//
+// Returns:
+//   - error: Non-nil if the status is unknown or the file cannot be read
+func runList(cmd *cobra.Command, status string, jsonOut bool) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != "" && !slices.Contains(config.DecisionStatuses, status) {
+		return fmt.Errorf(
+			"unknown status %q. Valid statuses: %s",
+			status, strings.Join(config.DecisionStatuses, ", "),
+		)
+	}
+
+	_, content, readErr := readDecisions()
+	if readErr != nil {
+		return readErr
+	}
+
+	items := make([]decisionItem, 0)
+	for _, d := range index.ParseDecisions(content) {
+		if status != "" && d.Status != status {
+			continue
+		}
+		items = append(items, decisionItem{
+			Timestamp:    d.Entry.Timestamp,
+			Date:         d.Entry.Date,
+			Title:        d.Entry.Title,
+			Status:       d.Status,
+			Supersedes:   d.Supersedes,
+			SupersededBy: d.SupersededBy,
+		})
+	}
+
+	if jsonOut {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	}
+
+	if len(items) == 0 {
+		cmd.Println("No decisions found.")
+		return nil
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for _, it := range items {
+		cmd.Println(fmt.Sprintf(
+			"%s  %-10s  %s", cyan(it.Timestamp), it.Status, it.Title,
+		))
+		if it.SupersededBy != "" {
+			cmd.Println(fmt.Sprintf(
+				"%s→ superseded by %s",
+				strings.Repeat(" ", len(it.Timestamp)+2), it.SupersededBy,
+			))
+		}
+	}
+	return nil
+}
+
+// runSupersede marks a decision as superseded by another.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - oldRef: Timestamp or title of the replaced decision
+//   - newRef: Timestamp or title of the replacing decision
+//
+// Returns:
+//   - error: Non-nil if a reference does not resolve or file operations
+//     fail
+func runSupersede(cmd *cobra.Command, oldRef, newRef string) error {
+	filePath, content, readErr := readDecisions()
+	if readErr != nil {
+		return readErr
+	}
+
+	updated, older, newer, err := supersede(content, oldRef, newRef)
+	if err != nil {
+		return err
+	}
+	updated = index.UpdateDecisions(updated)
+
+	if writeErr := os.WriteFile(
+		filePath, []byte(updated), config.PermFile,
+	); writeErr != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, writeErr)
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Println(fmt.Sprintf("%s Superseded %s", green("✓"), older.Ref()))
+	cmd.Println(fmt.Sprintf("  by %s", newer.Ref()))
+	return nil
+}
+
+// runImport imports ADR files into DECISIONS.md.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dir: Directory holding the records
+//
+// Returns:
+//   - error: Non-nil if the records or DECISIONS.md cannot be read or
+//     written
+func runImport(cmd *cobra.Command, dir string) error {
+	filePath, content, readErr := readDecisions()
+	if readErr != nil {
+		return readErr
+	}
+	records, adrErr := readADRs(dir)
+	if adrErr != nil {
+		return adrErr
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no ADR files (NNNN-title.md) found in %s", dir)
+	}
+
+	updated, result := importADRs(content, records)
+	if updated != content {
+		if writeErr := os.WriteFile(
+			filePath, []byte(updated), config.PermFile,
+		); writeErr != nil {
+			return fmt.Errorf("failed to write %s: %w", filePath, writeErr)
+		}
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Println(fmt.Sprintf(
+		"%s Imported %d ADRs from %s (%d added, %d updated, %d unchanged)",
+		green("✓"), len(records), dir,
+		result.added, result.updated, result.unchanged,
+	))
+	return index.ReindexFile(
+		cmd.OutOrStdout(), filePath, config.FileDecision,
+		index.UpdateDecisions, config.EntryPlural[config.EntryDecision],
+	)
+}
+
+// runExport writes the decisions in DECISIONS.md as ADR files.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dir: Directory to write the records to; created if missing
+//   - format: Record format; only config.ADRFormatMADR is supported
+//
+// Returns:
+//   - error: Non-nil if the format is unknown or file operations fail
+func runExport(cmd *cobra.Command, dir, format string) error {
+	if !strings.EqualFold(format, config.ADRFormatMADR) {
+		return fmt.Errorf(
+			"unknown format %q. Supported formats: %s",
+			format, config.ADRFormatMADR,
+		)
+	}
+
+	filePath, content, readErr := readDecisions()
+	if readErr != nil {
+		return readErr
+	}
+	if mkErr := os.MkdirAll(dir, config.PermExec); mkErr != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, mkErr)
+	}
+	existing, adrErr := readADRs(dir)
+	if adrErr != nil {
+		return adrErr
+	}
+
+	decisions := index.ParseDecisions(content)
+	files := exportFiles(decisions, existing)
+	written := 0
+	for i, d := range decisions {
+		p := filepath.Join(dir, files[i])
+		record := renderMADR(d, supersedingFile(decisions, files, d.SupersededBy))
+		if current, err := os.ReadFile(filepath.Clean(p)); err == nil &&
+			string(current) == record {
+			continue
+		}
+		if writeErr := os.WriteFile(p, []byte(record), config.PermFile); writeErr != nil {
+			return fmt.Errorf("failed to write %s: %w", p, writeErr)
+		}
+		written++
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	cmd.Println(fmt.Sprintf(
+		"%s Exported %d decisions to %s (%d written, %d unchanged)",
+		green("✓"), len(decisions), dir, written, len(decisions)-written,
+	))
+	return index.ReindexFile(
+		cmd.OutOrStdout(), filePath, config.FileDecision,
+		index.UpdateDecisions, config.EntryPlural[config.EntryDecision],
+	)
+}
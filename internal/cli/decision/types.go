@@ -0,0 +1,75 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package decision
+
+// decisionItem is a decision as output by "ctx decisions list --json".
+//
+// Fields:
+//   - Timestamp: Entry timestamp (YYYY-MM-DD-HHMMSS)
+//   - Date: Entry date (YYYY-MM-DD)
+//   - Title: Decision title
+//   - Status: Lower-case status
+//   - Supersedes: Reference to the decision this one replaces
+//   - SupersededBy: Reference to the decision that replaced this one
+type decisionItem struct {
+	Timestamp    string `json:"timestamp"`
+	Date         string `json:"date"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	Supersedes   string `json:"supersedes,omitempty"`
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+// adrRecord is an architecture decision record read from a file.
+//
+// Fields:
+//   - File: Base name of the file (e.g., "0001-use-postgres.md")
+//   - Number: Record number from the file name
+//   - Title: Title, without the number prefix
+//   - Date: Date of the record (YYYY-MM-DD)
+//   - Status: ctx decision status (see config.DecisionStatuses)
+//   - Context: Context section
+//   - Decision: Decision section (Decision Outcome in MADR)
+//   - Consequences: Consequences section
+//   - CtxID: Timestamp of the ctx entry the record was exported from
+//   - SupersededBy: File name or number of the replacing record
+type adrRecord struct {
+	File         string
+	Number       int
+	Title        string
+	Date         string
+	Status       string
+	Context      string
+	Decision     string
+	Consequences string
+	CtxID        string
+	SupersededBy string
+}
+
+// adrFrontMatter is the YAML front matter of a MADR record.
+//
+// Fields:
+//   - Status: Record status (e.g., "accepted")
+//   - Date: Record date (YYYY-MM-DD)
+//   - CtxID: Timestamp of the ctx entry the record was exported from
+type adrFrontMatter struct {
+	Status string `yaml:"status"`
+	Date   string `yaml:"date"`
+	CtxID  string `yaml:"ctx-id"`
+}
+
+// importResult counts what "ctx decisions import" did.
+//
+// Fields:
+//   - added: New entries
+//   - updated: Entries rewritten from a changed record
+//   - unchanged: Entries already matching their record
+type importResult struct {
+	added     int
+	updated   int
+	unchanged int
+}
@@ -0,0 +1,82 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/drift"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// runUpdateBaseline accepts all current drift issues.
+//
+// Runs all enabled checks and writes their issues to
+// .context/drift-baseline.json, replacing any earlier baseline.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - jsonOutput: If true, output the baseline as JSON
+//
+// Returns:
+//   - error: Non-nil if context loading fails or the file cannot be
+//     written
+func runUpdateBaseline(cmd *cobra.Command, jsonOutput bool) error {
+	ctx, err := loadContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	baseline := drift.NewBaseline(drift.Detect(ctx))
+	path := baselinePath()
+	if saveErr := baseline.Save(path); saveErr != nil {
+		return errFileWrite(path, saveErr)
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(baseline)
+	}
+
+	cmd.Println(fmt.Sprintf(
+		"Accepted %d issue(s) in %s", len(baseline.Issues), path,
+	))
+	return nil
+}
+
+// loadBaseline reads the drift baseline, if there is one.
+//
+// A baseline that cannot be read is reported as a warning on stderr
+// and ignored, so every issue is reported.
+//
+// Parameters:
+//   - cmd: Cobra command for warnings
+//
+// Returns:
+//   - *drift.Baseline: The baseline; nil if there is none
+func loadBaseline(cmd *cobra.Command) *drift.Baseline {
+	baseline, err := drift.LoadBaseline(baselinePath())
+	if err != nil {
+		cmd.PrintErrln(fmt.Sprintf("ctx: warning: ignoring drift baseline: %v", err))
+		return nil
+	}
+	return baseline
+}
+
+// baselinePath returns the path of the drift baseline file.
+//
+// Returns:
+//   - string: Path to drift-baseline.json in the context directory
+func baselinePath() string {
+	return filepath.Join(rc.ContextDir(), config.FileDriftBaseline)
+}
@@ -17,7 +17,11 @@ import (
 //
 // Flags:
 //   - --json: Output results as JSON for machine parsing
-//   - --fix: Auto-fix supported issues (staleness, missing_file)
+//   - --fix: Auto-fix supported issues (staleness, missing_file, dead_path)
+//   - --yes: Apply --fix rewrites of dead paths without asking
+//   - --only: Run only the named checks or issue types
+//   - --list-checks: List the available checks and exit
+//   - --update-baseline: Accept the current issues and exit
 //
 // Returns:
 //   - *cobra.Command: Configured drift command with flags registered
@@ -25,6 +29,10 @@ func Cmd() *cobra.Command {
 	var (
 		jsonOutput bool
 		fix        bool
+		yes        bool
+		listChecks bool
+		update     bool
+		only       []string
 	)
 
 	cmd := &cobra.Command{
@@ -39,16 +47,52 @@ Checks performed:
   - Constitution rule violations (potential secrets)
   - Required files are present
 
+Checks can be disabled, given thresholds and promoted to violations in
+the "drift:" section of .ctxrc. Use --list-checks to see them all, and
+--only to run some of them (by check name or issue type):
+
+  ctx drift --only dead_path,stale_age
+
+With --fix, dead paths are looked up in git history: references to
+files that were renamed or moved are rewritten in all context files
+after a diff preview and confirmation (skip it with --yes), and pending
+tasks that mention deleted files are labeled #stale-ref.
+
+Known issues can be accepted with --update-baseline, which writes them
+to .context/drift-baseline.json. Later runs report only new issues,
+plus accepted ones that have gone away; accepted issues do not affect
+the exit code.
+
 Use --json for machine-readable output.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDrift(cmd, jsonOutput, fix)
+			if listChecks {
+				return runListChecks(cmd, jsonOutput)
+			}
+			if update {
+				return runUpdateBaseline(cmd, jsonOutput)
+			}
+			return runDrift(cmd, jsonOutput, fix, yes, only)
 		},
 	}
 
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&fix,
-		"fix", false, "Auto-fix supported issues (staleness, missing files)",
+		"fix", false, "Auto-fix supported issues (staleness, missing files, dead paths)",
+	)
+	cmd.Flags().BoolVarP(&yes,
+		"yes", "y", false, "Apply --fix changes to dead paths without asking",
+	)
+	cmd.Flags().StringSliceVar(&only,
+		"only", nil, "Run only these checks or issue types (comma-separated)",
+	)
+	cmd.Flags().BoolVar(&listChecks,
+		"list-checks", false, "List available checks with their settings",
+	)
+	cmd.Flags().BoolVar(&update,
+		"update-baseline", false, "Accept the current issues in the drift baseline",
 	)
+	cmd.MarkFlagsMutuallyExclusive("update-baseline", "fix")
+	cmd.MarkFlagsMutuallyExclusive("update-baseline", "only")
 
 	return cmd
 }
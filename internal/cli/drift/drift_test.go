@@ -8,8 +8,10 @@ package drift
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -442,7 +444,7 @@ func TestApplyFixes_DeadPath(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if result.skipped != 1 {
 		t.Errorf("expected 1 skipped, got %d", result.skipped)
 	}
@@ -456,6 +458,113 @@ func TestApplyFixes_DeadPath(t *testing.T) {
 	}
 }
 
+// gitRepo makes the working directory a git repository in which
+// src/a.go was moved to lib/c.go and src/gone.go was deleted.
+func gitRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	run := func(args ...string) {
+		t.Helper()
+		c := exec.Command("git", args...)
+		c.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com",
+		)
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll("src", 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll("lib", 0750); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"src/a.go":    "package a\n\n// A parses input.\nfunc A() {}\n",
+		"src/gone.go": "package a\n\n// Gone is removed.\nfunc Gone() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q")
+	run("add", "src")
+	run("commit", "-q", "-m", "add")
+	run("mv", "src/a.go", "src/b.go")
+	run("commit", "-q", "-m", "rename")
+	run("mv", "src/b.go", "lib/c.go")
+	run("rm", "-q", "src/gone.go")
+	run("commit", "-q", "-m", "move and delete")
+}
+
+func TestApplyFixes_DeadPathRenamed(t *testing.T) {
+	_, cleanup := setupContextDir(t)
+	defer cleanup()
+	gitRepo(t)
+
+	archPath := filepath.Join(config.DirContext, config.FileArchitecture)
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	arch := "# Architecture\n\n- Parser: `src/a.go`.\n- Helpers: `src/gone.go`\n"
+	tasks := "# Tasks\n\n- [ ] Refactor `src/gone.go`\n" +
+		"- [ ] Test `src/a.go`\n- [x] Wrote `src/gone.go`\n"
+	if err := os.WriteFile(archPath, []byte(arch), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tasksPath, []byte(tasks), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := context.Load("")
+	if err != nil {
+		t.Fatalf("failed to load context: %v", err)
+	}
+	report := &drift.Report{
+		Warnings: []drift.Issue{
+			{File: config.FileArchitecture, Line: 3, Type: drift.IssueDeadPath, Path: "src/a.go"},
+			{File: config.FileArchitecture, Line: 4, Type: drift.IssueDeadPath, Path: "src/gone.go"},
+		},
+	}
+
+	// Declining the preview leaves the files alone.
+	cmd, buf := newTestCmd()
+	cmd.SetIn(strings.NewReader("n\n"))
+	result := applyFixes(cmd, ctx, report, false)
+	if result.fixed != 0 || result.skipped != 2 {
+		t.Errorf("declined: fixed %d, skipped %d", result.fixed, result.skipped)
+	}
+	for _, want := range []string{
+		"src/a.go was moved to lib/c.go", "src/gone.go was deleted",
+		"- - Parser: `src/a.go`.", "+ - Parser: `lib/c.go`.", "Apply these changes?",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output should contain %q:\n%s", want, buf.String())
+		}
+	}
+	if got, _ := os.ReadFile(archPath); string(got) != arch {
+		t.Errorf("ARCHITECTURE.md changed without confirmation:\n%s", got)
+	}
+
+	cmd, _ = newTestCmd()
+	result = applyFixes(cmd, ctx, report, true)
+	if result.fixed != 1 || result.skipped != 1 || len(result.errors) != 0 {
+		t.Errorf("confirmed: fixed %d, skipped %d, errors %v",
+			result.fixed, result.skipped, result.errors)
+	}
+	got, _ := os.ReadFile(archPath)
+	if want := strings.Replace(arch, "src/a.go", "lib/c.go", 1); string(got) != want {
+		t.Errorf("ARCHITECTURE.md = %q, want %q", got, want)
+	}
+	got, _ = os.ReadFile(tasksPath)
+	want := "# Tasks\n\n- [ ] Refactor `src/gone.go` #stale-ref\n" +
+		"- [ ] Test `lib/c.go`\n- [x] Wrote `src/gone.go`\n"
+	if string(got) != want {
+		t.Errorf("TASKS.md = %q, want %q", got, want)
+	}
+}
+
 func TestApplyFixes_Secret(t *testing.T) {
 	cmd, buf := newTestCmd()
 	ctx := &context.Context{}
@@ -466,7 +575,7 @@ func TestApplyFixes_Secret(t *testing.T) {
 		},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if result.skipped != 1 {
 		t.Errorf("expected 1 skipped, got %d", result.skipped)
 	}
@@ -499,7 +608,7 @@ func TestApplyFixes_MissingFile(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if result.fixed != 1 {
 		t.Errorf("expected 1 fixed, got %d", result.fixed)
 	}
@@ -527,7 +636,7 @@ func TestApplyFixes_MissingFileNoTemplate(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if len(result.errors) != 1 {
 		t.Errorf("expected 1 error, got %d", len(result.errors))
 	}
@@ -552,7 +661,7 @@ func TestApplyFixes_Staleness_NoTasksFile(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if len(result.errors) != 1 {
 		t.Errorf("expected 1 error, got %d errors: %v", len(result.errors), result.errors)
 	}
@@ -582,7 +691,7 @@ func TestApplyFixes_Staleness_NoCompletedTasks(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if len(result.errors) != 1 {
 		t.Errorf("expected 1 error (no completed tasks), got %d: %v", len(result.errors), result.errors)
 	}
@@ -612,7 +721,7 @@ func TestApplyFixes_Staleness_Success(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if result.fixed != 1 {
 		t.Errorf("expected 1 fixed, got %d; errors: %v", result.fixed, result.errors)
 	}
@@ -854,7 +963,7 @@ func TestRunDrift_FixWithErrorsOutput(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 
 	// Should have error because no TASKS.md in context
 	if len(result.errors) == 0 {
@@ -975,7 +1084,7 @@ func TestApplyFixes_StaleAge(t *testing.T) {
 		Violations: []drift.Issue{},
 	}
 
-	result := applyFixes(cmd, ctx, report)
+	result := applyFixes(cmd, ctx, report, false)
 	if result.skipped != 1 {
 		t.Errorf("expected 1 skipped, got %d", result.skipped)
 	}
@@ -988,3 +1097,158 @@ func TestApplyFixes_StaleAge(t *testing.T) {
 		t.Errorf("expected file age skip message, got: %s", out)
 	}
 }
+
+// --- check selection and .ctxrc settings ---
+
+// helper: run "ctx drift" with args and capture its output
+func runDriftCmd(args ...string) (string, error) {
+	cmd := Cmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return buf.String(), err
+}
+
+func TestRunDrift_Only(t *testing.T) {
+	tmpDir, cleanup := setupContextDir(t)
+	defer cleanup()
+
+	archPath := filepath.Join(tmpDir, config.DirContext, config.FileArchitecture)
+	if err := os.WriteFile(archPath, []byte("# Architecture\n\nSee `gone.go`.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runDriftCmd("--only", "dead_path,stale_age")
+	if err != nil {
+		t.Fatalf("drift --only failed: %v", err)
+	}
+	for _, want := range []string{"references 'gone.go'", "No stale files by age"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "All required files present") {
+		t.Errorf("unselected check ran:\n%s", out)
+	}
+
+	if _, err = runDriftCmd("--only", "dead_paths"); err == nil ||
+		!strings.Contains(err.Error(), "dead_paths") {
+		t.Errorf("expected unknown check error, got %v", err)
+	}
+}
+
+func TestRunDrift_SeverityOverride(t *testing.T) {
+	tmpDir, cleanup := setupContextDir(t)
+	defer cleanup()
+
+	tasksPath := filepath.Join(tmpDir, config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte("# Tasks\n\n- [x] One\n- [x] Two\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rcContent := "drift:\n  staleness_check:\n    max_completed: 1\n    severity: violation\n  unknown_check: {}\n"
+	if err := os.WriteFile(config.FileContextRC, []byte(rcContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rc.Reset()
+
+	out, err := runDriftCmd("--only", "staleness_check")
+	if err == nil {
+		t.Fatal("expected the promoted staleness issue to fail the run")
+	}
+	for _, want := range []string{
+		"ctx: warning: drift.unknown_check: unknown check or issue type",
+		"VIOLATIONS (1)",
+		"TASKS.md: has many completed items",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunDrift_ListChecks(t *testing.T) {
+	_, cleanup := setupContextDir(t)
+	defer cleanup()
+
+	rcContent := "drift:\n  file_age_check:\n    max_age_days: 90\n  dangling_dependency:\n    enabled: false\n"
+	if err := os.WriteFile(config.FileContextRC, []byte(rcContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rc.Reset()
+
+	out, err := runDriftCmd("--list-checks")
+	if err != nil {
+		t.Fatalf("drift --list-checks failed: %v", err)
+	}
+	for _, want := range []string{
+		"constitution_check (violation)",
+		"Issues: dependency_cycle, dangling_dependency (disabled)",
+		"max_age_days: 90 (default 30)",
+		"max_completed: 10 —",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Drift Detection Report") {
+		t.Error("--list-checks should not run the checks")
+	}
+}
+
+func TestRunDrift_Baseline(t *testing.T) {
+	tmpDir, cleanup := setupContextDir(t)
+	defer cleanup()
+
+	archPath := filepath.Join(tmpDir, config.DirContext, config.FileArchitecture)
+	if err := os.WriteFile(archPath, []byte("# Architecture\n\nSee `gone.go`.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runDriftCmd("--update-baseline")
+	if err != nil {
+		t.Fatalf("drift --update-baseline failed: %v", err)
+	}
+	if !strings.Contains(out, "issue(s) in "+filepath.Join(config.DirContext, config.FileDriftBaseline)) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	out, err = runDriftCmd("--json")
+	if err != nil {
+		t.Fatalf("drift --json failed: %v", err)
+	}
+	var result JsonOutput
+	if jsonErr := json.Unmarshal([]byte(out), &result); jsonErr != nil {
+		t.Fatalf("invalid JSON: %v\n%s", jsonErr, out)
+	}
+	if result.Status != drift.StatusOk || len(result.Warnings) != 0 ||
+		len(result.Baselined) == 0 {
+		t.Errorf("status %q with %d baselined, want ok with all accepted",
+			result.Status, len(result.Baselined))
+	}
+
+	// A new dead path is reported; the fixed one shows as resolved.
+	if err = os.WriteFile(archPath, []byte("# Architecture\n\nSee `new.go`.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runDriftCmd()
+	if err != nil {
+		t.Fatalf("drift failed: %v", err)
+	}
+	for _, want := range []string{
+		"references 'new.go'",
+		"RESOLVED SINCE BASELINE (1)",
+		"ARCHITECTURE.md: references path that does not exist (gone.go)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err = runDriftCmd("--update-baseline", "--only", "dead_path"); err == nil {
+		t.Error("expected --update-baseline with --only to fail")
+	}
+}
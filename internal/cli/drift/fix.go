@@ -29,23 +29,37 @@ import (
 // Currently, supports fixing:
 //   - staleness: Archives completed tasks from TASKS.md
 //   - missing_file: Creates missing required files from templates
+//   - dead_path: Rewrites references to files renamed in git history,
+//     after showing the changes and asking for confirmation; pending
+//     tasks that mention deleted files are labeled #stale-ref
+//
+// Issues from a parent context layer are skipped: fixes only ever write
+// to the local context directory.
 //
 // Parameters:
 //   - cmd: Cobra command for output messages
 //   - ctx: Loaded context
 //   - report: Drift report containing issues to fix
+//   - yes: Apply dead path rewrites without asking
 //
 // Returns:
 //   - *fixResult: Summary of fixes applied
 func applyFixes(
-	cmd *cobra.Command, ctx *context.Context, report *drift.Report,
+	cmd *cobra.Command, ctx *context.Context, report *drift.Report, yes bool,
 ) *fixResult {
 	result := &fixResult{}
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
+	var deadPaths []drift.Issue
 
 	// Process warnings (staleness, missing_file, dead_path)
 	for _, issue := range report.Warnings {
+		if issue.Layer != "" {
+			cmd.Println(fmt.Sprintf("%s Skipping %s: belongs to parent layer %s",
+				yellow("○"), issue.File, issue.Layer))
+			result.skipped++
+			continue
+		}
 		switch issue.Type {
 		case drift.IssueStaleness:
 			if fixErr := fixStaleness(cmd, ctx); fixErr != nil {
@@ -72,9 +86,7 @@ func applyFixes(
 			}
 
 		case drift.IssueDeadPath:
-			cmd.Println(fmt.Sprintf("%s Cannot auto-fix dead path in %s:%d (%s)",
-				yellow("○"), issue.File, issue.Line, issue.Path))
-			result.skipped++
+			deadPaths = append(deadPaths, issue)
 
 		case drift.IssueStaleAge:
 			cmd.Println(fmt.Sprintf("%s Cannot auto-fix file age: %s",
@@ -83,6 +95,11 @@ func applyFixes(
 		}
 	}
 
+	// Dead paths are fixed together: one rename can fix several issues.
+	// Tasks may mention moved files the report does not list, so this
+	// runs even without dead_path issues.
+	fixDeadPaths(cmd, ctx, deadPaths, yes, result)
+
 	// Process violations (potential_secret) - never auto-fix
 	for _, issue := range report.Violations {
 		if issue.Type == drift.IssueSecret {
@@ -109,7 +126,8 @@ func applyFixes(
 func fixStaleness(cmd *cobra.Command, ctx *context.Context) error {
 	tasksFile := ctx.File(config.FileTask)
 
-	if tasksFile == nil {
+	// Never rewrite a parent layer's TASKS.md.
+	if tasksFile == nil || !ctx.IsLocal(tasksFile) {
 		return errTasksNotFound()
 	}
 
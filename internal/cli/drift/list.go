@@ -0,0 +1,125 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/drift"
+)
+
+// runListChecks lists the registered drift checks with their settings.
+//
+// Severities, enabled states and option values reflect the "drift:"
+// section of .ctxrc.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - jsonOutput: If true, output as JSON; otherwise output as text
+//
+// Returns:
+//   - error: Non-nil if JSON encoding fails
+func runListChecks(cmd *cobra.Command, jsonOutput bool) error {
+	checks := checkInfos()
+
+	if jsonOutput {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(checks)
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	for i, c := range checks {
+		if i > 0 {
+			cmd.Println()
+		}
+		state := string(c.Severity)
+		if !c.Enabled {
+			state += ", disabled"
+		}
+		cmd.Println(fmt.Sprintf("%s (%s)", cyan(c.Name), state))
+		cmd.Println(fmt.Sprintf("  %s", c.Description))
+
+		issues := make([]string, len(c.Issues))
+		for j, it := range c.Issues {
+			issues[j] = string(it.Type)
+			var notes []string
+			if it.Severity != c.Severity {
+				notes = append(notes, string(it.Severity))
+			}
+			if !it.Enabled {
+				notes = append(notes, "disabled")
+			}
+			if len(notes) > 0 {
+				issues[j] += fmt.Sprintf(" (%s)", strings.Join(notes, ", "))
+			}
+		}
+		cmd.Println(fmt.Sprintf("  Issues: %s", strings.Join(issues, ", ")))
+
+		for _, o := range c.Options {
+			value := optionValue(o.Option, o.Value)
+			if o.Value != o.Default {
+				value += fmt.Sprintf(" (default %s)", optionValue(o.Option, o.Default))
+			}
+			cmd.Println(fmt.Sprintf("  %s: %s — %s", o.Name, value, o.Description))
+		}
+	}
+
+	return nil
+}
+
+// checkInfos describes the registered drift checks in the order they run.
+//
+// Returns:
+//   - []CheckInfo: One entry per check, with effective settings
+func checkInfos() []CheckInfo {
+	var infos []CheckInfo
+	for _, c := range drift.Checks() {
+		info := CheckInfo{
+			Name:        c.Name(),
+			Description: c.Description(),
+			Severity:    drift.SeverityOf(c, ""),
+			Enabled:     drift.Enabled(c),
+		}
+		for _, t := range c.Issues() {
+			info.Issues = append(info.Issues, IssueInfo{
+				Type:     t,
+				Severity: drift.SeverityOf(c, t),
+				Enabled:  drift.IssueEnabled(t),
+			})
+		}
+		values := drift.OptionsOf(c)
+		for _, o := range c.Options() {
+			info.Options = append(info.Options, OptionInfo{
+				Option: o, Value: values[o.Name],
+			})
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// optionValue formats an option value as .ctxrc would set it.
+//
+// Parameters:
+//   - o: The option
+//   - v: Value to format
+//
+// Returns:
+//   - string: "true" or "false" for switches; the number otherwise
+func optionValue(o drift.Option, v int) string {
+	if o.Switch {
+		return strconv.FormatBool(v != 0)
+	}
+	return strconv.Itoa(v)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/drift"
 )
 
@@ -45,9 +46,9 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 		)
 		cmd.Println()
 		for _, v := range report.Violations {
-			line := fmt.Sprintf("  - %s: %s", v.File, v.Message)
+			line := fmt.Sprintf("  - %s: %s", issueFile(v), v.Message)
 			if v.Line > 0 {
-				line = fmt.Sprintf("  - %s:%d %s", v.File, v.Line, v.Message)
+				line = fmt.Sprintf("  - %s:%d %s", issueFile(v), v.Line, v.Message)
 			}
 			if v.Rule != "" {
 				line += fmt.Sprintf(" (rule: %s)", v.Rule)
@@ -66,6 +67,7 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 
 		// Group by type
 		var pathRefs []drift.Issue
+		var symbolRefs []drift.Issue
 		var staleness []drift.Issue
 		var other []drift.Issue
 
@@ -73,6 +75,8 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 			switch w.Type {
 			case drift.IssueDeadPath:
 				pathRefs = append(pathRefs, w)
+			case drift.IssueDeadSymbol:
+				symbolRefs = append(symbolRefs, w)
 			case drift.IssueStaleness, drift.IssueStaleAge:
 				staleness = append(staleness, w)
 			default:
@@ -84,7 +88,17 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 			cmd.Println("  Path References:")
 			for _, w := range pathRefs {
 				cmd.Println(fmt.Sprintf(
-					"  - %s:%d references '%s' (not found)", w.File, w.Line, w.Path,
+					"  - %s:%d references '%s' (not found)", issueFile(w), w.Line, w.Path,
+				))
+			}
+			cmd.Println()
+		}
+
+		if len(symbolRefs) > 0 {
+			cmd.Println("  Symbol References:")
+			for _, w := range symbolRefs {
+				cmd.Println(fmt.Sprintf(
+					"  - %s:%d %s", issueFile(w), w.Line, w.Message,
 				))
 			}
 			cmd.Println()
@@ -93,7 +107,7 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 		if len(staleness) > 0 {
 			cmd.Println("  Staleness:")
 			for _, w := range staleness {
-				cmd.Println(fmt.Sprintf("  - %s %s", w.File, w.Message))
+				cmd.Println(fmt.Sprintf("  - %s %s", issueFile(w), w.Message))
 			}
 			cmd.Println()
 		}
@@ -101,12 +115,43 @@ func outputDriftText(cmd *cobra.Command, report *drift.Report) error {
 		if len(other) > 0 {
 			cmd.Println("  Other:")
 			for _, w := range other {
-				cmd.Println(fmt.Sprintf("  - %s: %s", w.File, w.Message))
+				if w.Line > 0 {
+					cmd.Println(fmt.Sprintf(
+						"  - %s:%d %s", issueFile(w), w.Line, w.Message,
+					))
+					continue
+				}
+				cmd.Println(fmt.Sprintf("  - %s: %s", issueFile(w), w.Message))
 			}
 			cmd.Println()
 		}
 	}
 
+	// Baseline
+	if len(report.Resolved) > 0 {
+		cmd.Println(fmt.Sprintf(
+			"%s RESOLVED SINCE BASELINE (%d)", green("✔"), len(report.Resolved),
+		))
+		cmd.Println()
+		for _, r := range report.Resolved {
+			line := fmt.Sprintf("  - %s: %s", r.File, r.Message)
+			if r.Path != "" {
+				line += fmt.Sprintf(" (%s)", r.Path)
+			}
+			cmd.Println(line)
+		}
+		cmd.Println()
+		cmd.Println("  Run 'ctx drift --update-baseline' to drop them from the baseline.")
+		cmd.Println()
+	}
+	if len(report.Baselined) > 0 {
+		cmd.Println(fmt.Sprintf(
+			"%d accepted issue(s) not shown (see %s)",
+			len(report.Baselined), config.FileDriftBaseline,
+		))
+		cmd.Println()
+	}
+
 	// Passed
 	if len(report.Passed) > 0 {
 		cmd.Println(fmt.Sprintf("%s PASSED (%d)", green("✅"), len(report.Passed)))
@@ -154,6 +199,8 @@ func outputDriftJSON(cmd *cobra.Command, report *drift.Report) error {
 		Warnings:   report.Warnings,
 		Violations: report.Violations,
 		Passed:     report.Passed,
+		Baselined:  report.Baselined,
+		Resolved:   report.Resolved,
 	}
 
 	enc := json.NewEncoder(cmd.OutOrStdout())
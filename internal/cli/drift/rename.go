@@ -0,0 +1,338 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/drift"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// Status letters of "git log --name-status" records.
+const (
+	gitStatusDeleted = "D"
+	gitStatusRenamed = "R"
+)
+
+// fixDeadPaths updates references to files that were moved or deleted.
+//
+// Each dead path, and each missing path a pending task mentions in
+// backticks, is looked up in git history. References to a renamed file
+// are rewritten across all local context files; pending tasks that
+// mention a deleted file are labeled #stale-ref. The changes are shown
+// as a diff and written only once confirmed.
+//
+// Parameters:
+//   - cmd: Cobra command for output and confirmation input
+//   - ctx: Loaded context
+//   - issues: Local dead_path issues
+//   - yes: Apply the changes without asking
+//   - result: Fix counters to update
+func fixDeadPaths(
+	cmd *cobra.Command, ctx *context.Context, issues []drift.Issue,
+	yes bool, result *fixResult,
+) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	paths := taskPaths(ctx)
+	for _, issue := range issues {
+		if !slices.Contains(paths, issue.Path) {
+			paths = append(paths, issue.Path)
+		}
+	}
+
+	moves := make(map[string]string)
+	var deleted []string
+	for _, p := range paths {
+		to, known := traceMove(p)
+		switch {
+		case !known:
+			continue
+		case to == "":
+			deleted = append(deleted, p)
+			cmd.Println(fmt.Sprintf("  %s was deleted", p))
+		default:
+			moves[p] = to
+			cmd.Println(fmt.Sprintf("  %s was moved to %s", p, to))
+		}
+	}
+
+	edits := planEdits(ctx, moves, deleted)
+	applied := false
+	if len(edits) > 0 {
+		previewEdits(cmd, edits)
+		if !confirmEdits(cmd, yes) {
+			cmd.Println("  No files changed.")
+		} else {
+			applied = true
+			for _, e := range edits {
+				if writeErr := os.WriteFile(
+					e.path, []byte(strings.Join(e.after, config.NewlineLF)),
+					config.PermFile,
+				); writeErr != nil {
+					result.errors = append(result.errors,
+						errFileWrite(e.path, writeErr).Error())
+					applied = false
+				}
+			}
+		}
+	}
+
+	for _, issue := range issues {
+		if to, ok := moves[issue.Path]; ok && applied {
+			cmd.Println(fmt.Sprintf("%s Updated dead path %s to %s",
+				green("✓"), issue.Path, to))
+			result.fixed++
+			continue
+		}
+		reason := "not found in git history"
+		switch {
+		case slices.Contains(deleted, issue.Path):
+			reason = "deleted"
+		case moves[issue.Path] != "":
+			reason = "changes not applied"
+		}
+		cmd.Println(fmt.Sprintf("%s Cannot auto-fix dead path in %s:%d (%s: %s)",
+			yellow("○"), issue.File, issue.Line, issue.Path, reason))
+		result.skipped++
+	}
+}
+
+// taskPaths returns the backticked file paths of pending tasks in the
+// local TASKS.md that do not exist.
+//
+// Parameters:
+//   - ctx: Loaded context
+//
+// Returns:
+//   - []string: Missing paths, in order of first mention
+func taskPaths(ctx *context.Context) []string {
+	f := ctx.File(config.FileTask)
+	if f == nil || !ctx.IsLocal(f) {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(string(f.Content), config.NewlineLF) {
+		match := config.RegExTask.FindStringSubmatch(line)
+		if match == nil || !task.Pending(match) {
+			continue
+		}
+		for _, m := range config.RegExPath.FindAllStringSubmatch(line, -1) {
+			p := m[1]
+			if strings.HasPrefix(p, "http") || strings.ContainsAny(p, "{*") ||
+				slices.Contains(paths, p) {
+				continue
+			}
+			if _, statErr := os.Stat(p); os.IsNotExist(statErr) {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// traceMove follows a missing path through git history.
+//
+// "git log --follow" finds the commit that removed the path; if that
+// commit renamed it, the new path is followed in turn, up to
+// config.MaxRenameHops times, until one exists.
+//
+// Parameters:
+//   - path: Missing path, relative to the project root
+//
+// Returns:
+//   - string: Where the file is now; empty if it was deleted
+//   - bool: False if git does not know what happened to the path
+func traceMove(path string) (string, bool) {
+	current := path
+	for range config.MaxRenameHops {
+		out, logErr := gitOutput(
+			"log", "--follow", "--name-status", "--format=%H", "-n", "1",
+			"--", current,
+		)
+		fields := strings.Fields(out)
+		if logErr != nil || len(fields) < 2 ||
+			fields[1] != gitStatusDeleted {
+			return "", false
+		}
+
+		show, showErr := gitOutput(
+			"show", "-M", "--name-status", "--format=", fields[0],
+		)
+		if showErr != nil {
+			return "", false
+		}
+		next := ""
+		for _, line := range strings.Split(show, config.NewlineLF) {
+			parts := strings.Split(line, "\t")
+			if len(parts) == 3 &&
+				strings.HasPrefix(parts[0], gitStatusRenamed) &&
+				parts[1] == current {
+				next = parts[2]
+				break
+			}
+		}
+		if next == "" {
+			return "", true
+		}
+		if _, statErr := os.Stat(next); statErr == nil {
+			return next, true
+		}
+		current = next
+	}
+	return "", false
+}
+
+// gitOutput runs git in the working directory.
+//
+// Parameters:
+//   - args: git arguments
+//
+// Returns:
+//   - string: Standard output
+//   - error: Non-nil if git is missing or the command fails
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	return string(out), err
+}
+
+// planEdits works out the changes to the local context files.
+//
+// Parameters:
+//   - ctx: Loaded context
+//   - moves: New path by old path
+//   - deleted: Paths of deleted files
+//
+// Returns:
+//   - []fileEdit: One edit per file that changes, in context file order
+func planEdits(
+	ctx *context.Context, moves map[string]string, deleted []string,
+) []fileEdit {
+	var edits []fileEdit
+	for i := range ctx.Files {
+		f := &ctx.Files[i]
+		if !ctx.IsLocal(f) {
+			continue
+		}
+		before := strings.Split(string(f.Content), config.NewlineLF)
+		after := slices.Clone(before)
+		changed := false
+		for n, line := range after {
+			for from, to := range moves {
+				line = pathPattern(from).ReplaceAllString(line, "${1}"+to+"${2}")
+			}
+			if f.Name == config.FileTask && staleTask(line, deleted) {
+				line = task.WithLabel(line, config.TaskLabelStaleRef)
+			}
+			if line != after[n] {
+				after[n] = line
+				changed = true
+			}
+		}
+		if changed {
+			edits = append(edits, fileEdit{
+				name: f.Name, path: f.Path, before: before, after: after,
+			})
+		}
+	}
+	return edits
+}
+
+// pathPattern matches a path where it stands on its own, not as part of
+// a longer path.
+//
+// Parameters:
+//   - path: Path to match
+//
+// Returns:
+//   - *regexp.Regexp: Pattern whose groups 1 and 2 hold the characters
+//     around the path
+func pathPattern(path string) *regexp.Regexp {
+	return regexp.MustCompile(
+		`(^|[^\w./-])` + regexp.QuoteMeta(path) + `(\.?(?:[^\w./-]|$))`,
+	)
+}
+
+// staleTask reports whether a line is a pending task that mentions a
+// deleted path and is not yet labeled #stale-ref.
+//
+// Parameters:
+//   - line: Line of TASKS.md
+//   - deleted: Paths of deleted files
+//
+// Returns:
+//   - bool: True if the task should be labeled
+func staleTask(line string, deleted []string) bool {
+	match := config.RegExTask.FindStringSubmatch(line)
+	if match == nil || !task.Pending(match) ||
+		task.WithoutLabel(line, config.TaskLabelStaleRef) !=
+			strings.TrimRight(line, config.Whitespace) {
+		return false
+	}
+	return slices.ContainsFunc(deleted, func(p string) bool {
+		return pathPattern(p).MatchString(line)
+	})
+}
+
+// previewEdits prints the planned changes as a diff.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - edits: Planned changes
+func previewEdits(cmd *cobra.Command, edits []fileEdit) {
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	cmd.Println()
+	for _, e := range edits {
+		cmd.Println(cyan(e.name))
+		for n := range e.before {
+			if e.before[n] == e.after[n] {
+				continue
+			}
+			cmd.Println(red(fmt.Sprintf("  %d - %s", n+1, e.before[n])))
+			cmd.Println(green(fmt.Sprintf("  %d + %s", n+1, e.after[n])))
+		}
+	}
+	cmd.Println()
+}
+
+// confirmEdits asks whether to write the previewed changes.
+//
+// Parameters:
+//   - cmd: Cobra command for the prompt and its input
+//   - yes: Confirm without asking
+//
+// Returns:
+//   - bool: True if the changes should be written; false if the answer
+//     is not yes or cannot be read
+func confirmEdits(cmd *cobra.Command, yes bool) bool {
+	if yes {
+		return true
+	}
+	cmd.Print("Apply these changes? [y/N] ")
+	response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if response == "" {
+		cmd.Println()
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes" //nolint:goconst // trivial user input check
+}
@@ -9,10 +9,12 @@ package drift
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
 	"github.com/ActiveMemory/ctx/internal/drift"
 )
@@ -21,26 +23,34 @@ import (
 //
 // Loads context, runs drift detection, and outputs results in the
 // specified format. When `fix` is true, attempts to auto-fix supported
-// issue types (staleness, missing_file).
+// issue types (staleness, missing_file, dead_path). Problems in the "drift:"
+// section of .ctxrc are reported as warnings on stderr. Issues accepted
+// in the baseline are left out and do not affect the status.
 //
 // Parameters:
 //   - cmd: Cobra command for output stream
 //   - jsonOutput: If true, output as JSON; otherwise output as text
 //   - fix: If true, attempt to auto-fix supported issues
+//   - yes: If true, apply dead path rewrites without asking
+//   - only: Checks or issue types to run; empty runs all enabled checks
 //
 // Returns:
-//   - error: Non-nil if context loading fails or .context/ is not found
-func runDrift(cmd *cobra.Command, jsonOutput, fix bool) error {
-	ctx, err := context.Load("")
+//   - error: Non-nil if context loading fails, .context/ is not found,
+//     or only names an unknown check
+func runDrift(
+	cmd *cobra.Command, jsonOutput, fix, yes bool, only []string,
+) error {
+	ctx, err := loadContext(cmd)
 	if err != nil {
-		var notFoundError *context.NotFoundError
-		if errors.As(err, &notFoundError) {
-			return errNoContext()
-		}
 		return err
 	}
 
-	report := drift.Detect(ctx)
+	report, detectErr := drift.DetectOnly(ctx, only)
+	if detectErr != nil {
+		return detectErr
+	}
+	baseline := loadBaseline(cmd)
+	baseline.Apply(report)
 
 	// Apply fixes if requested
 	if fix && (len(report.Warnings) > 0 || len(report.Violations) > 0) {
@@ -50,7 +60,7 @@ func runDrift(cmd *cobra.Command, jsonOutput, fix bool) error {
 		cmd.Println("Applying fixes...")
 		cmd.Println()
 
-		result := applyFixes(cmd, ctx, report)
+		result := applyFixes(cmd, ctx, report, yes)
 
 		cmd.Println()
 		if result.fixed > 0 {
@@ -69,7 +79,8 @@ func runDrift(cmd *cobra.Command, jsonOutput, fix bool) error {
 			cmd.Println()
 			cmd.Println("Re-checking after fixes...")
 			ctx, _ = context.Load("")
-			report = drift.Detect(ctx)
+			report, _ = drift.DetectOnly(ctx, only)
+			baseline.Apply(report)
 		}
 	}
 
@@ -79,3 +90,31 @@ func runDrift(cmd *cobra.Command, jsonOutput, fix bool) error {
 
 	return outputDriftText(cmd, report)
 }
+
+// loadContext loads the context and warns about problems in the
+// "drift:" section of .ctxrc.
+//
+// Parameters:
+//   - cmd: Cobra command for warnings
+//
+// Returns:
+//   - *context.Context: The loaded context
+//   - error: Non-nil if loading fails or .context/ is not found
+func loadContext(cmd *cobra.Command) (*context.Context, error) {
+	ctx, err := context.Load("")
+	if err != nil {
+		var notFoundError *context.NotFoundError
+		if errors.As(err, &notFoundError) {
+			return nil, errNoContext()
+		}
+		return nil, err
+	}
+
+	if cfgErr := drift.ValidateConfig(); cfgErr != nil {
+		for _, line := range strings.Split(cfgErr.Error(), config.NewlineLF) {
+			cmd.PrintErrln(fmt.Sprintf("ctx: warning: %s", line))
+		}
+	}
+
+	return ctx, nil
+}
@@ -6,7 +6,11 @@
 
 package drift
 
-import "github.com/ActiveMemory/ctx/internal/drift"
+import (
+	"path/filepath"
+
+	"github.com/ActiveMemory/ctx/internal/drift"
+)
 
 // formatCheckName converts internal check identifiers to human-readable names.
 //
@@ -29,7 +33,30 @@ func formatCheckName(name drift.CheckName) string {
 		return "All required files present"
 	case drift.CheckFileAge:
 		return "No stale files by age"
+	case drift.CheckLayers:
+		return "Context layers merge cleanly"
+	case drift.CheckTaskDependencies:
+		return "Task dependencies are consistent"
+	case drift.CheckSymbolReferences:
+		return "Symbol references are valid"
 	default:
 		return string(name)
 	}
 }
+
+// issueFile returns the file label for an issue.
+//
+// Issues from a parent context layer are qualified with the layer path
+// so their provenance is visible.
+//
+// Parameters:
+//   - issue: Drift issue to label
+//
+// Returns:
+//   - string: Filename, or layer-qualified path for inherited files
+func issueFile(issue drift.Issue) string {
+	if issue.Layer == "" {
+		return issue.File
+	}
+	return filepath.Join(issue.Layer, issue.File)
+}
@@ -20,6 +20,20 @@ type fixResult struct {
 	errors  []string
 }
 
+// fileEdit is a planned rewrite of a context file by "ctx drift --fix".
+//
+// Fields:
+//   - name: Context file name, for display
+//   - path: File path to write
+//   - before: Current lines
+//   - after: Lines to write; same count as before
+type fileEdit struct {
+	name   string
+	path   string
+	before []string
+	after  []string
+}
+
 // JsonOutput represents the JSON structure for machine-readable drift output.
 //
 // Fields:
@@ -28,10 +42,54 @@ type fixResult struct {
 //   - Warnings: Issues that should be addressed but don't block
 //   - Violations: Constitution violations that must be fixed
 //   - Passed: Names of checks that passed successfully
+//   - Baselined: Issues accepted in the drift baseline
+//   - Resolved: Accepted issues that are no longer found
 type JsonOutput struct {
-	Timestamp  string            `json:"timestamp"`
-	Status     drift.StatusType  `json:"status"`
-	Warnings   []drift.Issue     `json:"warnings"`
-	Violations []drift.Issue     `json:"violations"`
-	Passed     []drift.CheckName `json:"passed"`
+	Timestamp  string                `json:"timestamp"`
+	Status     drift.StatusType      `json:"status"`
+	Warnings   []drift.Issue         `json:"warnings"`
+	Violations []drift.Issue         `json:"violations"`
+	Passed     []drift.CheckName     `json:"passed"`
+	Baselined  []drift.Issue         `json:"baselined,omitempty"`
+	Resolved   []drift.BaselineEntry `json:"resolved,omitempty"`
+}
+
+// CheckInfo describes a drift check for "ctx drift --list-checks --json".
+//
+// Fields:
+//   - Name: Check name
+//   - Description: What the check looks for
+//   - Severity: Effective severity of the check's issues
+//   - Enabled: Whether the check runs by default
+//   - Issues: Issue types the check reports
+//   - Options: Tunable thresholds with their effective values
+type CheckInfo struct {
+	Name        drift.CheckName  `json:"name"`
+	Description string           `json:"description"`
+	Severity    drift.StatusType `json:"severity"`
+	Enabled     bool             `json:"enabled"`
+	Issues      []IssueInfo      `json:"issues"`
+	Options     []OptionInfo     `json:"options,omitempty"`
+}
+
+// IssueInfo describes an issue type reported by a drift check.
+//
+// Fields:
+//   - Type: Issue type
+//   - Severity: Effective severity of issues of this type
+//   - Enabled: Whether issues of this type are reported by default
+type IssueInfo struct {
+	Type     drift.IssueType  `json:"type"`
+	Severity drift.StatusType `json:"severity"`
+	Enabled  bool             `json:"enabled"`
+}
+
+// OptionInfo describes a drift check option.
+//
+// Fields:
+//   - Option: Name, default and description of the option
+//   - Value: Effective value, from .ctxrc or the default
+type OptionInfo struct {
+	drift.Option
+	Value int `json:"value"`
 }
@@ -17,11 +17,16 @@ import (
 //
 // Flags:
 //   - --write, -w: Write the configuration file instead of printing
+//   - --profile: Only print hooks for this .ctxrc agent profile
+//     (claude-code)
 //
 // Returns:
 //   - *cobra.Command: Configured hook command that accepts a tool name argument
 func Cmd() *cobra.Command {
-	var write bool
+	var (
+		write   bool
+		profile string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "hook <tool>",
@@ -39,11 +44,15 @@ Supported tools:
 Use --write to generate the configuration file directly:
   ctx hook copilot --write    # Creates .github/copilot-instructions.md
 
+For claude-code, hook settings are also printed for every agent
+profile in .ctxrc; use --profile to print only one:
+  ctx hook claude-code --profile reviewer
+
 Example:
   ctx hook cursor`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runHook(cmd, args, write)
+			return runHook(cmd, args, write, profile)
 		},
 	}
 
@@ -51,6 +60,10 @@ Example:
 		&write, "write", "w", false,
 		"Write the configuration file instead of printing",
 	)
+	cmd.Flags().StringVar(
+		&profile, "profile", "",
+		"Only print hooks for this .ctxrc agent profile (claude-code)",
+	)
 
 	return cmd
 }
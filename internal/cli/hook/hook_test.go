@@ -7,7 +7,13 @@
 package hook
 
 import (
+	"bytes"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
 )
 
 // TestHookCommand tests the hook command.
@@ -45,3 +51,50 @@ func TestHookCommandUnknownTool(t *testing.T) {
 		t.Error("hook command should fail for unknown tool")
 	}
 }
+
+func TestHookClaudeCodeProfiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Cleanup(rc.Reset)
+
+	rcContent := "profiles:\n  reviewer:\n    sections: [conventions]\n" +
+		"  docs:\n    sections: [glossary]\n"
+	if err := os.WriteFile(config.FileContextRC, []byte(rcContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rc.Reset()
+
+	run := func(args ...string) (string, error) {
+		hookCmd := Cmd()
+		var out bytes.Buffer
+		hookCmd.SetOut(&out)
+		hookCmd.SetArgs(args)
+		err := hookCmd.Execute()
+		return out.String(), err
+	}
+
+	all, err := run("claude-code")
+	if err != nil {
+		t.Fatalf("hook claude-code failed: %v", err)
+	}
+	for _, want := range []string{
+		"ctx agent --profile docs 2>/dev/null || true",
+		"ctx agent --profile reviewer 2>/dev/null || true",
+		`"PreToolUse"`,
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("output missing %q:\n%s", want, all)
+		}
+	}
+
+	one, err := run("claude-code", "--profile", "docs")
+	if err != nil {
+		t.Fatalf("hook claude-code --profile failed: %v", err)
+	}
+	if strings.Contains(one, "--profile reviewer") {
+		t.Errorf("--profile docs printed other profiles:\n%s", one)
+	}
+
+	if _, err := run("claude-code", "--profile", "missing"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
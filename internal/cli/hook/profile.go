@@ -0,0 +1,114 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/claude"
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// profileHookCommand returns the ctx agent hook command for a profile.
+//
+// The profile sets its own budget, so no --budget is passed.
+//
+// Parameters:
+//   - name: Profile name from .ctxrc
+//
+// Returns:
+//   - string: Shell command for a PreToolUse hook
+func profileHookCommand(name string) string {
+	return fmt.Sprintf("ctx agent --profile %s 2>/dev/null || true", name)
+}
+
+// profileHooks builds PreToolUse hook settings that inject a profile's
+// context packet.
+//
+// Parameters:
+//   - name: Profile name from .ctxrc
+//
+// Returns:
+//   - claude.HookConfig: Hooks section with one ".*" matcher
+func profileHooks(name string) claude.HookConfig {
+	return claude.HookConfig{
+		PreToolUse: []claude.HookMatcher{{
+			Matcher: ".*",
+			Hooks: []claude.Hook{{
+				Type:    "command",
+				Command: profileHookCommand(name),
+			}},
+		}},
+	}
+}
+
+// printProfileHooks prints per-profile Claude Code hook settings.
+//
+// With a profile name, only that profile is printed; otherwise every
+// profile defined in .ctxrc is. Nothing is printed when there are no
+// profiles.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - profile: Profile name from --profile, or empty for all
+//
+// Returns:
+//   - error: Non-nil if the named profile is not defined or encoding
+//     fails
+func printProfileHooks(cmd *cobra.Command, profile string) error {
+	names := rc.ProfileNames()
+	if profile != "" {
+		if rc.Profile(profile) == nil {
+			return fmt.Errorf(
+				"unknown profile %q (defined: %s)",
+				profile, strings.Join(names, ", "),
+			)
+		}
+		names = []string{profile}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	cmd.Println()
+	cmd.Println(cyan("Agent Profiles"))
+	cmd.Println(cyan("=============="))
+	cmd.Println()
+	cmd.Println(fmt.Sprintf(
+		"To give an agent a profile's packet, add its hooks to the\n"+
+			"agent's .claude/settings.local.json (profiles from %s):",
+		config.FileContextRC,
+	))
+
+	for _, name := range names {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		settings := map[string]claude.HookConfig{"hooks": profileHooks(name)}
+		if err := enc.Encode(settings); err != nil {
+			return fmt.Errorf("failed to encode hooks for %s: %w", name, err)
+		}
+		cmd.Println()
+		cmd.Println(name + ":")
+		cmd.Println(green("```json"))
+		cmd.Print(buf.String())
+		cmd.Println(green("```"))
+	}
+
+	return nil
+}
@@ -149,10 +149,13 @@ var ToolConfigFiles = map[string]string{
 //   - cmd: Cobra command for output stream
 //   - args: Command arguments; args[0] is the tool name
 //   - write: If true, write the configuration file instead of printing
+//   - profile: Agent profile for claude-code hooks (empty for all)
 //
 // Returns:
 //   - error: Non-nil if the tool is not supported or file write fails
-func runHook(cmd *cobra.Command, args []string, write bool) error {
+func runHook(
+	cmd *cobra.Command, args []string, write bool, profile string,
+) error {
 	tool := strings.ToLower(args[0])
 
 	cyan := color.New(color.FgCyan).SprintFunc()
@@ -172,6 +175,8 @@ func runHook(cmd *cobra.Command, args []string, write bool) error {
 		cmd.Println("The plugin provides hooks (context monitoring, persistence")
 		cmd.Println("nudges, post-commit capture) and 25 skills automatically.")
 
+		return printProfileHooks(cmd, profile)
+
 	case "cursor":
 		cmd.Println(cyan("Cursor IDE Integration"))
 		cmd.Println(cyan("======================"))
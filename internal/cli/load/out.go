@@ -73,7 +73,7 @@ func outputAssembled(
 	// Sort files by read order
 	files := sortByReadOrder(ctx.Files)
 
-	tokensUsed := context.EstimateTokensString(sb.String())
+	tokensUsed := context.CountTokensString(sb.String())
 
 	for _, f := range files {
 		// Skip empty files
@@ -91,9 +91,13 @@ func outputAssembled(
 			break
 		}
 
-		// Add the file section
+		// Add the file section; inherited files name their layer
+		title := fileNameToTitle(f.Name)
+		if !ctx.IsLocal(&f) {
+			title = fmt.Sprintf("%s (%s)", title, f.Layer)
+		}
 		sb.WriteString(fmt.Sprintf(
-			config.TplLoadSectionHeading+nl+nl, fileNameToTitle(f.Name)),
+			config.TplLoadSectionHeading+nl+nl, title),
 		)
 		sb.Write(f.Content)
 		if !strings.HasSuffix(string(f.Content), nl) {
@@ -16,8 +16,9 @@ import (
 // sortByReadOrder sorts context files according to [config.FileReadOrder].
 //
 // Files not in the read-order list are assigned a low priority (100) and
-// will appear at the end. The original slice is not modified; a new sorted
-// slice is returned.
+// will appear at the end. Files sharing a name (from different context
+// layers) keep their nearest-layer-first order. The original slice is
+// not modified; a new sorted slice is returned.
 //
 // Parameters:
 //   - files: Context files to sort
@@ -35,7 +36,7 @@ func sortByReadOrder(files []context.FileInfo) []context.FileInfo {
 	sorted := make([]context.FileInfo, len(files))
 	copy(sorted, files)
 
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		pi, ok := priority[sorted[i].Name]
 		if !ok {
 			pi = 100
@@ -9,6 +9,8 @@ package status
 import (
 	"fmt"
 	"time"
+
+	"github.com/ActiveMemory/ctx/internal/context"
 )
 
 // formatTimeAgo returns a human-readable relative time string.
@@ -89,3 +91,21 @@ func formatBytes(b int64) string {
 	}
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
+
+// displayName returns the label shown for a file in the status listing.
+//
+// Files from the nearest layer show their bare name; files inherited
+// from a parent layer show their path so provenance is visible.
+//
+// Parameters:
+//   - ctx: Loaded context the file belongs to
+//   - f: File to label
+//
+// Returns:
+//   - string: Filename or layer-qualified path
+func displayName(ctx *context.Context, f *context.FileInfo) string {
+	if ctx.IsLocal(f) {
+		return f.Name
+	}
+	return f.Path
+}
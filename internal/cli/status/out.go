@@ -9,6 +9,7 @@ package status
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -35,13 +36,18 @@ func outputStatusJSON(
 		ContextDir:  ctx.Dir,
 		TotalFiles:  len(ctx.Files),
 		TotalTokens: ctx.TotalTokens,
+		Tokenizer:   context.ActiveTokenizer().Name(),
 		TotalSize:   ctx.TotalSize,
 		Files:       make([]FileStatus, 0, len(ctx.Files)),
 	}
+	if ctx.IsLayered() {
+		output.Layers = ctx.Layers
+	}
 
 	for _, f := range ctx.Files {
 		fs := FileStatus{
 			Name:    f.Name,
+			Layer:   f.Layer,
 			Tokens:  f.Tokens,
 			Size:    f.Size,
 			IsEmpty: f.IsEmpty,
@@ -53,6 +59,7 @@ func outputStatusJSON(
 		}
 		output.Files = append(output.Files, fs)
 	}
+	output.Pinned = pinnedEntries(ctx)
 
 	enc := json.NewEncoder(cmd.OutOrStdout())
 	enc.SetIndent("", "  ")
@@ -85,10 +92,14 @@ func outputStatusText(
 	cmd.Println()
 
 	cmd.Println(fmt.Sprintf("Context Directory: %s", ctx.Dir))
+	if ctx.IsLayered() {
+		cmd.Println(fmt.Sprintf("Layers: %s", strings.Join(ctx.Layers, " > ")))
+	}
 	cmd.Println(fmt.Sprintf("Total Files: %d", len(ctx.Files)))
 	cmd.Println(fmt.Sprintf(
-		"Token Estimate: %s tokens", formatNumber(ctx.TotalTokens)),
-	)
+		"Token Estimate: %s tokens (%s)",
+		formatNumber(ctx.TotalTokens), context.ActiveTokenizer().Name(),
+	))
 	cmd.Println()
 
 	cmd.Println("Files:")
@@ -99,6 +110,7 @@ func outputStatusText(
 	sortFilesByPriority(sortedFiles)
 
 	for _, f := range sortedFiles {
+		name := displayName(ctx, &f)
 		var status string
 		var indicator string
 		if f.IsEmpty {
@@ -112,7 +124,7 @@ func outputStatusText(
 		if verbose {
 			// Verbose: show tokens and size
 			cmd.Println(fmt.Sprintf("  %s %s (%s) [%s tokens, %s]",
-				indicator, f.Name, status,
+				indicator, name, status,
 				formatNumber(f.Tokens), formatBytes(f.Size)))
 
 			// Show content preview for non-empty files
@@ -123,7 +135,16 @@ func outputStatusText(
 				}
 			}
 		} else {
-			cmd.Println(fmt.Sprintf("  %s %s (%s)", indicator, f.Name, status))
+			cmd.Println(fmt.Sprintf("  %s %s (%s)", indicator, name, status))
+		}
+	}
+
+	// Pinned entries
+	if pinned := pinnedEntries(ctx); len(pinned) > 0 {
+		cmd.Println()
+		cmd.Println("Pinned (always in agent packets):")
+		for _, p := range pinned {
+			cmd.Println(fmt.Sprintf("  - %s: %s", p.File, p.Title))
 		}
 	}
 
@@ -133,7 +154,7 @@ func outputStatusText(
 	recentFiles := getRecentFiles(ctx.Files, 3)
 	for _, f := range recentFiles {
 		ago := formatTimeAgo(f.ModTime)
-		cmd.Println(fmt.Sprintf("  - %s modified %s", f.Name, ago))
+		cmd.Println(fmt.Sprintf("  - %s modified %s", displayName(ctx, &f), ago))
 	}
 
 	return nil
@@ -0,0 +1,58 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/index"
+)
+
+// pinnedEntries lists decisions, learnings and conventions marked #pin.
+//
+// Entries that also carry #nopacket are left out, matching ctx agent.
+//
+// Parameters:
+//   - ctx: Loaded context
+//
+// Returns:
+//   - []PinnedEntry: Pinned items in file priority order
+func pinnedEntries(ctx *context.Context) []PinnedEntry {
+	var pinned []PinnedEntry
+	for _, name := range []string{config.FileDecision, config.FileLearning} {
+		for _, f := range ctx.FileLayers(name) {
+			for _, eb := range index.ParseEntryBlocks(string(f.Content)) {
+				if eb.Entry.Pinned && !eb.Entry.NoPacket {
+					pinned = append(pinned, PinnedEntry{
+						File:  displayName(ctx, f),
+						Title: eb.Entry.Title,
+					})
+				}
+			}
+		}
+	}
+
+	for _, f := range ctx.FileLayers(config.FileConvention) {
+		matches := config.RegExBulletItem.FindAllStringSubmatch(
+			string(f.Content), -1,
+		)
+		for _, m := range matches {
+			text, pin, noPacket := index.ParsePacketMarkers(
+				strings.TrimSpace(m[1]),
+			)
+			if pin && !noPacket {
+				pinned = append(pinned, PinnedEntry{
+					File:  displayName(ctx, f),
+					Title: text,
+				})
+			}
+		}
+	}
+	return pinned
+}
@@ -0,0 +1,42 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+func TestPinnedEntries(t *testing.T) {
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{
+			{
+				Name:    config.FileConvention,
+				Content: []byte("- Use tabs #pin\n- Plain rule\n"),
+			},
+			{
+				Name: config.FileDecision,
+				Content: []byte("## [2026-02-18-120000] Core invariant #pin\n\n" +
+					"## [2026-02-17-120000] Conflicted #pin #nopacket\n"),
+			},
+		},
+	}
+
+	got := pinnedEntries(ctx)
+	if len(got) != 2 {
+		t.Fatalf("pinnedEntries() = %+v, want 2 items", got)
+	}
+	if got[0] != (PinnedEntry{File: config.FileDecision, Title: "Core invariant"}) {
+		t.Errorf("got[0] = %+v", got[0])
+	}
+	if got[1] != (PinnedEntry{File: config.FileConvention, Title: "Use tabs"}) {
+		t.Errorf("got[1] = %+v", got[1])
+	}
+}
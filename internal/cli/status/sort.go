@@ -21,7 +21,7 @@ import (
 // Parameters:
 //   - files: Slice of files to sort (modified in place)
 func sortFilesByPriority(files []context.FileInfo) {
-	sort.Slice(files, func(i, j int) bool {
+	sort.SliceStable(files, func(i, j int) bool {
 		return rc.FilePriority(
 			files[i].Name,
 		) < rc.FilePriority(files[j].Name)
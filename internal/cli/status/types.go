@@ -9,23 +9,42 @@ package status
 // Output represents the JSON output format for the status command.
 //
 // Fields:
-//   - ContextDir: Path to the .context/ directory
+//   - ContextDir: Path to the .context/ directory (the nearest layer)
+//   - Layers: Merged context directories, outermost first (omitted
+//     unless the context is layered)
 //   - TotalFiles: Number of context files found
 //   - TotalTokens: Estimated total token count across all files
+//   - Tokenizer: Tokenizer used for the token counts (e.g., "bpe")
 //   - TotalSize: Total size in bytes across all files
 //   - Files: Individual file status entries
+//   - Pinned: Entries and conventions marked #pin (always in ctx agent
+//     packets)
 type Output struct {
-	ContextDir  string       `json:"context_dir"`
-	TotalFiles  int          `json:"total_files"`
-	TotalTokens int          `json:"total_tokens"`
-	TotalSize   int64        `json:"total_size"`
-	Files       []FileStatus `json:"files"`
+	ContextDir  string        `json:"context_dir"`
+	Layers      []string      `json:"layers,omitempty"`
+	TotalFiles  int           `json:"total_files"`
+	TotalTokens int           `json:"total_tokens"`
+	Tokenizer   string        `json:"tokenizer"`
+	TotalSize   int64         `json:"total_size"`
+	Files       []FileStatus  `json:"files"`
+	Pinned      []PinnedEntry `json:"pinned,omitempty"`
+}
+
+// PinnedEntry is a decision, learning or convention marked #pin.
+//
+// Fields:
+//   - File: File the entry is in (path for parent-layer files)
+//   - Title: Entry title, or convention text
+type PinnedEntry struct {
+	File  string `json:"file"`
+	Title string `json:"title"`
 }
 
 // FileStatus represents a single file's status in JSON output.
 //
 // Fields:
 //   - Name: Filename (e.g., "TASKS.md")
+//   - Layer: Context directory the file was loaded from
 //   - Tokens: Estimated token count for this file
 //   - Size: File size in bytes
 //   - IsEmpty: True if the file has no meaningful content
@@ -34,6 +53,7 @@ type Output struct {
 //   - Preview: Content preview lines (only with --verbose)
 type FileStatus struct {
 	Name    string   `json:"name"`
+	Layer   string   `json:"layer"`
 	Tokens  int      `json:"tokens"`
 	Size    int64    `json:"size"`
 	IsEmpty bool     `json:"is_empty"`
@@ -6,10 +6,7 @@
 
 package task
 
-import (
-	"github.com/ActiveMemory/ctx/internal/config"
-	"github.com/ActiveMemory/ctx/internal/task"
-)
+import "github.com/ActiveMemory/ctx/internal/task"
 
 // countPendingTasks counts top-level unchecked tasks in the lines.
 //
@@ -20,9 +17,8 @@ import (
 //   - int: Number of top-level unchecked tasks
 func countPendingTasks(lines []string) int {
 	count := 0
-	for _, line := range lines {
-		match := config.RegExTask.FindStringSubmatch(line)
-		if match != nil && task.Pending(match) && !task.SubTask(match) {
+	for _, t := range task.Parse(lines) {
+		if t.Pending() {
 			count++
 		}
 	}
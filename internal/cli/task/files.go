@@ -0,0 +1,168 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// ignoreRule is one pattern from a .gitignore file.
+//
+// Fields:
+//   - pattern: Glob without the "!", leading "/" and trailing "/"
+//   - negate: True for "!pattern" (re-includes a path)
+//   - dirOnly: True for "pattern/" (matches directories only)
+//   - anchored: True if the pattern contains a "/" and so matches the
+//     path from the root, not just the last element
+type ignoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// sourceFiles lists the files of a project that are not ignored.
+//
+// Inside a git work tree this is what git sees: tracked files plus
+// untracked ones not excluded by .gitignore and the other exclude
+// files. Without git, the tree is walked and the root .gitignore
+// applied. Either way, .git and the context directory are skipped.
+//
+// Parameters:
+//   - root: Project root
+//   - contextDir: Context directory, skipped
+//
+// Returns:
+//   - []string: Slash-separated paths relative to root, sorted by git
+//     or in walk order
+//   - error: Non-nil if the tree cannot be walked
+func sourceFiles(root, contextDir string) ([]string, error) {
+	if rel, err := filepath.Rel(root, contextDir); err == nil {
+		contextDir = rel
+	}
+	skip := func(rel string) bool {
+		for _, dir := range []string{config.DirGit, filepath.ToSlash(contextDir)} {
+			if rel == dir || strings.HasPrefix(rel, dir+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	out, gitErr := exec.Command(
+		"git", "-C", root,
+		"ls-files", "-z", "--cached", "--others", "--exclude-standard",
+	).Output()
+	if gitErr == nil {
+		var files []string
+		for _, f := range strings.Split(string(out), "\x00") {
+			if f != "" && !skip(f) {
+				files = append(files, f)
+			}
+		}
+		return files, nil
+	}
+
+	rules := readGitignore(filepath.Join(root, config.FileGitignore))
+	var files []string
+	walkErr := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, relErr := filepath.Rel(root, p)
+		if relErr != nil || rel == "." {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if skip(rel) || ignored(rules, rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+
+	return files, walkErr
+}
+
+// readGitignore reads the rules of a .gitignore file.
+//
+// Parameters:
+//   - file: Path to the .gitignore file
+//
+// Returns:
+//   - []ignoreRule: Rules in file order; nil if the file cannot be read
+func readGitignore(file string) []ignoreRule {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil
+	}
+
+	var rules []ignoreRule
+	for _, line := range strings.Split(string(content), config.NewlineLF) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var r ignoreRule
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		line = strings.TrimPrefix(line, "**/")
+		r.anchored = strings.Contains(line, "/")
+		r.pattern = strings.TrimPrefix(line, "/")
+		if r.pattern != "" {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// ignored reports whether .gitignore rules exclude a path.
+//
+// As in git, the last matching rule wins, so "!pattern" can re-include
+// a path an earlier rule excluded.
+//
+// Parameters:
+//   - rules: Rules from readGitignore
+//   - rel: Slash-separated path relative to the root
+//   - isDir: True if the path is a directory
+//
+// Returns:
+//   - bool: True if the path is ignored
+func ignored(rules []ignoreRule, rel string, isDir bool) bool {
+	result := false
+	for _, r := range rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		name := path.Base(rel)
+		if r.anchored {
+			name = rel
+		}
+		if ok, _ := path.Match(r.pattern, name); ok {
+			result = !r.negate
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// graphLabelLen is the maximum length of a task title in a graph node.
+const graphLabelLen = 40
+
+// renderDOT renders a task dependency graph in Graphviz DOT format.
+//
+// Completed tasks are filled grey; archived and unknown IDs are drawn
+// dashed.
+//
+// Parameters:
+//   - g: Dependency graph
+//   - all: Include tasks without dependencies
+//
+// Returns:
+//   - string: DOT source
+func renderDOT(g *task.Graph, all bool) string {
+	nl := config.NewlineLF
+	var sb strings.Builder
+
+	sb.WriteString("digraph tasks {" + nl)
+	sb.WriteString("  rankdir=LR;" + nl)
+	sb.WriteString("  node [shape=box];" + nl)
+	for _, k := range g.Nodes(all) {
+		attrs := fmt.Sprintf("label=%q", nodeLabel(g, k))
+		switch t := g.Task(k); {
+		case t == nil:
+			attrs += ", style=dashed"
+		case t.Done:
+			attrs += ", style=filled, fillcolor=lightgrey"
+		}
+		sb.WriteString(fmt.Sprintf("  %q [%s];"+nl, k, attrs))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %q -> %q;"+nl, e.From, e.To))
+	}
+	sb.WriteString("}" + nl)
+
+	return sb.String()
+}
+
+// renderMermaid renders a task dependency graph as a Mermaid flowchart.
+//
+// Completed tasks get the "done" class; archived and unknown IDs get
+// the "missing" class.
+//
+// Parameters:
+//   - g: Dependency graph
+//   - all: Include tasks without dependencies
+//
+// Returns:
+//   - string: Mermaid source
+func renderMermaid(g *task.Graph, all bool) string {
+	nl := config.NewlineLF
+	var sb strings.Builder
+
+	sb.WriteString("graph LR" + nl)
+	for _, k := range g.Nodes(all) {
+		label := strings.ReplaceAll(nodeLabel(g, k), `"`, "#quot;")
+		class := ""
+		switch t := g.Task(k); {
+		case t == nil:
+			class = ":::missing"
+		case t.Done:
+			class = ":::done"
+		}
+		sb.WriteString(fmt.Sprintf(
+			"  %s[\"%s\"]%s"+nl, mermaidID(k), label, class,
+		))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf(
+			"  %s --> %s"+nl, mermaidID(e.From), mermaidID(e.To),
+		))
+	}
+	sb.WriteString("  classDef done fill:#ddd,color:#666" + nl)
+	sb.WriteString("  classDef missing stroke-dasharray:5 5" + nl)
+
+	return sb.String()
+}
+
+// nodeLabel returns the text shown in a graph node.
+//
+// Parameters:
+//   - g: Dependency graph
+//   - key: Node key
+//
+// Returns:
+//   - string: "key: title" for tasks, "id (archived)" or "id (missing)"
+//     for IDs without a task in TASKS.md
+func nodeLabel(g *task.Graph, key string) string {
+	t := g.Task(key)
+	switch {
+	case t != nil:
+		return key + ": " + truncate(t.Title, graphLabelLen)
+	case g.Archived[key]:
+		return key + " (archived)"
+	default:
+		return key + " (missing)"
+	}
+}
+
+// mermaidID makes a node key safe to use as a Mermaid node ID.
+//
+// Parameters:
+//   - key: Node key
+//
+// Returns:
+//   - string: Key with anything but letters and digits replaced by "_"
+func mermaidID(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// dependencyProblems describes the cycles and dangling references in a
+// dependency graph.
+//
+// Parameters:
+//   - g: Dependency graph
+//
+// Returns:
+//   - []string: One message per problem; empty if the graph is sound
+func dependencyProblems(g *task.Graph) []string {
+	var problems []string
+	for _, c := range g.Cycles() {
+		problems = append(problems, fmt.Sprintf(
+			"dependency cycle between %s", strings.Join(c, ", "),
+		))
+	}
+	for _, r := range g.Dangling {
+		problems = append(problems, fmt.Sprintf(
+			"%s: #%s:%s names an unknown task",
+			task.Key(r.Task), r.Tag, r.ID,
+		))
+	}
+	return problems
+}
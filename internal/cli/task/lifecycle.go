@@ -0,0 +1,114 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// lifecycleTask finds the pending task a lifecycle command acts on.
+//
+// Parameters:
+//   - tasks: Top-level tasks from task.Parse
+//   - id: Task ID, with or without a leading "#" or "#id:"
+//
+// Returns:
+//   - *task.Task: The task
+//   - error: Non-nil if no task has the ID, or the task is done or
+//     skipped
+func lifecycleTask(tasks []*task.Task, id string) (*task.Task, error) {
+	t := task.Find(tasks, id)
+	switch {
+	case t == nil:
+		return nil, fmt.Errorf(
+			"no task with ID %q. Use 'ctx tasks list' to see tasks",
+			task.NormalizeID(id),
+		)
+	case t.Done:
+		return nil, fmt.Errorf("task %s is already completed: %s", t.ID, t.Content)
+	case t.Skipped:
+		return nil, fmt.Errorf("task %s is skipped: %s", t.ID, t.Content)
+	}
+	return t, nil
+}
+
+// startTask labels a task as in progress.
+//
+// Adds "#in-progress" and a "#started:" timestamp unless the task has
+// them, and removes "#blocked" with the "- Blocked: ..." notes that
+// blockTask wrote: starting work means it is unblocked. An existing
+// "#started:" time is kept, so restarting a task does not shorten its
+// cycle time.
+//
+// Parameters:
+//   - lines: TASKS.md lines
+//   - t: Task to start, parsed from lines
+//   - now: Time to record as the start
+//
+// Returns:
+//   - []string: Updated lines
+//   - bool: True if the task changed
+func startTask(lines []string, t *task.Task, now time.Time) ([]string, bool) {
+	line := lines[t.Line]
+	if t.HasLabel(config.TaskLabelBlocked) {
+		line = task.WithoutLabel(line, config.TaskLabelBlocked)
+	}
+	if !t.HasLabel(config.TaskLabelInProgress) {
+		line = task.WithLabel(line, config.TaskLabelInProgress)
+	}
+	if t.Started.IsZero() {
+		line = task.WithTag(
+			line, config.TaskTagStarted, now.Format("2006-01-02-150405"),
+		)
+	}
+	changed := line != lines[t.Line]
+	lines[t.Line] = line
+
+	// Notes of subtasks are indented deeper and stay with them
+	note := strings.Repeat(" ", t.Indent+2) + "- " + config.PrefixTaskBlockedReason
+	for i := t.End - 1; i > t.Line; i-- {
+		if strings.HasPrefix(lines[i], note) {
+			lines = slices.Delete(lines, i, i+1)
+			changed = true
+		}
+	}
+	return lines, changed
+}
+
+// blockTask labels a task as blocked and records why.
+//
+// The task stays where it is (tasks never move between phases): it gets
+// "#blocked" in place of "#in-progress", and the reason is written as
+// an indented note ("- Blocked: ...") after the task's description,
+// before any other notes and subtasks, where it travels with the task
+// when it is archived.
+//
+// Parameters:
+//   - lines: TASKS.md lines
+//   - t: Task to block, parsed from lines
+//   - reason: Why the task is blocked
+//
+// Returns:
+//   - []string: Updated lines
+func blockTask(lines []string, t *task.Task, reason string) []string {
+	line := task.WithoutLabel(lines[t.Line], config.TaskLabelInProgress)
+	if !t.HasLabel(config.TaskLabelBlocked) {
+		line = task.WithLabel(line, config.TaskLabelBlocked)
+	}
+	lines[t.Line] = line
+
+	note := strings.Repeat(" ", t.Indent+2) + "- " +
+		config.PrefixTaskBlockedReason +
+		strings.Join(strings.Fields(reason), " ")
+	return slices.Insert(lines, t.TextEnd, note)
+}
@@ -0,0 +1,345 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// listPhaseLen is the maximum width of the phase column in list tables.
+const listPhaseLen = 24
+
+// taskStatuses are the values accepted by "ctx tasks list --status".
+var taskStatuses = []string{
+	config.TaskStatusPending,
+	config.TaskStatusInProgress,
+	config.TaskStatusDone,
+	config.TaskStatusSkipped,
+}
+
+// match reports whether a task passes every filter that is set.
+//
+// Parameters:
+//   - t: Task to check
+//
+// Returns:
+//   - bool: True if the task matches
+func (f listFilter) match(t *task.Task) bool {
+	for _, label := range f.labels {
+		if !hasLabel(t, label) {
+			return false
+		}
+	}
+	if f.status != "" && !hasStatus(t, f.status) {
+		return false
+	}
+	if f.phase != "" && !containsFold(t.Phase, f.phase) {
+		return false
+	}
+	if !f.addedSince.IsZero() &&
+		(t.Added.IsZero() || t.Added.Before(f.addedSince)) {
+		return false
+	}
+	if f.source != "" &&
+		!strings.EqualFold(t.Tags[config.TaskTagSource], f.source) {
+		return false
+	}
+	if f.text != "" && !containsFold(t.Text, f.text) {
+		return false
+	}
+	return true
+}
+
+// hasLabel reports whether a task carries a label filter.
+//
+// Parameters:
+//   - t: Task to check
+//   - label: "name" matches a bare label or any value of a key-value
+//     tag; "key:value" matches that tag value. A leading "#" is ignored.
+//
+// Returns:
+//   - bool: True if the task carries the label
+func hasLabel(t *task.Task, label string) bool {
+	label = strings.TrimPrefix(label, "#")
+	key, value, hasValue := strings.Cut(label, ":")
+	if hasValue {
+		return strings.EqualFold(t.Tags[key], value)
+	}
+	_, tagged := t.Tags[key]
+	return t.HasLabel(key) || tagged
+}
+
+// hasStatus reports whether a task has a status.
+//
+// In-progress tasks are pending too, so "--status pending" lists them.
+//
+// Parameters:
+//   - t: Task to check
+//   - status: One of taskStatuses
+//
+// Returns:
+//   - bool: True if the task has the status
+func hasStatus(t *task.Task, status string) bool {
+	if status == config.TaskStatusPending {
+		return t.Pending()
+	}
+	return t.Status() == status
+}
+
+// containsFold reports whether s contains substr, ignoring case.
+//
+// Parameters:
+//   - s: Text to search
+//   - substr: Text to find
+//
+// Returns:
+//   - bool: True if substr occurs in s
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// filterTasks selects the tasks that match a filter, keeping the
+// parent-subtask structure.
+//
+// A subtask that matches is listed under its parent; a parent that does
+// not match itself is kept as context (listedTask.Context) so the
+// subtask is not shown on its own.
+//
+// Parameters:
+//   - tasks: Tasks from task.Parse (top-level, or a task's Subtasks)
+//   - f: Filter to apply
+//
+// Returns:
+//   - []*listedTask: Matching tasks and the parents of matching subtasks
+func filterTasks(tasks []*task.Task, f listFilter) []*listedTask {
+	var listed []*listedTask
+	for _, t := range tasks {
+		subtasks := filterTasks(t.Subtasks, f)
+		matched := f.match(t)
+		if !matched && len(subtasks) == 0 {
+			continue
+		}
+		lt := &listedTask{
+			ID:       t.ID,
+			Title:    t.Title,
+			Status:   t.Status(),
+			Priority: t.Tags[config.TaskTagPriority],
+			Phase:    task.StripTags(t.Phase),
+			Labels:   t.Labels,
+			Line:     t.Line + 1,
+			Context:  !matched,
+			Subtasks: subtasks,
+			task:     t,
+		}
+		if !t.Added.IsZero() {
+			lt.Added = t.Added.Format("2006-01-02")
+		}
+		if !t.Completed.IsZero() {
+			lt.Completed = t.Completed.Format("2006-01-02")
+		}
+		listed = append(listed, lt)
+	}
+	return listed
+}
+
+// countListed counts the tasks in a listing that matched the filter.
+//
+// Parameters:
+//   - listed: Filtered tasks
+//
+// Returns:
+//   - int: Matching tasks, subtasks included, context parents excluded
+func countListed(listed []*listedTask) int {
+	n := 0
+	for _, lt := range listed {
+		if !lt.Context {
+			n++
+		}
+		n += countListed(lt.Subtasks)
+	}
+	return n
+}
+
+// parseSince reads a point in time given as a date or an age.
+//
+// Parameters:
+//   - value: "YYYY-MM-DD", or a number of days or weeks ago ("30d", "2w")
+//   - now: Time ages are counted back from
+//
+// Returns:
+//   - time.Time: Start of the period
+//   - error: Non-nil if the value is neither a date nor an age
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t := task.ParseTime(value); !t.IsZero() {
+		return t, nil
+	}
+	if len(value) > 1 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n >= 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf(
+		"invalid date %q (use YYYY-MM-DD, or an age like 30d or 2w)", value,
+	)
+}
+
+// outputListJSON writes filtered tasks as a JSON array.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - listed: Filtered tasks
+//
+// Returns:
+//   - error: Non-nil if JSON encoding fails
+func outputListJSON(cmd *cobra.Command, listed []*listedTask) error {
+	if listed == nil {
+		listed = []*listedTask{}
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(listed)
+}
+
+// outputListTable prints filtered tasks as a table, subtasks indented
+// under their parents.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - listed: Filtered tasks (at least one)
+func outputListTable(cmd *cobra.Command, listed []*listedTask) {
+	header := color.New(color.Bold)
+	dim := color.New(color.FgHiBlack)
+
+	type row struct {
+		lt    *listedTask
+		depth int
+	}
+	var rows []row
+	var walk func(items []*listedTask, depth int)
+	walk = func(items []*listedTask, depth int) {
+		for _, lt := range items {
+			rows = append(rows, row{lt, depth})
+			walk(lt.Subtasks, depth+1)
+		}
+	}
+	walk(listed, 0)
+
+	idW, statusW, prioW, phaseW := len("ID"), len("Status"), len("Priority"),
+		len("Phase")
+	for _, r := range rows {
+		idW = max(idW, len(r.lt.ID))
+		statusW = max(statusW, len(r.lt.Status))
+		prioW = max(prioW, len(r.lt.Priority))
+		phaseW = max(phaseW, len([]rune(truncate(r.lt.Phase, listPhaseLen))))
+	}
+
+	rowFmt := fmt.Sprintf("  %%-%ds  %%-%ds  %%-%ds  %%-%ds  %%s\n",
+		idW, statusW, prioW, phaseW)
+	_, _ = header.Fprintf(cmd.OutOrStdout(), rowFmt,
+		"ID", "Status", "Priority", "Phase", "Task")
+
+	for _, r := range rows {
+		line := fmt.Sprintf(rowFmt, r.lt.ID, r.lt.Status, r.lt.Priority,
+			truncate(r.lt.Phase, listPhaseLen),
+			strings.Repeat("  ", r.depth)+r.lt.Title)
+		if r.lt.Context {
+			_, _ = dim.Fprint(cmd.OutOrStdout(), line)
+			continue
+		}
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), line)
+	}
+
+	cmd.Println()
+	cmd.Println(fmt.Sprintf("%d tasks", countListed(listed)))
+}
+
+// outputListMarkdown prints filtered tasks as a TASKS.md-style
+// checklist, grouped under their phase headings.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - listed: Filtered tasks
+func outputListMarkdown(cmd *cobra.Command, listed []*listedTask) {
+	nl := config.NewlineLF
+	var sb strings.Builder
+
+	var write func(items []*listedTask, depth int)
+	write = func(items []*listedTask, depth int) {
+		for _, lt := range items {
+			box := " "
+			switch lt.Status {
+			case config.TaskStatusDone:
+				box = config.MarkTaskComplete
+			case config.TaskStatusSkipped:
+				box = config.MarkTaskSkipped
+			}
+			sb.WriteString(fmt.Sprintf("%s- [%s] %s"+nl,
+				strings.Repeat("  ", depth), box, lt.task.Content))
+			write(lt.Subtasks, depth+1)
+		}
+	}
+
+	phase := ""
+	for i, lt := range listed {
+		if i == 0 || lt.task.Phase != phase {
+			phase = lt.task.Phase
+			if i > 0 {
+				sb.WriteString(nl)
+			}
+			if phase != "" {
+				sb.WriteString("### " + phase + nl + nl)
+			}
+		}
+		write([]*listedTask{lt}, 0)
+	}
+
+	cmd.Print(sb.String())
+}
+
+// truncate shortens text to a maximum number of characters.
+//
+// Parameters:
+//   - s: Text to shorten
+//   - maxLen: Maximum length in runes
+//
+// Returns:
+//   - string: s, or its first maxLen-1 runes followed by "…"
+func truncate(s string, maxLen int) string {
+	r := []rune(s)
+	if len(r) <= maxLen {
+		return s
+	}
+	return string(r[:maxLen-1]) + "…"
+}
+
+// validStatus reports whether a --status value is known.
+//
+// Parameters:
+//   - status: Value to check
+//
+// Returns:
+//   - bool: True if status is one of taskStatuses
+func validStatus(status string) bool {
+	return slices.Contains(taskStatuses, status)
+}
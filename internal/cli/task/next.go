@@ -0,0 +1,92 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// outputNextJSON writes ranked tasks as a JSON array.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - ranked: Candidates to print, best first
+//
+// Returns:
+//   - error: Non-nil if JSON encoding fails
+func outputNextJSON(cmd *cobra.Command, ranked []task.Candidate) error {
+	out := make([]nextTask, 0, len(ranked))
+	for i, c := range ranked {
+		nt := nextTask{
+			Rank:       i + 1,
+			ID:         c.Task.ID,
+			Title:      c.Task.Title,
+			Line:       c.Task.Line + 1,
+			Phase:      c.Task.Phase,
+			Priority:   c.Priority,
+			InProgress: c.InProgress,
+			Blocked:    c.Blocked,
+			Ready:      c.Ready(),
+			Reasons:    c.Reasons(),
+		}
+		for _, dep := range c.Open {
+			nt.WaitingOn = append(nt.WaitingOn, task.Key(dep))
+		}
+		nt.WaitingOn = append(nt.WaitingOn, c.Unknown...)
+		if !c.Task.Added.IsZero() {
+			nt.Added = c.Task.Added.Format("2006-01-02")
+		}
+		out = append(out, nt)
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// outputNext prints ranked tasks with the reasons for their rank.
+//
+// A single task is printed as "Next: ..."; several are numbered.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - ranked: Candidates to print, best first (at least one)
+func outputNext(cmd *cobra.Command, ranked []task.Candidate) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	for i, c := range ranked {
+		title := fmt.Sprintf("%s  %s", cyan(task.Key(c.Task)), c.Task.Title)
+		indent := "  "
+		if len(ranked) == 1 {
+			cmd.Println("Next: " + title)
+		} else {
+			if i > 0 {
+				cmd.Println()
+			}
+			cmd.Println(fmt.Sprintf("%d. %s", i+1, title))
+			indent = "   "
+		}
+		for _, r := range c.Reasons() {
+			cmd.Println(fmt.Sprintf("%s- %s", indent, r))
+		}
+	}
+
+	if !ranked[0].Ready() {
+		cmd.Println()
+		cmd.Println(fmt.Sprintf(
+			"%s No task is ready: every pending task is blocked or waiting "+
+				"on open or unknown dependencies.", yellow("⚠"),
+		))
+	}
+}
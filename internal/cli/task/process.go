@@ -7,7 +7,6 @@
 package task
 
 import (
-	"bufio"
 	"strings"
 
 	"github.com/ActiveMemory/ctx/internal/config"
@@ -17,11 +16,13 @@ import (
 // separateTasks parses TASKS.md and separates completed from pending tasks.
 //
 // The function scans TASKS.md line by line, identifying task items by their
-// checkbox markers ([x] for completed, [ ] for pending). It preserves phase
+// checkbox markers ([x] for completed, [-] for skipped, [ ] for pending).
+// Skipped tasks are archived with completed ones. It preserves phase
 // headers (### Phase ...) in the archived content for traceability.
 //
-// Subtasks (indented task items) follow their parent task:
-//   - Subtasks of completed tasks are archived with the parent
+// Tasks are read with task.Parse. Subtasks and other nested content
+// follow their parent task:
+//   - Subtasks of completed and skipped tasks are archived with the parent
 //   - Subtasks of pending tasks remain with the parent
 //
 // Parameters:
@@ -29,8 +30,9 @@ import (
 //
 // Returns:
 //   - remaining: Content with only pending tasks (to write back to TASKS.md)
-//   - archived: Content with completed tasks and their phase headers
-//   - stats: Counts of completed and pending tasks processed
+//   - archived: Content with completed and skipped tasks and their phase
+//     headers
+//   - stats: Counts of completed, skipped and pending tasks processed
 func separateTasks(content string) (string, string, taskStats) {
 	var remaining strings.Builder
 	var archived strings.Builder
@@ -42,11 +44,19 @@ func separateTasks(content string) (string, string, taskStats) {
 	var phaseHasArchivedTasks bool
 	var phaseArchiveBuffer strings.Builder
 
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	var inCompletedTask bool
+	lines := strings.Split(strings.TrimSuffix(content, nl), nl)
+	if content == "" {
+		lines = nil
+	}
+
+	// Index top-level tasks by their first line
+	tasks := make(map[int]*task.Task)
+	for _, t := range task.Parse(lines) {
+		tasks[t.Line] = t
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	for i := 0; i < len(lines); {
+		line := lines[i]
 
 		// Check for phase headers
 		if config.RegExPhase.MatchString(line) {
@@ -61,40 +71,33 @@ func separateTasks(content string) (string, string, taskStats) {
 			phaseHasArchivedTasks = false
 			phaseArchiveBuffer.Reset()
 			remaining.WriteString(line + nl)
-			inCompletedTask = false
+			i++
 			continue
 		}
 
-		// Check if the line is a task item
-		match := config.RegExTask.FindStringSubmatch(line)
-		if match != nil {
-			if task.SubTask(match) {
-				// Handle subtasks - follow their parent
-				if inCompletedTask {
-					phaseArchiveBuffer.WriteString(line + nl)
-				} else {
-					remaining.WriteString(line + nl)
-				}
-				continue
-			}
+		t, ok := tasks[i]
+		if !ok {
+			// Non-task lines go to the remaining
+			remaining.WriteString(line + nl)
+			i++
+			continue
+		}
 
-			// Top-level task
-			if task.Completed(match) {
-				stats.completed++
-				phaseHasArchivedTasks = true
-				phaseArchiveBuffer.WriteString(line + nl)
-				inCompletedTask = true
+		// Subtasks and nested content follow their top-level task
+		block := strings.Join(lines[t.Line:t.End], nl) + nl
+		if !t.Pending() {
+			if t.Skipped {
+				stats.skipped++
 			} else {
-				stats.pending++
-				remaining.WriteString(line + nl)
-				inCompletedTask = false
+				stats.completed++
 			}
-			continue
+			phaseHasArchivedTasks = true
+			phaseArchiveBuffer.WriteString(block)
+		} else {
+			stats.pending++
+			remaining.WriteString(block)
 		}
-
-		// Non-task lines go to the remaining
-		remaining.WriteString(line + nl)
-		inCompletedTask = false
+		i = t.End
 	}
 
 	// Flush final phase's archived tasks
@@ -0,0 +1,177 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// staleDays is the default number of days a task may be in progress
+// before "ctx tasks report" calls it stale.
+const staleDays = 7
+
+// outputReportJSON writes task flow metrics as JSON.
+//
+// Parameters:
+//   - cmd: Cobra command for output stream
+//   - r: Metrics to write
+//   - staleAfter: Stale threshold the report was computed with
+//
+// Returns:
+//   - error: Non-nil if JSON encoding fails
+func outputReportJSON(
+	cmd *cobra.Command, r task.Report, staleAfter time.Duration,
+) error {
+	out := taskReport{
+		Since:      r.Since.Format("2006-01-02"),
+		Until:      r.Until.Format("2006-01-02"),
+		Completed:  len(r.Completed),
+		LeadTime:   spanStats(r.LeadTime),
+		CycleTime:  spanStats(r.CycleTime),
+		Throughput: make([]reportWeek, 0, len(r.Throughput)),
+		StaleDays:  int(staleAfter / (24 * time.Hour)),
+		Stale:      make([]staleTask, 0, len(r.Stale)),
+	}
+	for _, w := range r.Throughput {
+		out.Throughput = append(out.Throughput, reportWeek{
+			Week: w.Start.Format("2006-01-02"), Done: w.Done,
+		})
+	}
+	for _, t := range r.Stale {
+		out.Stale = append(out.Stale, staleTask{
+			ID:      t.ID,
+			Title:   t.Title,
+			Started: t.Started.Format("2006-01-02"),
+			Days:    int(r.Until.Sub(t.Started).Hours() / 24),
+		})
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// outputReport prints task flow metrics.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - r: Metrics to print
+//   - staleAfter: Stale threshold the report was computed with
+func outputReport(cmd *cobra.Command, r task.Report, staleAfter time.Duration) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	header := color.New(color.Bold).SprintFunc()
+
+	cmd.Println(header(fmt.Sprintf(
+		"Task report: %s to %s", r.Since.Format("2006-01-02"),
+		r.Until.Format("2006-01-02"),
+	)))
+	cmd.Println()
+	cmd.Println(fmt.Sprintf("  Completed:   %d tasks", len(r.Completed)))
+	cmd.Println(fmt.Sprintf(
+		"  Lead time:   %s", formatStats(r.LeadTime, "#added"),
+	))
+	cmd.Println(fmt.Sprintf(
+		"  Cycle time:  %s", formatStats(r.CycleTime, "#started"),
+	))
+
+	cmd.Println()
+	cmd.Println(header("Throughput per week:"))
+	for _, w := range r.Throughput {
+		cmd.Println(strings.TrimRight(fmt.Sprintf(
+			"  %s  %3d  %s", w.Start.Format("2006-01-02"), w.Done,
+			strings.Repeat("█", w.Done),
+		), " "))
+	}
+
+	days := int(staleAfter / (24 * time.Hour))
+	cmd.Println()
+	if len(r.Stale) == 0 {
+		cmd.Println(fmt.Sprintf(
+			"No task has been in progress for more than %d days.", days,
+		))
+		return
+	}
+	cmd.Println(fmt.Sprintf(
+		"%s %d task(s) in progress for more than %d days:",
+		yellow("⚠"), len(r.Stale), days,
+	))
+	for _, t := range r.Stale {
+		cmd.Println(fmt.Sprintf(
+			"  %s  %s (started %s, %s ago)", cyan(task.Key(t)), t.Title,
+			t.Started.Format("2006-01-02"), formatSpan(r.Until.Sub(t.Started)),
+		))
+	}
+}
+
+// formatStats describes a set of spans on one line.
+//
+// Parameters:
+//   - d: Spans to describe
+//   - tag: Start tag the spans need, for the empty case (e.g., "#added")
+//
+// Returns:
+//   - string: "median 2d 4h, mean 3d, max 9d (n tasks)", or a note
+//     that no completed task has the tag
+func formatStats(d task.Durations, tag string) string {
+	if d.Count == 0 {
+		return fmt.Sprintf("n/a (no completed task has %s and #done)", tag)
+	}
+	return fmt.Sprintf(
+		"median %s, mean %s, max %s (%d tasks)",
+		formatSpan(d.Median), formatSpan(d.Mean), formatSpan(d.Max), d.Count,
+	)
+}
+
+// formatSpan renders a duration in days and hours.
+//
+// Parameters:
+//   - d: Duration to render
+//
+// Returns:
+//   - string: "<1h", "5h", "3d", or "3d 4h"
+func formatSpan(d time.Duration) string {
+	hours := int(d.Hours())
+	switch {
+	case hours < 1:
+		return "<1h"
+	case hours < 24:
+		return fmt.Sprintf("%dh", hours)
+	case hours%24 == 0:
+		return fmt.Sprintf("%dd", hours/24)
+	default:
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	}
+}
+
+// spanStats converts span statistics to their JSON form.
+//
+// Parameters:
+//   - d: Statistics to convert
+//
+// Returns:
+//   - reportSpans: Count and spans in hours, rounded to one decimal
+func spanStats(d task.Durations) reportSpans {
+	hours := func(v time.Duration) float64 {
+		return math.Round(v.Hours()*10) / 10
+	}
+	return reportSpans{
+		Count:       d.Count,
+		MedianHours: hours(d.Median),
+		MeanHours:   hours(d.Mean),
+		MaxHours:    hours(d.Max),
+	}
+}
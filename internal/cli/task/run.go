@@ -16,8 +16,11 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/ActiveMemory/ctx/internal/cli/add"
 	"github.com/ActiveMemory/ctx/internal/cli/compact"
 	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+	"github.com/ActiveMemory/ctx/internal/task"
 	"github.com/ActiveMemory/ctx/internal/validation"
 )
 
@@ -87,9 +90,10 @@ func runTasksSnapshot(cmd *cobra.Command, args []string) error {
 
 // runTaskArchive executes the archive subcommand logic.
 //
-// Moves completed tasks (marked with [x]) from TASKS.md to a timestamped
-// archive file, including all nested content (subtasks, metadata). Tasks
-// with incomplete children are skipped to avoid orphaning pending work.
+// Moves completed and skipped tasks (marked with [x] or [-]) from
+// TASKS.md to a timestamped archive file, including all nested content
+// (subtasks, metadata). Tasks with incomplete children are skipped to
+// avoid orphaning pending work.
 //
 // Parameters:
 //   - cmd: Cobra command (unused, for interface compliance)
@@ -197,3 +201,431 @@ func runTaskArchive(cmd *cobra.Command, dryRun bool) error {
 
 	return nil
 }
+
+// runTaskMigrate executes the migrate subcommand logic.
+//
+// Assigns an ID to every task in TASKS.md that does not have one and
+// writes the file back.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - dryRun: If true, preview the IDs without modifying files
+//
+// Returns:
+//   - error: Non-nil if TASKS.md doesn't exist or file operations fail
+func runTaskMigrate(cmd *cobra.Command, dryRun bool) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	tasksPath := tasksFilePath()
+	nl := config.NewlineLF
+
+	// Check if TASKS.md exists
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+
+	// Read TASKS.md
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	lines := strings.Split(string(content), nl)
+	assigned := task.AssignIDs(lines)
+
+	if len(assigned) == 0 {
+		cmd.Println("All tasks already have IDs.")
+		return nil
+	}
+
+	if dryRun {
+		cmd.Println(yellow("Dry run - no files modified"))
+		cmd.Println()
+	}
+	for _, t := range assigned {
+		cmd.Println(fmt.Sprintf("  #id:%s  %s", t.ID, t.Content))
+	}
+	if dryRun {
+		cmd.Println()
+		cmd.Println(fmt.Sprintf("Would assign IDs to %d tasks", len(assigned)))
+		return nil
+	}
+
+	if writeErr := os.WriteFile(
+		tasksPath, []byte(strings.Join(lines, nl)), config.PermFile,
+	); writeErr != nil {
+		return fmt.Errorf("failed to update TASKS.md: %w", writeErr)
+	}
+
+	cmd.Println()
+	cmd.Println(fmt.Sprintf(
+		"%s Assigned IDs to %d tasks in TASKS.md", green("✓"), len(assigned),
+	))
+
+	return nil
+}
+
+// runTaskGraph executes the graph subcommand logic.
+//
+// Builds the dependency graph of TASKS.md, with archived task IDs
+// counted as done, and prints it in the requested format. Problems
+// with the graph are printed to stderr as warnings.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - format: config.FormatDOT or config.FormatMermaid
+//   - all: Include tasks without dependencies
+//
+// Returns:
+//   - error: Non-nil if the format is unknown, TASKS.md doesn't exist,
+//     or files cannot be read
+func runTaskGraph(cmd *cobra.Command, format string, all bool) error {
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	var render func(*task.Graph, bool) string
+	switch format {
+	case config.FormatDOT:
+		render = renderDOT
+	case config.FormatMermaid:
+		render = renderMermaid
+	default:
+		return fmt.Errorf(
+			"unknown format %q (use %s or %s)",
+			format, config.FormatDOT, config.FormatMermaid,
+		)
+	}
+
+	tasksPath := tasksFilePath()
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	archived, archiveErr := task.ParseArchive(archiveDirPath())
+	if archiveErr != nil {
+		return fmt.Errorf("failed to read archive: %w", archiveErr)
+	}
+
+	tasks := task.Parse(strings.Split(string(content), config.NewlineLF))
+	g := task.NewGraph(tasks, task.IDs(archived))
+
+	cmd.Print(render(g, all))
+	for _, p := range dependencyProblems(g) {
+		cmd.PrintErrln(fmt.Sprintf("%s %s", yellow("⚠"), p))
+	}
+
+	return nil
+}
+
+// runTaskNext executes the next subcommand logic.
+//
+// Ranks the pending tasks in TASKS.md with task.Rank and prints the
+// first n.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - n: Number of tasks to print
+//   - asJSON: Print JSON instead of text
+//
+// Returns:
+//   - error: Non-nil if n is less than 1, TASKS.md doesn't exist, or it
+//     or the archive cannot be read
+func runTaskNext(cmd *cobra.Command, n int, asJSON bool) error {
+	if n < 1 {
+		return fmt.Errorf("--n must be at least 1, got %d", n)
+	}
+
+	tasksPath := tasksFilePath()
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	archived, archiveErr := task.ParseArchive(archiveDirPath())
+	if archiveErr != nil {
+		return fmt.Errorf("failed to read archive: %w", archiveErr)
+	}
+
+	ranked := task.Rank(
+		task.Parse(strings.Split(string(content), config.NewlineLF)),
+		task.IDs(archived),
+	)
+	ranked = ranked[:min(n, len(ranked))]
+
+	if asJSON {
+		return outputNextJSON(cmd, ranked)
+	}
+	if len(ranked) == 0 {
+		cmd.Println("No pending tasks.")
+		return nil
+	}
+	outputNext(cmd, ranked)
+
+	return nil
+}
+
+// runTaskList executes the list subcommand logic.
+//
+// Parses TASKS.md with task.Parse, the parser behind archiving, and
+// prints the tasks that match the filter.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - f: Filter from the flags (addedSince is set here)
+//   - addedSince: Raw --added-since value; empty for no limit
+//   - format: config.FormatTable, config.FormatJSON or
+//     config.FormatMarkdown
+//
+// Returns:
+//   - error: Non-nil if a flag is invalid, TASKS.md doesn't exist or
+//     cannot be read
+func runTaskList(
+	cmd *cobra.Command, f listFilter, addedSince, format string,
+) error {
+	switch format {
+	case config.FormatTable, config.FormatJSON, config.FormatMarkdown:
+	default:
+		return fmt.Errorf(
+			"unknown format %q (use %s, %s or %s)", format,
+			config.FormatTable, config.FormatJSON, config.FormatMarkdown,
+		)
+	}
+	if f.status != "" && !validStatus(f.status) {
+		return fmt.Errorf(
+			"unknown status %q (use %s)", f.status, strings.Join(taskStatuses, ", "),
+		)
+	}
+	if addedSince != "" {
+		since, sinceErr := parseSince(addedSince, time.Now())
+		if sinceErr != nil {
+			return sinceErr
+		}
+		f.addedSince = since
+	}
+
+	tasksPath := tasksFilePath()
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	listed := filterTasks(
+		task.Parse(strings.Split(string(content), config.NewlineLF)), f,
+	)
+
+	switch {
+	case format == config.FormatJSON:
+		return outputListJSON(cmd, listed)
+	case len(listed) == 0:
+		cmd.Println("No tasks match the filters.")
+	case format == config.FormatMarkdown:
+		outputListMarkdown(cmd, listed)
+	default:
+		outputListTable(cmd, listed)
+	}
+
+	return nil
+}
+
+// runTaskStart executes the start subcommand logic.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - id: ID of the task to start
+//
+// Returns:
+//   - error: Non-nil if TASKS.md doesn't exist, the task is not found
+//     or not pending, or file operations fail
+func runTaskStart(cmd *cobra.Command, id string) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	tasksPath := tasksFilePath()
+
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	lines := strings.Split(string(content), config.NewlineLF)
+	t, findErr := lifecycleTask(task.Parse(lines), id)
+	if findErr != nil {
+		return findErr
+	}
+
+	lines, started := startTask(lines, t, time.Now())
+	if !started {
+		cmd.Println(fmt.Sprintf("Already in progress: %s", t.Title))
+		return nil
+	}
+
+	if writeErr := os.WriteFile(
+		tasksPath, []byte(strings.Join(lines, config.NewlineLF)), config.PermFile,
+	); writeErr != nil {
+		return fmt.Errorf("failed to update TASKS.md: %w", writeErr)
+	}
+
+	cmd.Println(fmt.Sprintf("%s Started: %s", green("✓"), t.Title))
+
+	return nil
+}
+
+// runTaskBlock executes the block subcommand logic.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - id: ID of the task to block
+//   - reason: Why the task is blocked
+//
+// Returns:
+//   - error: Non-nil if the reason is empty, TASKS.md doesn't exist,
+//     the task is not found or not pending, or file operations fail
+func runTaskBlock(cmd *cobra.Command, id, reason string) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	tasksPath := tasksFilePath()
+
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("--reason must not be empty")
+	}
+
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	lines := strings.Split(string(content), config.NewlineLF)
+	t, findErr := lifecycleTask(task.Parse(lines), id)
+	if findErr != nil {
+		return findErr
+	}
+
+	lines = blockTask(lines, t, reason)
+	if writeErr := os.WriteFile(
+		tasksPath, []byte(strings.Join(lines, config.NewlineLF)), config.PermFile,
+	); writeErr != nil {
+		return fmt.Errorf("failed to update TASKS.md: %w", writeErr)
+	}
+
+	cmd.Println(fmt.Sprintf("%s Blocked: %s", green("✓"), t.Title))
+	cmd.Println(fmt.Sprintf("  Reason: %s", strings.Join(strings.Fields(reason), " ")))
+
+	return nil
+}
+
+// runTaskReport executes the report subcommand logic.
+//
+// Reads TASKS.md and the archived task files, computes the metrics with
+// task.NewReport and prints them.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - since: Raw --since value
+//   - stale: Days in progress after which a task is stale
+//   - asJSON: Print JSON instead of text
+//
+// Returns:
+//   - error: Non-nil if a flag is invalid, TASKS.md doesn't exist, or
+//     files cannot be read
+func runTaskReport(cmd *cobra.Command, since string, stale int, asJSON bool) error {
+	now := time.Now()
+	from, sinceErr := parseSince(since, now)
+	if sinceErr != nil {
+		return sinceErr
+	}
+	if stale < 1 {
+		return fmt.Errorf("--stale-days must be at least 1, got %d", stale)
+	}
+	staleAfter := time.Duration(stale) * 24 * time.Hour
+
+	tasksPath := tasksFilePath()
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	archived, archiveErr := task.ParseArchive(archiveDirPath())
+	if archiveErr != nil {
+		return fmt.Errorf("failed to read archive: %w", archiveErr)
+	}
+
+	tasks := task.Parse(strings.Split(string(content), config.NewlineLF))
+	r := task.NewReport(append(tasks, archived...), from, now, staleAfter)
+
+	if asJSON {
+		return outputReportJSON(cmd, r, staleAfter)
+	}
+	outputReport(cmd, r, staleAfter)
+
+	return nil
+}
+
+// runTaskScan executes the scan subcommand logic.
+//
+// Scans the project (the parent of the context directory) for code
+// comments, compares them with TASKS.md and, with apply, adds the new
+// ones through add.WriteEntry.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - apply: Write the new tasks to TASKS.md
+//   - section: Section to add them under; empty for the default place
+//
+// Returns:
+//   - error: Non-nil if TASKS.md doesn't exist, the project cannot be
+//     walked, or writing fails
+func runTaskScan(cmd *cobra.Command, apply bool, section string) error {
+	tasksPath := tasksFilePath()
+	if _, statErr := os.Stat(tasksPath); os.IsNotExist(statErr) {
+		return fmt.Errorf("no TASKS.md found")
+	}
+	content, readErr := os.ReadFile(filepath.Clean(tasksPath))
+	if readErr != nil {
+		return fmt.Errorf("failed to read TASKS.md: %w", readErr)
+	}
+
+	contextDir := rc.ContextDir()
+	root := filepath.Dir(contextDir)
+	files, filesErr := sourceFiles(root, contextDir)
+	if filesErr != nil {
+		return fmt.Errorf("failed to list source files: %w", filesErr)
+	}
+
+	plan := planScan(
+		task.Parse(strings.Split(string(content), config.NewlineLF)),
+		scanComments(root, files),
+	)
+
+	if apply {
+		// Each task is inserted at the same place, ahead of the previous
+		// one, so adding in reverse keeps them in file order
+		for i := len(plan.add) - 1; i >= 0; i-- {
+			if writeErr := add.WriteEntry(add.EntryParams{
+				Type:    config.EntryTask,
+				Content: plan.add[i].content(),
+				Section: section,
+			}); writeErr != nil {
+				return writeErr
+			}
+		}
+	}
+
+	outputScan(cmd, plan, apply)
+
+	return nil
+}
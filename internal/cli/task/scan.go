@@ -0,0 +1,275 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// scanComments finds TODO, FIXME and HACK comments in source files.
+//
+// Only files with a known comment syntax (config.CodeCommentPrefixes)
+// are read; binary files and files over config.MaxScanFileSize are
+// skipped.
+//
+// Parameters:
+//   - root: Project root
+//   - files: Slash-separated paths relative to root
+//
+// Returns:
+//   - []codeComment: Comments in file and line order
+func scanComments(root string, files []string) []codeComment {
+	var comments []codeComment
+	for _, rel := range files {
+		prefixes := commentPrefixes(rel)
+		if prefixes == nil {
+			continue
+		}
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		info, statErr := os.Stat(p)
+		if statErr != nil || !info.Mode().IsRegular() ||
+			info.Size() > config.MaxScanFileSize {
+			continue
+		}
+		content, readErr := os.ReadFile(filepath.Clean(p))
+		if readErr != nil || bytes.IndexByte(content, 0) >= 0 {
+			continue
+		}
+		for i, line := range strings.Split(string(content), config.NewlineLF) {
+			if c, ok := parseComment(line, prefixes); ok {
+				c.Path, c.Line = rel, i+1
+				comments = append(comments, c)
+			}
+		}
+	}
+	return comments
+}
+
+// commentPrefixes returns the comment tokens of a file's language.
+//
+// Parameters:
+//   - rel: Slash-separated file path
+//
+// Returns:
+//   - []string: Tokens that start a comment; nil for unknown languages
+func commentPrefixes(rel string) []string {
+	if prefixes, ok := config.CodeCommentPrefixes[path.Ext(rel)]; ok {
+		return prefixes
+	}
+	return config.CodeCommentPrefixes[path.Base(rel)]
+}
+
+// parseComment reads a TODO, FIXME or HACK comment from a line.
+//
+// The marker must open the comment, directly after a comment token, so
+// markers mentioned in prose are not picked up.
+//
+// Parameters:
+//   - line: Line of source code
+//   - prefixes: Comment tokens of the file's language
+//
+// Returns:
+//   - codeComment: Marker and text (Path and Line are not set)
+//   - bool: True if the line has such a comment
+func parseComment(line string, prefixes []string) (codeComment, bool) {
+	loc := config.RegExCodeMarker.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return codeComment{}, false
+	}
+	before := strings.TrimRight(line[:loc[0]], config.Whitespace)
+	opens := false
+	for _, p := range prefixes {
+		if strings.HasSuffix(before, p) {
+			opens = true
+			break
+		}
+	}
+	if !opens {
+		return codeComment{}, false
+	}
+
+	text := line[loc[4]:loc[5]]
+	for _, closer := range []string{"*/", config.CommentClose} {
+		text = strings.TrimSuffix(strings.TrimSpace(text), closer)
+	}
+	return codeComment{
+		Marker: line[loc[2]:loc[3]],
+		Text:   strings.Join(strings.Fields(text), " "),
+	}, true
+}
+
+// summary returns the comment as task text, without the reference.
+//
+// Returns:
+//   - string: "MARKER: text", or just the marker if there is no text
+func (c codeComment) summary() string {
+	if c.Text == "" {
+		return c.Marker
+	}
+	return c.Marker + ": " + c.Text
+}
+
+// content returns the text of the task proposed for the comment.
+//
+// Returns:
+//   - string: Summary, backticked "path:line" reference and source tag
+func (c codeComment) content() string {
+	return fmt.Sprintf(
+		"%s `%s:%d` #%s:%s",
+		c.summary(), c.Path, c.Line, config.TaskTagSource, config.TaskSourceCode,
+	)
+}
+
+// key identifies the comment regardless of its line number, which
+// shifts as the file is edited.
+//
+// Returns:
+//   - string: Normalized summary and path
+func (c codeComment) key() string {
+	return scanKey(c.summary(), c.Path)
+}
+
+// taskScanKey returns the key of a task written by "ctx tasks scan".
+//
+// Parameters:
+//   - t: Task to check
+//
+// Returns:
+//   - string: Key comparable to codeComment.key; empty if the task has
+//     no "path:line" reference
+func taskScanKey(t *task.Task) string {
+	refs := config.RegExCodeRef.FindAllStringSubmatchIndex(t.Title, -1)
+	if len(refs) == 0 {
+		return ""
+	}
+	last := refs[len(refs)-1]
+	summary := t.Title[:last[0]] + t.Title[last[1]:]
+	return scanKey(summary, t.Title[last[2]:last[3]])
+}
+
+// scanKey builds a comment key from its summary and path.
+//
+// Parameters:
+//   - summary: "MARKER: text"
+//   - file: Slash-separated file path
+//
+// Returns:
+//   - string: Key, with tags dropped and whitespace and case folded
+func scanKey(summary, file string) string {
+	return strings.ToLower(task.StripTags(summary)) + "\x00" + file
+}
+
+// planScan compares comments found in the code with TASKS.md.
+//
+// Parameters:
+//   - tasks: Top-level tasks from task.Parse
+//   - comments: Comments from scanComments
+//
+// Returns:
+//   - scanPlan: Comments to add, tracked count and tasks whose comment
+//     is gone
+func planScan(tasks []*task.Task, comments []codeComment) scanPlan {
+	plan := scanPlan{found: len(comments)}
+
+	existing := make(map[string]bool)
+	for _, t := range task.All(tasks) {
+		if k := taskScanKey(t); k != "" {
+			existing[k] = true
+		}
+	}
+
+	current := make(map[string]bool)
+	for _, c := range comments {
+		k := c.key()
+		switch {
+		case existing[k]:
+			plan.tracked++
+		case !current[k]:
+			plan.add = append(plan.add, c)
+		}
+		current[k] = true
+	}
+
+	for _, t := range task.All(tasks) {
+		if !t.Pending() ||
+			t.Tags[config.TaskTagSource] != config.TaskSourceCode {
+			continue
+		}
+		if k := taskScanKey(t); k != "" && !current[k] {
+			plan.gone = append(plan.gone, t)
+		}
+	}
+
+	return plan
+}
+
+// outputScan prints the result of a scan.
+//
+// Parameters:
+//   - cmd: Cobra command for output
+//   - plan: Scan result
+//   - applied: True if the new tasks were written to TASKS.md
+func outputScan(cmd *cobra.Command, plan scanPlan, applied bool) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	cmd.Println(fmt.Sprintf(
+		"Found %d TODO/FIXME/HACK comments (%d already tracked)",
+		plan.found, plan.tracked,
+	))
+
+	if len(plan.add) > 0 {
+		cmd.Println()
+		verb := "New tasks"
+		if applied {
+			verb = "Added"
+		}
+		cmd.Println(fmt.Sprintf("%s (%d):", verb, len(plan.add)))
+		for _, c := range plan.add {
+			cmd.Println(fmt.Sprintf(
+				"  %s %s `%s:%d`", green("+"), c.summary(), c.Path, c.Line,
+			))
+		}
+	}
+
+	if len(plan.gone) > 0 {
+		cmd.Println()
+		cmd.Println(fmt.Sprintf(
+			"%s Source comment gone (%d); complete or skip these tasks:",
+			yellow("⚠"), len(plan.gone),
+		))
+		for _, t := range plan.gone {
+			cmd.Println(fmt.Sprintf("  %s  %s", cyan(task.Key(t)), t.Title))
+		}
+	}
+
+	cmd.Println()
+	switch {
+	case len(plan.add) == 0:
+		cmd.Println("No new tasks.")
+	case applied:
+		cmd.Println(fmt.Sprintf(
+			"%s Added %d tasks to TASKS.md", green("✓"), len(plan.add),
+		))
+	default:
+		cmd.Println(fmt.Sprintf(
+			"Run with --apply to add %d tasks to TASKS.md.", len(plan.add),
+		))
+	}
+}
@@ -10,6 +10,13 @@
 // The task package provides subcommands to:
 //   - archive: Move completed tasks to timestamped archive files
 //   - snapshot: Create point-in-time copies of TASKS.md
+//   - migrate: Give existing tasks stable #id: tags
+//   - graph: Draw task dependencies as DOT or Mermaid
+//   - next: Pick the task to work on next
+//   - list: Query tasks by label, status, phase, age, source or text
+//   - start, block: Record lifecycle changes with labels and timestamps
+//   - report: Lead time, cycle time, throughput and stale work
+//   - scan: Turn TODO/FIXME/HACK comments in source code into tasks
 //
 // Archive files preserve phase structure for traceability, while snapshots
 // copy the entire file as-is without modification.
@@ -17,6 +24,8 @@ package task
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/ActiveMemory/ctx/internal/config"
 )
 
 // Cmd returns the tasks command with subcommands.
@@ -24,6 +33,14 @@ import (
 // The tasks command provides utilities for managing the task lifecycle:
 //   - archive: Move completed tasks out of TASKS.md
 //   - snapshot: Create point-in-time backup without modification
+//   - migrate: Assign IDs to tasks that have none
+//   - graph: Render the dependency graph
+//   - next: Rank pending tasks and print the best one
+//   - list: Filter and print tasks
+//   - start: Mark a task in progress
+//   - block: Mark a task blocked, with a reason
+//   - report: Summarize task flow over a period
+//   - scan: Sync code comments into TASKS.md
 //
 // Returns:
 //   - *cobra.Command: Configured tasks command with subcommands
@@ -39,11 +56,27 @@ copies without modifying the original.
 
 Subcommands:
   archive   Move completed tasks to timestamped archive file
-  snapshot  Create point-in-time snapshot of TASKS.md`,
+  snapshot  Create point-in-time snapshot of TASKS.md
+  migrate   Assign stable #id: tags to tasks without one
+  graph     Render task dependencies as DOT or Mermaid
+  next      Pick the task to work on next
+  list      List tasks, with filters
+  start     Mark a task #in-progress with a #started timestamp
+  block     Mark a task #blocked, with a reason
+  report    Report lead time, cycle time and throughput
+  scan      Propose tasks for TODO/FIXME/HACK comments in the code`,
 	}
 
 	cmd.AddCommand(archiveCmd())
 	cmd.AddCommand(snapshotCmd())
+	cmd.AddCommand(migrateCmd())
+	cmd.AddCommand(graphCmd())
+	cmd.AddCommand(nextCmd())
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(startCmd())
+	cmd.AddCommand(blockCmd())
+	cmd.AddCommand(reportCmd())
+	cmd.AddCommand(scanCmd())
 
 	return cmd
 }
@@ -116,3 +149,423 @@ Unlike archive, snapshot copies the entire file as-is.`,
 
 	return cmd
 }
+
+// migrateCmd returns the tasks migrate subcommand.
+//
+// The migrate command appends a stable "#id:" tag to every task in
+// TASKS.md that does not have one yet, so older files can use IDs with
+// "ctx complete". Tasks that already have an ID are left alone, so the
+// command is safe to run repeatedly.
+//
+// Flags:
+//   - --dry-run: Preview the IDs without modifying files
+//
+// Returns:
+//   - *cobra.Command: Configured migrate subcommand
+func migrateCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Assign stable IDs to existing tasks",
+		Long: `Assign a stable #id: tag to every task in TASKS.md that has none.
+
+Tasks added with "ctx add task" get an ID automatically. Run this once
+to give IDs to tasks written before IDs existed, or by hand. The tag is
+appended to the task's checkbox line:
+
+  - [ ] Implement user authentication #added:2026-01-15-120000 #id:k3f9
+
+Tasks that already have an ID keep it, so running migrate again is
+harmless. Subtasks and completed tasks get IDs too.
+
+Use --dry-run to preview the IDs without modifying files.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskMigrate(cmd, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"Preview changes without modifying files",
+	)
+
+	return cmd
+}
+
+// graphCmd returns the tasks graph subcommand.
+//
+// The graph command renders the "#depends:" and "#blocks:" relations
+// between tasks as Graphviz DOT or a Mermaid flowchart on stdout.
+// Cycles and references to unknown IDs are reported on stderr.
+//
+// Flags:
+//   - --format: Output format, "dot" (default) or "mermaid"
+//   - --all: Include tasks without dependencies
+//
+// Returns:
+//   - *cobra.Command: Configured graph subcommand
+func graphCmd() *cobra.Command {
+	var (
+		format string
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Render task dependencies as DOT or Mermaid",
+		Long: `Render the dependency graph of TASKS.md.
+
+Dependencies are declared with tags that name task IDs:
+
+  - [ ] Add login form #id:a1b2 #depends:k3f9
+  - [ ] Set up auth service #id:k3f9 #blocks:a1b2,q7r2
+
+Both tags mean "k3f9 must be done before a1b2"; an edge is drawn from
+the prerequisite to the dependent task. Completed tasks are shaded and
+IDs found only in .context/archive/ are drawn dashed.
+
+Dependency cycles and IDs that match no task are reported on stderr,
+so the graph can be piped straight into a renderer:
+
+  ctx tasks graph | dot -Tsvg > tasks.svg
+  ctx tasks graph --format mermaid >> docs/plan.md`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskGraph(cmd, format, all)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&format,
+		"format",
+		config.FormatDOT,
+		"Output format: dot or mermaid",
+	)
+	cmd.Flags().BoolVar(
+		&all,
+		"all",
+		false,
+		"Include tasks without dependencies",
+	)
+
+	return cmd
+}
+
+// nextCmd returns the tasks next subcommand.
+//
+// The next command ranks the pending tasks in TASKS.md and prints the
+// best one with the reasons for its rank. The ranking is deterministic,
+// so scripts and autonomous loops pick the same task for the same file.
+//
+// Flags:
+//   - --n: Number of tasks to print
+//   - --json: Output machine-readable JSON
+//
+// Returns:
+//   - *cobra.Command: Configured next subcommand
+func nextCmd() *cobra.Command {
+	var (
+		n      int
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Pick the task to work on next",
+		Long: `Rank the pending tasks in TASKS.md and print the one to work on next.
+
+Tasks are compared by, in order:
+
+  1. Readiness: ready tasks first, then tasks waiting on open or
+     unknown #depends: tasks, then #blocked tasks and the Blocked
+     section
+  2. Priority: #priority:high, medium, low, then none (a task without
+     a priority takes its phase heading's)
+  3. In progress: #in-progress tasks before new ones
+  4. Phase: earlier phases first
+  5. Age: older #added timestamps first
+  6. Position in the file
+
+The same file always gives the same answer. Use --n to see the runners
+up and --json for scripts.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskNext(cmd, n, asJSON)
+		},
+	}
+
+	cmd.Flags().IntVarP(&n, "n", "n", 1, "Number of tasks to show")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output machine-readable JSON")
+
+	return cmd
+}
+
+// listCmd returns the tasks list subcommand.
+//
+// The list command prints the tasks in TASKS.md that match every given
+// filter, with subtasks under their parents.
+//
+// Arguments:
+//   - [text]: Optional text the task description must contain
+//
+// Flags:
+//   - --label: Label or key:value tag the task must carry (repeatable)
+//   - --status: pending, in-progress, done or skipped
+//   - --phase: Text the phase heading must contain
+//   - --added-since: Date (YYYY-MM-DD) or age (30d, 2w)
+//   - --source: Value of the task's #source: tag
+//   - --format: table (default), json or md
+//   - --json: Shorthand for --format json
+//
+// Returns:
+//   - *cobra.Command: Configured list subcommand
+func listCmd() *cobra.Command {
+	var (
+		f          listFilter
+		addedSince string
+		format     string
+		asJSON     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [text]",
+		Short: "List tasks, with filters",
+		Long: `List the tasks in TASKS.md that match every given filter.
+
+Subtasks are listed under their parents. When only a subtask matches,
+its parent is shown too (dimmed, and marked "context" in JSON).
+
+Filters:
+  --label priority:high   Tag with that value (#priority:high)
+  --label in-progress     Label or tag of any value (repeatable; all must match)
+  --status pending        pending, in-progress, done or skipped
+                          (pending includes in-progress)
+  --phase "Phase 2"       Phase heading contains the text
+  --added-since 2w        #added on or after a date (YYYY-MM-DD) or age (30d, 2w)
+  --source report-7       #source:report-7
+  [text]                  Description contains the text
+
+Output is a table by default; use --json or --format md for a
+TASKS.md-style checklist.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				f.text = args[0]
+			}
+			if asJSON {
+				format = config.FormatJSON
+			}
+			return runTaskList(cmd, f, addedSince, format)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(
+		&f.labels, "label", nil,
+		"Label or key:value tag the task must carry (repeatable)",
+	)
+	cmd.Flags().StringVar(
+		&f.status, "status", "",
+		"Task status: pending, in-progress, done or skipped",
+	)
+	cmd.Flags().StringVar(
+		&f.phase, "phase", "", "Text the task's phase heading must contain",
+	)
+	cmd.Flags().StringVar(
+		&addedSince, "added-since", "",
+		"Only tasks added since a date (YYYY-MM-DD) or age (30d, 2w)",
+	)
+	cmd.Flags().StringVar(
+		&f.source, "source", "", "Value of the task's #source: tag",
+	)
+	cmd.Flags().StringVar(
+		&format, "format", config.FormatTable, "Output format: table, json or md",
+	)
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output machine-readable JSON")
+
+	return cmd
+}
+
+// startCmd returns the tasks start subcommand.
+//
+// The start command labels a task "#in-progress" and stamps it with
+// "#started:", the time cycle time is measured from.
+//
+// Arguments:
+//   - id: Task ID
+//
+// Returns:
+//   - *cobra.Command: Configured start subcommand
+func startCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start <id>",
+		Short: "Mark a task #in-progress with a #started timestamp",
+		Long: `Mark a task as being worked on.
+
+The task gets an #in-progress label and a #started: timestamp on its
+checkbox line, and loses #blocked and its "- Blocked:" note if it had
+them. The task is not moved:
+
+  - [ ] Add login form #id:a1b2 #in-progress #started:2026-01-15-143000
+
+A task that was started before keeps its first #started: time, so
+"ctx tasks report" measures the whole cycle time.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskStart(cmd, args[0])
+		},
+	}
+
+	return cmd
+}
+
+// blockCmd returns the tasks block subcommand.
+//
+// The block command labels a task "#blocked" and writes the reason
+// under it.
+//
+// Arguments:
+//   - id: Task ID
+//
+// Flags:
+//   - --reason: Why the task is blocked (required)
+//
+// Returns:
+//   - *cobra.Command: Configured block subcommand
+func blockCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "block <id> --reason <text>",
+		Short: "Mark a task #blocked, with a reason",
+		Long: `Mark a task as blocked and record why.
+
+The task stays in its phase (tasks are never moved). #in-progress is
+replaced by #blocked and the reason is added as a note under the task:
+
+  - [ ] Add login form #id:a1b2 #started:2026-01-15-143000 #blocked
+    - Blocked: waiting for the OAuth client ID
+
+Blocked tasks rank last in "ctx tasks next". Run "ctx tasks start" to
+unblock the task and pick it up again.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskBlock(cmd, args[0], reason)
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the task is blocked")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+// reportCmd returns the tasks report subcommand.
+//
+// The report command computes task flow metrics from TASKS.md and the
+// archived task files in .context/archive/.
+//
+// Flags:
+//   - --since: Start of the period, as a date or an age (default 30d)
+//   - --stale-days: Days in progress after which a task is stale
+//   - --json: Output machine-readable JSON
+//
+// Returns:
+//   - *cobra.Command: Configured report subcommand
+func reportCmd() *cobra.Command {
+	var (
+		since  string
+		stale  int
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report lead time, cycle time and throughput",
+		Long: `Report how tasks flowed through TASKS.md over a period.
+
+Tasks are read from TASKS.md and from the archived task files in
+.context/archive/, so archiving does not lose history. For tasks
+completed (#done:) in the period:
+
+  Lead time    #added to #done: how long work waited and took
+  Cycle time   #started to #done: how long work took once begun
+  Throughput   Tasks completed per week (weeks start on Monday)
+
+Tasks without the timestamps a metric needs are left out of it. The
+report also lists pending tasks that have been in progress (#started:)
+for longer than --stale-days.
+
+Examples:
+  ctx tasks report
+  ctx tasks report --since 2w
+  ctx tasks report --since 2026-01-01 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskReport(cmd, since, stale, asJSON)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&since, "since", "30d",
+		"Start of the period: a date (YYYY-MM-DD) or age (30d, 2w)",
+	)
+	cmd.Flags().IntVar(
+		&stale, "stale-days", staleDays,
+		"Days in progress after which a task is stale",
+	)
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output machine-readable JSON")
+
+	return cmd
+}
+
+// scanCmd returns the tasks scan subcommand.
+//
+// The scan command finds TODO, FIXME and HACK comments in the project's
+// source files and proposes a task for each one that TASKS.md does not
+// track yet.
+//
+// Flags:
+//   - --apply: Add the proposed tasks to TASKS.md
+//   - --section: Section to add the tasks under
+//
+// Returns:
+//   - *cobra.Command: Configured scan subcommand
+func scanCmd() *cobra.Command {
+	var (
+		apply   bool
+		section string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Propose tasks for TODO/FIXME/HACK comments in the code",
+		Long: `Find TODO, FIXME and HACK comments in source code and sync them
+into TASKS.md.
+
+The project is walked the way git sees it: files excluded by .gitignore
+are skipped (without git, the root .gitignore is applied). A marker
+counts only when it opens a comment: right after "//" or "/*" in Go,
+after "#" in shell and YAML. Each comment becomes a pending task that
+points back at the code:
+
+  - [ ] TODO: handle expired tokens ` + "`internal/auth/token.go:42`" + ` #source:code
+
+Comments that already have a task (same text and file; the line number
+may change) are not proposed again. Pending #source:code tasks whose
+comment has disappeared are listed, so they can be completed or skipped.
+
+Without --apply nothing is written. With --apply the tasks are added
+the way "ctx add task" adds them, with #added and #id tags.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskScan(cmd, apply, section)
+		},
+	}
+
+	cmd.Flags().BoolVar(&apply, "apply", false, "Add the proposed tasks to TASKS.md")
+	cmd.Flags().StringVarP(
+		&section, "section", "s", "",
+		"Section to add the tasks under (default: before the first pending task)",
+	)
+
+	return cmd
+}
@@ -8,10 +8,12 @@ package task
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ActiveMemory/ctx/internal/cli/add"
 	"github.com/ActiveMemory/ctx/internal/cli/initialize"
@@ -25,6 +27,7 @@ func TestSeparateTasks(t *testing.T) {
 		name              string
 		input             string
 		expectedCompleted int
+		expectedSkipped   int
 		expectedPending   int
 	}{
 		{
@@ -33,6 +36,12 @@ func TestSeparateTasks(t *testing.T) {
 			expectedCompleted: 1,
 			expectedPending:   1,
 		},
+		{
+			name:            "skipped tasks",
+			input:           "# Tasks\n\n- [-] Dropped task\n- [ ] Pending task\n",
+			expectedSkipped: 1,
+			expectedPending: 1,
+		},
 		{
 			name:              "all completed",
 			input:             "# Tasks\n\n- [x] Task 1\n- [x] Task 2\n",
@@ -59,6 +68,9 @@ func TestSeparateTasks(t *testing.T) {
 			if stats.completed != tt.expectedCompleted {
 				t.Errorf("separateTasks() completed = %d, want %d", stats.completed, tt.expectedCompleted)
 			}
+			if stats.skipped != tt.expectedSkipped {
+				t.Errorf("separateTasks() skipped = %d, want %d", stats.skipped, tt.expectedSkipped)
+			}
 			if stats.pending != tt.expectedPending {
 				t.Errorf("separateTasks() pending = %d, want %d", stats.pending, tt.expectedPending)
 			}
@@ -455,6 +467,7 @@ func TestArchiveCommand_WithCompletedTasks(t *testing.T) {
 - [x] Completed task 1
 - [ ] Pending task 1
 - [x] Completed task 2
+- [-] Skipped task 1
 `
 	tasksPath := filepath.Join(config.DirContext, config.FileTask)
 	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
@@ -480,6 +493,13 @@ func TestArchiveCommand_WithCompletedTasks(t *testing.T) {
 	if strings.Contains(string(data), "Completed task 1") {
 		t.Error("completed task 1 should be removed from TASKS.md")
 	}
+	if strings.Contains(string(data), "Skipped task 1") {
+		t.Error("skipped task 1 should be archived with completed tasks")
+	}
+	if !strings.Contains(out, "Archived 3 completed tasks") ||
+		!strings.Contains(out, "1 pending tasks remain") {
+		t.Errorf("output = %q, want 3 archived and 1 pending", out)
+	}
 	if !strings.Contains(string(data), "Pending task 1") {
 		t.Error("pending task 1 should remain in TASKS.md")
 	}
@@ -535,6 +555,23 @@ func TestCmd_HasSubcommands(t *testing.T) {
 	if !names["snapshot"] {
 		t.Error("missing snapshot subcommand")
 	}
+	if !names["migrate"] {
+		t.Error("missing migrate subcommand")
+	}
+	if !names["graph"] {
+		t.Error("missing graph subcommand")
+	}
+	if !names["next"] {
+		t.Error("missing next subcommand")
+	}
+	if !names["list"] {
+		t.Error("missing list subcommand")
+	}
+	for _, name := range []string{"start", "block", "report"} {
+		if !names[name] {
+			t.Errorf("missing %s subcommand", name)
+		}
+	}
 }
 
 func TestArchiveCommand_DryRunFlag(t *testing.T) {
@@ -598,3 +635,697 @@ func TestSnapshotCommand_SnapshotContentFormat(t *testing.T) {
 	}
 	t.Error("snapshot file not found")
 }
+
+func TestMigrateCommand(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksContent := `# Tasks
+
+## Next Up
+
+- [ ] Pending task
+  - [ ] Subtask
+- [x] Done task #id:k3f9
+`
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("migrate", "--dry-run")
+	if err != nil {
+		t.Fatalf("migrate --dry-run error: %v", err)
+	}
+	if !strings.Contains(out, "Would assign IDs to 2 tasks") {
+		t.Errorf("dry-run output = %q", out)
+	}
+	data, _ := os.ReadFile(tasksPath) //nolint:gosec // test temp path
+	if string(data) != tasksContent {
+		t.Error("dry run should not modify TASKS.md")
+	}
+
+	out, err = runTaskCmd("migrate")
+	if err != nil {
+		t.Fatalf("migrate error: %v", err)
+	}
+	if !strings.Contains(out, "Assigned IDs to 2 tasks") {
+		t.Errorf("output = %q", out)
+	}
+	data, _ = os.ReadFile(tasksPath) //nolint:gosec // test temp path
+	if strings.Count(string(data), "#id:") != 3 {
+		t.Errorf("every task should have one ID:\n%s", data)
+	}
+	if !strings.Contains(string(data), "- [x] Done task #id:k3f9\n") {
+		t.Errorf("existing ID should be kept:\n%s", data)
+	}
+
+	out, err = runTaskCmd("migrate")
+	if err != nil {
+		t.Fatalf("second migrate error: %v", err)
+	}
+	if !strings.Contains(out, "All tasks already have IDs") {
+		t.Errorf("second run output = %q", out)
+	}
+}
+
+func TestGraphCommand(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksContent := `# Tasks
+
+- [x] Set up "auth" service #id:k3f9
+- [ ] Add login form #id:a1b2 #depends:k3f9
+- [ ] Add logout #id:q7r2 #depends:a1b2,old1
+- [ ] Unrelated #id:u0u0
+`
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("graph")
+	if err != nil {
+		t.Fatalf("graph error: %v", err)
+	}
+	for _, want := range []string{
+		"digraph tasks {",
+		`"k3f9" [label="k3f9: Set up \"auth\" service", style=filled`,
+		`"k3f9" -> "a1b2";`,
+		`"old1" [label="old1 (missing)", style=dashed];`,
+		"#depends:old1 names an unknown task",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dot output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "u0u0") {
+		t.Errorf("task without dependencies should be left out:\n%s", out)
+	}
+
+	archiveDir := filepath.Join(config.DirContext, config.DirArchive)
+	if err = os.MkdirAll(archiveDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(
+		filepath.Join(archiveDir, "tasks-2026-01-10.md"),
+		[]byte("- [x] Old task #id:old1\n"), 0600,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = runTaskCmd("graph", "--format", "mermaid", "--all")
+	if err != nil {
+		t.Fatalf("graph --format mermaid error: %v", err)
+	}
+	for _, want := range []string{
+		"graph LR",
+		`k3f9["k3f9: Set up #quot;auth#quot; service"]:::done`,
+		"a1b2 --> q7r2",
+		`old1["old1 (archived)"]:::missing`,
+		`u0u0["u0u0: Unrelated"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "unknown task") {
+		t.Errorf("archived ID should not be reported:\n%s", out)
+	}
+
+	if _, err = runTaskCmd("graph", "--format", "svg"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestGraphCommand_Cycle(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksContent := "# Tasks\n\n" +
+		"- [ ] A #id:aaaa #depends:bbbb\n" +
+		"- [ ] B #id:bbbb #depends:aaaa\n"
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("graph")
+	if err != nil {
+		t.Fatalf("graph error: %v", err)
+	}
+	if !strings.Contains(out, "dependency cycle between aaaa, bbbb") {
+		t.Errorf("expected cycle warning:\n%s", out)
+	}
+}
+
+func TestNextCommand(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksContent := `# Tasks
+
+### Phase 1
+- [ ] Write docs #id:d0c5
+- [ ] Fix login #id:k3f9 #priority:high
+- [ ] Ship release #id:r3l5 #priority:high #depends:k3f9
+`
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("next")
+	if err != nil {
+		t.Fatalf("next error: %v", err)
+	}
+	if !strings.Contains(out, "Next: k3f9  Fix login") ||
+		!strings.Contains(out, "  - priority high") {
+		t.Errorf("output = %q", out)
+	}
+	if strings.Contains(out, "Write docs") {
+		t.Errorf("only the winner should be printed by default:\n%s", out)
+	}
+
+	out, err = runTaskCmd("next", "--n", "3", "--json")
+	if err != nil {
+		t.Fatalf("next --json error: %v", err)
+	}
+	var ranked []nextTask
+	if jsonErr := json.Unmarshal([]byte(out), &ranked); jsonErr != nil {
+		t.Fatalf("invalid JSON: %v\n%s", jsonErr, out)
+	}
+	if len(ranked) != 3 || ranked[0].ID != "k3f9" || ranked[1].ID != "d0c5" {
+		t.Fatalf("ranked = %+v", ranked)
+	}
+	if last := ranked[2]; last.Ready ||
+		len(last.WaitingOn) != 1 || last.WaitingOn[0] != "k3f9" {
+		t.Errorf("release should wait on k3f9: %+v", last)
+	}
+
+	if _, err = runTaskCmd("next", "--n", "0"); err == nil {
+		t.Error("expected error for --n 0")
+	}
+}
+
+func TestNextCommand_NoPendingTasks(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(
+		tasksPath, []byte("# Tasks\n\n- [x] Done #id:d0n3\n"), 0600,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("next")
+	if err != nil {
+		t.Fatalf("next error: %v", err)
+	}
+	if !strings.Contains(out, "No pending tasks.") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+const listTasksContent = `# Tasks
+
+### Phase 1: Setup
+- [x] Repo #id:aaaa #done:2026-01-03
+- [ ] CI pipeline #id:bbbb #added:2026-01-10-100000 #source:report-7
+  - [ ] Cache modules #id:b1b1 #priority:high
+  - [x] Add lint job #id:b2b2
+- [-] Old idea #id:cccc
+
+### Phase 2: Features
+- [ ] Login #id:dddd #priority:high #in-progress
+`
+
+func TestListCommand_Filters(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(listTasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// ids flattens a JSON listing into "id" or "(id)" for context parents
+	var ids func(listed []*listedTask) []string
+	ids = func(listed []*listedTask) []string {
+		var out []string
+		for _, lt := range listed {
+			if lt.Context {
+				out = append(out, "("+lt.ID+")")
+			} else {
+				out = append(out, lt.ID)
+			}
+			out = append(out, ids(lt.Subtasks)...)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"all", nil, "aaaa bbbb b1b1 b2b2 cccc dddd"},
+		{"label with value", []string{"--label", "priority:high"}, "(bbbb) b1b1 dddd"},
+		{"bare label", []string{"--label", "#in-progress"}, "dddd"},
+		{"pending includes in-progress", []string{"--status", "pending"}, "bbbb b1b1 dddd"},
+		{"in-progress", []string{"--status", "in-progress"}, "dddd"},
+		{"done", []string{"--status", "done"}, "aaaa (bbbb) b2b2"},
+		{"skipped", []string{"--status", "skipped"}, "cccc"},
+		{"phase", []string{"--phase", "features"}, "dddd"},
+		{"added since", []string{"--added-since", "2026-01-05"}, "bbbb"},
+		{"source", []string{"--source", "report-7"}, "bbbb"},
+		{"text", []string{"lint"}, "(bbbb) b2b2"},
+		{"combined", []string{"--status", "pending", "--phase", "setup", "cache"}, "(bbbb) b1b1"},
+		{"no match", []string{"nothing like this"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runTaskCmd(append([]string{"list", "--json"}, tt.args...)...)
+			if err != nil {
+				t.Fatalf("list error: %v", err)
+			}
+			var listed []*listedTask
+			if jsonErr := json.Unmarshal([]byte(out), &listed); jsonErr != nil {
+				t.Fatalf("invalid JSON: %v\n%s", jsonErr, out)
+			}
+			if got := strings.Join(ids(listed), " "); got != tt.want {
+				t.Errorf("ids = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListCommand_Formats(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(listTasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("list", "--status", "pending")
+	if err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	for _, want := range []string{
+		"ID    Status       Priority  Phase              Task",
+		"bbbb  pending                Phase 1: Setup     CI pipeline",
+		"b1b1  pending      high      Phase 1: Setup       Cache modules",
+		"3 tasks",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runTaskCmd("list", "--format", "md", "--label", "priority:high")
+	if err != nil {
+		t.Fatalf("list --format md error: %v", err)
+	}
+	want := "### Phase 1: Setup\n\n" +
+		"- [ ] CI pipeline #id:bbbb #added:2026-01-10-100000 #source:report-7\n" +
+		"  - [ ] Cache modules #id:b1b1 #priority:high\n" +
+		"\n### Phase 2: Features\n\n" +
+		"- [ ] Login #id:dddd #priority:high #in-progress\n"
+	if out != want {
+		t.Errorf("markdown = %q, want %q", out, want)
+	}
+
+	for _, args := range [][]string{
+		{"list", "--status", "blocked"},
+		{"list", "--format", "xml"},
+		{"list", "--added-since", "last week"},
+	} {
+		if _, err = runTaskCmd(args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-01-15", time.Date(2026, 1, 15, 0, 0, 0, 0, time.Local)},
+		{"30d", now.AddDate(0, 0, -30)},
+		{"2w", now.AddDate(0, 0, -14)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "d", "-3d", "3m", "soon"} {
+		if _, err := parseSince(bad, now); err == nil {
+			t.Errorf("parseSince(%q) should fail", bad)
+		}
+	}
+}
+
+func TestStartAndBlockCommands(t *testing.T) {
+	setupTaskDir(t)
+
+	tasksContent := `# Tasks
+
+### Phase 1
+- [ ] Fix login #id:k3f9 #blocked
+  - [ ] Write test #id:t3st
+- [x] Set up repo #id:r3p0
+`
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+	readTasks := func() string {
+		data, err := os.ReadFile(tasksPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	out, err := runTaskCmd("start", "#k3f9")
+	if err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if !strings.Contains(out, "Started: Fix login") {
+		t.Errorf("output = %q", out)
+	}
+	content := readTasks()
+	if !strings.Contains(content, "- [ ] Fix login #id:k3f9 #in-progress #started:") {
+		t.Errorf("start should replace #blocked with #in-progress and #started:\n%s", content)
+	}
+
+	out, err = runTaskCmd("start", "k3f9")
+	if err != nil {
+		t.Fatalf("second start error: %v", err)
+	}
+	if !strings.Contains(out, "Already in progress") || readTasks() != content {
+		t.Errorf("starting twice should change nothing: %q", out)
+	}
+
+	if _, err = runTaskCmd("block", "t3st", "--reason", "needs  fixture\ndata"); err != nil {
+		t.Fatalf("block error: %v", err)
+	}
+	want := "  - [ ] Write test #id:t3st #blocked\n" +
+		"    - Blocked: needs fixture data\n"
+	if content = readTasks(); !strings.Contains(content, want) {
+		t.Errorf("TASKS.md missing %q:\n%s", want, content)
+	}
+
+	if _, err = runTaskCmd("block", "k3f9", "--reason", "API down"); err != nil {
+		t.Fatalf("block error: %v", err)
+	}
+	content = readTasks()
+	if strings.Contains(content, "#in-progress") ||
+		!strings.Contains(content, "#started:") {
+		t.Errorf("block should drop #in-progress and keep #started:\n%s", content)
+	}
+
+	// Starting a blocked task drops its block note, not its subtask's
+	if _, err = runTaskCmd("start", "k3f9"); err != nil {
+		t.Fatalf("restart error: %v", err)
+	}
+	content = readTasks()
+	if strings.Contains(content, "API down") || strings.Contains(content, "#blocked #") ||
+		!strings.Contains(content, "Blocked: needs fixture data") {
+		t.Errorf("start should remove only the task's block note:\n%s", content)
+	}
+
+	// The note goes after a wrapped description, not inside it
+	wrapped := "# Tasks\n\n" +
+		"- [ ] Fix login #id:k3f9 #in-progress\n" +
+		"  for the legacy adapter\n" +
+		"  - [ ] Write test #id:t3st\n"
+	if err = os.WriteFile(tasksPath, []byte(wrapped), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = runTaskCmd("block", "k3f9", "--reason", "API down"); err != nil {
+		t.Fatalf("block error: %v", err)
+	}
+	want = "  for the legacy adapter\n" +
+		"  - Blocked: API down\n" +
+		"  - [ ] Write test"
+	if content = readTasks(); !strings.Contains(content, want) {
+		t.Errorf("TASKS.md missing %q:\n%s", want, content)
+	}
+	out, err = runTaskCmd("list", "--json")
+	if err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if !strings.Contains(out, "Fix login for the legacy adapter") {
+		t.Errorf("blocking should keep the full title:\n%s", out)
+	}
+
+	for _, args := range [][]string{
+		{"start", "r3p0"},
+		{"start", "zzzz"},
+		{"block", "k3f9"},
+		{"block", "k3f9", "--reason", " "},
+	} {
+		if _, err = runTaskCmd(args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestReportCommand(t *testing.T) {
+	setupTaskDir(t)
+
+	now := time.Now()
+	stamp := func(days int) string {
+		return now.AddDate(0, 0, -days).Format("2006-01-02-150405")
+	}
+	tasksContent := "# Tasks\n\n" +
+		"- [ ] Stuck #id:s7uk #in-progress #started:" + stamp(10) + "\n" +
+		"- [ ] Fresh #id:fr5h #in-progress #started:" + stamp(1) + "\n" +
+		"- [x] Recent #id:r3c1 #added:" + stamp(6) +
+		" #started:" + stamp(4) + " #done:" + stamp(2) + "\n"
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	if err := os.WriteFile(tasksPath, []byte(tasksContent), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	archiveDir := filepath.Join(config.DirContext, config.DirArchive)
+	if err := os.MkdirAll(archiveDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	archived := "# Archived Tasks\n\n" +
+		"- [x] Archived #id:arc1 #added:" + stamp(12) + " #done:" + stamp(8) + "\n" +
+		"- [x] Ancient #id:anc1 #added:" + stamp(90) + " #done:" + stamp(60) + "\n" +
+		"- [x] Recent copy #id:r3c1 #done:" + stamp(2) + "\n"
+	if err := os.WriteFile(
+		filepath.Join(archiveDir, "tasks-2026-01-10.md"), []byte(archived), 0600,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runTaskCmd("report", "--json")
+	if err != nil {
+		t.Fatalf("report error: %v", err)
+	}
+	var r taskReport
+	if jsonErr := json.Unmarshal([]byte(out), &r); jsonErr != nil {
+		t.Fatalf("invalid JSON: %v\n%s", jsonErr, out)
+	}
+	if r.Completed != 2 {
+		t.Errorf("completed = %d, want 2 (archived, deduplicated)", r.Completed)
+	}
+	if r.LeadTime.Count != 2 || r.LeadTime.MaxHours != 96 {
+		t.Errorf("lead time = %+v, want 2 tasks, max 96h", r.LeadTime)
+	}
+	if r.CycleTime.Count != 1 || r.CycleTime.MedianHours != 48 {
+		t.Errorf("cycle time = %+v, want 1 task, 48h", r.CycleTime)
+	}
+	done := 0
+	for _, w := range r.Throughput {
+		done += w.Done
+	}
+	if len(r.Throughput) < 5 || done != 2 {
+		t.Errorf("throughput = %+v", r.Throughput)
+	}
+	if len(r.Stale) != 1 || r.Stale[0].ID != "s7uk" || r.Stale[0].Days != 10 {
+		t.Errorf("stale = %+v, want [s7uk]", r.Stale)
+	}
+
+	out, err = runTaskCmd("report", "--since", "1w", "--stale-days", "14")
+	if err != nil {
+		t.Fatalf("report error: %v", err)
+	}
+	for _, want := range []string{
+		"Completed:   1 tasks",
+		"Cycle time:  median 2d, mean 2d, max 2d (1 tasks)",
+		"No task has been in progress for more than 14 days.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"report", "--since", "soon"},
+		{"report", "--stale-days", "0"},
+	} {
+		if _, err = runTaskCmd(args...); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+// codeMarkers spells out comment markers at runtime, so that scanning
+// this repository does not turn the test fixtures into tasks.
+var codeMarkers = strings.NewReplacer("<T>", "TODO", "<F>", "FIXME", "<H>", "HACK")
+
+func TestParseComment(t *testing.T) {
+	goPrefixes := []string{"//", "/*", "*"}
+	tests := []struct {
+		line   string
+		marker string
+		text   string
+	}{
+		{"// <T>: handle nil config", "TODO", "handle nil config"},
+		{"\tx := 1 // <F>(ana):  slow  path", "FIXME", "slow path"},
+		{"/* <H> work around bug */", "HACK", "work around bug"},
+		{" * <T>", "TODO", ""},
+		{`s := "mentions <T> in a string"`, "", ""},
+		{"// the <T> list is long", "", ""},
+		{"// <T>S are not markers", "", ""},
+	}
+	for _, tt := range tests {
+		line := codeMarkers.Replace(tt.line)
+		c, ok := parseComment(line, goPrefixes)
+		if ok != (tt.marker != "") {
+			t.Errorf("parseComment(%q) ok = %v", line, ok)
+			continue
+		}
+		if c.Marker != tt.marker || c.Text != tt.text {
+			t.Errorf("parseComment(%q) = %q %q, want %q %q",
+				line, c.Marker, c.Text, tt.marker, tt.text)
+		}
+	}
+
+	if _, ok := parseComment(codeMarkers.Replace("# <T>: step"), []string{"#"}); !ok {
+		t.Error("shell comment not found")
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	dir := t.TempDir()
+	gitignore := filepath.Join(dir, ".gitignore")
+	content := "# build output\nvendor/\n/dist\n*.gen.go\n!keep.gen.go\ndocs/api/\n"
+	if err := os.WriteFile(gitignore, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules := readGitignore(gitignore)
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"vendor", true, true},
+		{"pkg/vendor", true, true},
+		{"vendor", false, false},
+		{"dist", true, true},
+		{"pkg/dist", true, false},
+		{"pkg/model.gen.go", false, true},
+		{"pkg/keep.gen.go", false, false},
+		{"docs/api", true, true},
+		{"pkg/docs/api", true, false},
+		{"pkg/main.go", false, false},
+	}
+	for _, tt := range tests {
+		if got := ignored(rules, tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, %v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestScanCommand(t *testing.T) {
+	dir := setupTaskDir(t)
+
+	files := map[string]string{
+		"pkg/auth.go":   "package pkg\n\n// <T>: handle expired tokens\nfunc F() {} // <F>: slow\n",
+		"setup.sh":      "#!/bin/sh\n# <H> pin the version\n",
+		"vendor/lib.go": "// <T>: vendored code\n",
+		"notes.md":      "<T>: not source code\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(codeMarkers.Replace(content)), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.OpenFile(filepath.Join(dir, ".gitignore"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("\nvendor/\n")
+	_ = f.Close()
+
+	tasksPath := filepath.Join(config.DirContext, config.FileTask)
+	before, _ := os.ReadFile(tasksPath)
+
+	out, err := runTaskCmd("scan")
+	if err != nil {
+		t.Fatalf("scan error: %v", err)
+	}
+	for _, want := range []string{
+		"Found 3 TODO/FIXME/HACK comments (0 already tracked)",
+		"+ TODO: handle expired tokens `pkg/auth.go:3`",
+		"+ FIXME: slow `pkg/auth.go:4`",
+		"+ HACK: pin the version `setup.sh:2`",
+		"Run with --apply",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("scan output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "vendored") || strings.Contains(out, "not source") {
+		t.Errorf("ignored and non-source files should be skipped:\n%s", out)
+	}
+	if after, _ := os.ReadFile(tasksPath); string(after) != string(before) {
+		t.Error("scan without --apply should not modify TASKS.md")
+	}
+
+	if _, err = runTaskCmd("scan", "--apply"); err != nil {
+		t.Fatalf("scan --apply error: %v", err)
+	}
+	data, _ := os.ReadFile(tasksPath)
+	content := string(data)
+	first := strings.Index(content, "TODO: handle expired tokens `pkg/auth.go:3` #source:code #added:")
+	second := strings.Index(content, "FIXME: slow `pkg/auth.go:4` #source:code")
+	if first < 0 || second < first || !strings.Contains(content, "#id:") {
+		t.Errorf("tasks not added in file order:\n%s", content)
+	}
+
+	// Move a comment and drop another: the moved one is still tracked
+	authPath := filepath.Join(dir, "pkg", "auth.go")
+	moved := "package pkg\n\n// Package doc.\n\n// <T>: handle expired tokens\nfunc F() {}\n"
+	if err = os.WriteFile(authPath, []byte(codeMarkers.Replace(moved)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runTaskCmd("scan", "--apply")
+	if err != nil {
+		t.Fatalf("second scan error: %v", err)
+	}
+	if !strings.Contains(out, "Found 2 TODO/FIXME/HACK comments (2 already tracked)") ||
+		!strings.Contains(out, "No new tasks.") {
+		t.Errorf("second scan should find nothing new:\n%s", out)
+	}
+	if !strings.Contains(out, "Source comment gone (1)") ||
+		!strings.Contains(out, "FIXME: slow `pkg/auth.go:4`") {
+		t.Errorf("removed comment should be flagged:\n%s", out)
+	}
+	if again, _ := os.ReadFile(tasksPath); string(again) != content {
+		t.Error("second scan should not add tasks")
+	}
+}
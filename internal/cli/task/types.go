@@ -6,15 +6,193 @@
 
 package task
 
-// taskStats holds counts of completed and pending tasks.
+import (
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/task"
+)
+
+// taskStats holds counts of completed, skipped and pending tasks.
 //
 // Used by separateTasks to report how many tasks were processed during
 // an archive operation.
 //
 // Fields:
 //   - completed: Number of tasks marked with [x]
+//   - skipped: Number of tasks marked with [-]
 //   - pending: Number of tasks marked with [ ]
 type taskStats struct {
 	completed int
+	skipped   int
 	pending   int
 }
+
+// nextTask is the JSON form of a task ranked by "ctx tasks next".
+//
+// Fields:
+//   - Rank: 1-based position in the ranking
+//   - ID: Task ID; empty for tasks without one
+//   - Title: Task description without tags
+//   - Line: 1-based line of the task in TASKS.md
+//   - Phase: Heading the task sits under
+//   - Priority: Effective priority (the task's, or its phase's)
+//   - InProgress: True if the task is labeled #in-progress
+//   - Blocked: True if the task is labeled #blocked or in Blocked
+//   - Ready: True if the task is not blocked and all its dependencies
+//     are done
+//   - WaitingOn: Keys of open dependencies, then unknown dependency IDs
+//   - Added: The task's #added date (YYYY-MM-DD), if any
+//   - Reasons: Human-readable ranking reasons
+type nextTask struct {
+	Rank       int      `json:"rank"`
+	ID         string   `json:"id,omitempty"`
+	Title      string   `json:"title"`
+	Line       int      `json:"line"`
+	Phase      string   `json:"phase,omitempty"`
+	Priority   string   `json:"priority,omitempty"`
+	InProgress bool     `json:"in_progress"`
+	Blocked    bool     `json:"blocked"`
+	Ready      bool     `json:"ready"`
+	WaitingOn  []string `json:"waiting_on,omitempty"`
+	Added      string   `json:"added,omitempty"`
+	Reasons    []string `json:"reasons"`
+}
+
+// listFilter selects tasks for "ctx tasks list".
+//
+// Empty fields do not filter.
+//
+// Fields:
+//   - labels: Labels or "key:value" tags the task must all carry
+//   - status: Required status (one of taskStatuses)
+//   - phase: Text the task's phase heading must contain
+//   - addedSince: Earliest #added time
+//   - source: Required #source: value
+//   - text: Text the task description must contain
+type listFilter struct {
+	labels     []string
+	status     string
+	phase      string
+	addedSince time.Time
+	source     string
+	text       string
+}
+
+// listedTask is a task in the output of "ctx tasks list".
+//
+// Fields:
+//   - ID: Task ID; empty for tasks without one
+//   - Title: Task description without tags
+//   - Status: One of the config.TaskStatus* values
+//   - Priority: The task's #priority: value
+//   - Phase: Heading the task sits under
+//   - Labels: Bare labels (e.g., "in-progress")
+//   - Added: The task's #added date (YYYY-MM-DD), if any
+//   - Completed: The task's #done date (YYYY-MM-DD), if any
+//   - Line: 1-based line of the task in TASKS.md
+//   - Context: True if the task did not match and is only listed as
+//     the parent of a matching subtask
+//   - Subtasks: Listed subtasks
+type listedTask struct {
+	ID        string        `json:"id,omitempty"`
+	Title     string        `json:"title"`
+	Status    string        `json:"status"`
+	Priority  string        `json:"priority,omitempty"`
+	Phase     string        `json:"phase,omitempty"`
+	Labels    []string      `json:"labels,omitempty"`
+	Added     string        `json:"added,omitempty"`
+	Completed string        `json:"completed,omitempty"`
+	Line      int           `json:"line"`
+	Context   bool          `json:"context,omitempty"`
+	Subtasks  []*listedTask `json:"subtasks,omitempty"`
+
+	task *task.Task
+}
+
+// taskReport is the JSON form of "ctx tasks report".
+//
+// Fields:
+//   - Since: Start of the period (YYYY-MM-DD)
+//   - Until: End of the period (YYYY-MM-DD)
+//   - Completed: Tasks completed in the period
+//   - LeadTime: #added to #done spans
+//   - CycleTime: #started to #done spans
+//   - Throughput: Completed tasks per week
+//   - StaleDays: Days in progress after which a task is stale
+//   - Stale: Tasks in progress for longer than StaleDays
+type taskReport struct {
+	Since      string       `json:"since"`
+	Until      string       `json:"until"`
+	Completed  int          `json:"completed"`
+	LeadTime   reportSpans  `json:"lead_time"`
+	CycleTime  reportSpans  `json:"cycle_time"`
+	Throughput []reportWeek `json:"throughput"`
+	StaleDays  int          `json:"stale_days"`
+	Stale      []staleTask  `json:"stale"`
+}
+
+// reportSpans is the JSON form of task.Durations.
+//
+// Fields:
+//   - Count: Tasks measured
+//   - MedianHours: Median span in hours
+//   - MeanHours: Mean span in hours
+//   - MaxHours: Longest span in hours
+type reportSpans struct {
+	Count       int     `json:"count"`
+	MedianHours float64 `json:"median_hours"`
+	MeanHours   float64 `json:"mean_hours"`
+	MaxHours    float64 `json:"max_hours"`
+}
+
+// reportWeek is the JSON form of task.Week.
+//
+// Fields:
+//   - Week: Monday the week starts on (YYYY-MM-DD)
+//   - Done: Tasks completed in the week
+type reportWeek struct {
+	Week string `json:"week"`
+	Done int    `json:"done"`
+}
+
+// staleTask is a stale in-progress task in "ctx tasks report" JSON.
+//
+// Fields:
+//   - ID: Task ID; empty for tasks without one
+//   - Title: Task description without tags
+//   - Started: The task's #started date (YYYY-MM-DD)
+//   - Days: Whole days since the task was started
+type staleTask struct {
+	ID      string `json:"id,omitempty"`
+	Title   string `json:"title"`
+	Started string `json:"started"`
+	Days    int    `json:"days"`
+}
+
+// codeComment is a TODO, FIXME or HACK comment found by "ctx tasks scan".
+//
+// Fields:
+//   - Path: Slash-separated file path relative to the project root
+//   - Line: 1-based line number
+//   - Marker: "TODO", "FIXME" or "HACK"
+//   - Text: Comment text after the marker, whitespace collapsed
+type codeComment struct {
+	Path   string
+	Line   int
+	Marker string
+	Text   string
+}
+
+// scanPlan is the outcome of comparing code comments with TASKS.md.
+//
+// Fields:
+//   - found: Comments found in the code
+//   - tracked: Comments that already have a task
+//   - add: Comments without a task, in file order
+//   - gone: Pending "#source:code" tasks whose comment no longer exists
+type scanPlan struct {
+	found   int
+	tracked int
+	add     []codeComment
+	gone    []*task.Task
+}
@@ -82,8 +82,8 @@ func runWatch(cmd *cobra.Command, _ []string) error {
 //     context, rationale, consequences for decisions)
 //
 // Returns:
-//   - error: Non-nil if validation fails, type is unknown,
-//     or file operations fail
+//   - error: Non-nil if validation fails, the entry looks like a
+//     duplicate, type is unknown, or file operations fail
 func runAddSilent(update ContextUpdate) error {
 	params := add.EntryParams{
 		Type:         update.Type,
@@ -100,6 +100,16 @@ func runAddSilent(update ContextUpdate) error {
 		return err
 	}
 
+	// Refuse credentials (same as ctx add)
+	if err := add.CheckSecrets(params); err != nil {
+		return err
+	}
+
+	// Refuse near-duplicates (same as ctx add without --force)
+	if err := add.CheckDuplicates(params); err != nil {
+		return err
+	}
+
 	// Write using the shared function
 	// (handles formatting, append, and index update)
 	return add.WriteEntry(params)
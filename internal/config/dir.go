@@ -16,6 +16,8 @@ const (
 	DirClaudeHooks = ".claude/hooks"
 	// DirContext is the default context directory name.
 	DirContext = ".context"
+	// DirGit marks a repository root; context layer discovery stops there.
+	DirGit = ".git"
 	// DirJournal is the subdirectory for journal entries within .context/.
 	DirJournal = "journal"
 	// DirTools is the subdirectory for tool scripts within .context/.
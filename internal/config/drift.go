@@ -0,0 +1,19 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// Drift check severities, as set under "drift:" in .ctxrc.
+const (
+	// DriftSeverityWarning reports a check's issues as warnings.
+	DriftSeverityWarning = "warning"
+	// DriftSeverityViolation reports a check's issues as violations,
+	// which make "ctx drift" fail.
+	DriftSeverityViolation = "violation"
+)
+
+// DriftSeverities lists the valid drift check severities.
+var DriftSeverities = []string{DriftSeverityWarning, DriftSeverityViolation}
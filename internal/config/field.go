@@ -15,6 +15,8 @@ package config
 const (
 	// FieldContext is the background/situation field for decisions and learnings.
 	FieldContext = "context"
+	// FieldDecision is the decision field of a decision entry.
+	FieldDecision = "decision"
 	// FieldRationale is the reasoning field for decisions (why this choice).
 	FieldRationale = "rationale"
 	// FieldConsequence is the outcomes field for decisions (what changes).
@@ -22,16 +22,29 @@ const (
 	ExtMarkdown = ".md"
 	// ExtJSONL is the JSON Lines file extension.
 	ExtJSONL = ".jsonl"
+	// ExtGo is the Go source file extension.
+	ExtGo = ".go"
 )
 
 // Common filenames.
 const (
+	// FileGitignore is the git ignore file in the project root.
+	FileGitignore = ".gitignore"
 	// FilenameReadme is the standard README filename.
 	FilenameReadme = "README.md"
 	// FilenameIndex is the standard index filename for generated sites.
 	FilenameIndex = "index.md"
+	// FileDriftBaseline lists the drift issues accepted by
+	// "ctx drift --update-baseline", in the context directory.
+	FileDriftBaseline = "drift-baseline.json"
+	// FileGoMod is the Go module file that marks a module's root.
+	FileGoMod = "go.mod"
 )
 
+// GoSkipDirs lists directories that hold no source of the Go module
+// around them. Hidden directories are skipped as well.
+var GoSkipDirs = []string{"vendor", "testdata", "node_modules"}
+
 // Journal site configuration.
 const (
 	// FileZensicalToml is the zensical site configuration filename.
@@ -59,6 +72,11 @@ const (
 	EnvCtxDir = "CTX_DIR"
 	// EnvCtxTokenBudget is the environment variable for overriding the token budget.
 	EnvCtxTokenBudget = "CTX_TOKEN_BUDGET" //nolint:gosec // G101: env var name, not a credential
+	// EnvVisual and EnvEditor name the user's editor, VISUAL first.
+	EnvVisual = "VISUAL"
+	EnvEditor = "EDITOR"
+	// DefaultEditor is the editor used when neither variable is set.
+	DefaultEditor = "vi"
 )
 
 // Parser configuration.
@@ -166,6 +184,9 @@ const (
 	FileScratchpadMd = "scratchpad.md"
 	// FileScratchpadKey is the scratchpad encryption key file.
 	FileScratchpadKey = ".scratchpad.key"
+	// FileSecretAllowlist lists patterns the secret scanner ignores, one
+	// regular expression per line.
+	FileSecretAllowlist = ".secrets-allow"
 )
 
 // FileType maps short names to actual file names.
@@ -297,3 +318,44 @@ var DefaultClaudeDenyPermissions = []string{
 	"Edit(**/.env)",
 	"Edit(**/.env.*)",
 }
+
+// CodeCommentPrefixes maps source file extensions, or whole file names
+// for files without one, to the tokens that start a comment in them.
+// "ctx tasks scan" only reads these files, and only counts a TODO
+// marker that directly follows one of the tokens.
+var CodeCommentPrefixes = map[string][]string{
+	".go":        {"//", "/*", "*"},
+	".c":         {"//", "/*", "*"},
+	".h":         {"//", "/*", "*"},
+	".cc":        {"//", "/*", "*"},
+	".cpp":       {"//", "/*", "*"},
+	".hpp":       {"//", "/*", "*"},
+	".cs":        {"//", "/*", "*"},
+	".java":      {"//", "/*", "*"},
+	".kt":        {"//", "/*", "*"},
+	".scala":     {"//", "/*", "*"},
+	".swift":     {"//", "/*", "*"},
+	".rs":        {"//", "/*", "*"},
+	".js":        {"//", "/*", "*"},
+	".jsx":       {"//", "/*", "*"},
+	".ts":        {"//", "/*", "*"},
+	".tsx":       {"//", "/*", "*"},
+	".php":       {"//", "/*", "*", "#"},
+	".css":       {"/*", "*"},
+	".scss":      {"//", "/*", "*"},
+	".py":        {"#"},
+	".rb":        {"#"},
+	".pl":        {"#"},
+	".sh":        {"#"},
+	".bash":      {"#"},
+	".zsh":       {"#"},
+	".yaml":      {"#"},
+	".yml":       {"#"},
+	".toml":      {"#"},
+	".mk":        {"#"},
+	".sql":       {"--"},
+	".lua":       {"--"},
+	".hs":        {"--"},
+	"Makefile":   {"#"},
+	"Dockerfile": {"#"},
+}
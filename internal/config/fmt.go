@@ -12,4 +12,10 @@ const (
 	FormatJSON = "json"
 	// FormatMarkdown selects Markdown output.
 	FormatMarkdown = "md"
+	// FormatTable selects aligned-column text output.
+	FormatTable = "table"
+	// FormatDOT selects Graphviz DOT output.
+	FormatDOT = "dot"
+	// FormatMermaid selects Mermaid flowchart output.
+	FormatMermaid = "mermaid"
 )
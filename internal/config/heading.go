@@ -26,8 +26,14 @@ const (
 	HeadingNextUp = "## Next Up"
 	// HeadingCompleted is the section heading for completed tasks.
 	HeadingCompleted = "## Completed"
+	// HeadingBlocked is the section heading for blocked tasks.
+	HeadingBlocked = "## Blocked"
 	// HeadingArchivedTasks is the heading for archived task files.
 	HeadingArchivedTasks = "# Archived Tasks"
+	// HeadingArchivedLearnings is the heading for archived learning files.
+	HeadingArchivedLearnings = "# Archived Learnings"
+	// HeadingArchivedDecisions is the heading for archived decision files.
+	HeadingArchivedDecisions = "# Archived Decisions"
 )
 
 // Decisions
@@ -36,6 +42,8 @@ const (
 	HeadingDecisions = "# Decisions"
 	// ColumnDecision is the singular column header for decision index tables.
 	ColumnDecision = "Decision"
+	// ColumnStatus is the column header for decision statuses.
+	ColumnStatus = "Status"
 )
 
 // Journal index headings
@@ -33,6 +33,51 @@ const (
 	InsightWordBoundaryMin = 100
 )
 
+// MaxScanFileSize is the largest file, in bytes, "ctx tasks scan" reads;
+// bigger files are generated or vendored, not hand-written.
+const MaxScanFileSize = 1 << 20
+
+// Secret scanner thresholds.
+//
+// Random base64 keys pass both high-entropy thresholds about 95% of the
+// time at 20 characters and 98% from 32 on; identifiers, paths and
+// prose fail at least one.
+const (
+	// SecretMinEntropyRatio is the Shannon entropy, as a share of the
+	// highest entropy possible at the string's length, above which a
+	// string may be a generated key.
+	SecretMinEntropyRatio = 0.83
+	// SecretMinClassChanges is the share of adjacent characters that
+	// switch between upper case, lower case and digits above which a
+	// string may be a generated key. CamelCase names and paths switch
+	// far less often.
+	SecretMinClassChanges = 0.4
+	// SecretPreviewLen is the number of characters of a secret shown in
+	// findings; the rest is masked.
+	SecretPreviewLen = 4
+)
+
+// MaxRenameHops is the number of successive renames "ctx drift --fix"
+// follows in git history to find where a dead path went.
+const MaxRenameHops = 10
+
+// MaxDuplicateMatches is the number of similar entries "ctx add" lists
+// when it refuses a near-duplicate.
+const MaxDuplicateMatches = 3
+
+// Clustering constants for "ctx consolidate".
+const (
+	// ConsolidateThreshold is the average similarity, from 0 to 1, at
+	// which entries are proposed as one group.
+	ConsolidateThreshold = 0.2
+	// ConsolidateRefBonus is added to the similarity of two entries for
+	// each path or symbol reference they share (up to two).
+	ConsolidateRefBonus = 0.1
+	// MaxConsolidateGroup is the largest group proposed; bigger topics
+	// need splitting, not merging.
+	MaxConsolidateGroup = 8
+)
+
 // BinaryVersion holds the ctx binary version, set by bootstrap at startup.
 // Defaults to "dev" when not set (e.g., during tests).
 var BinaryVersion = "dev"
@@ -14,6 +14,14 @@ const (
 	CommentClose = "-->"
 )
 
+// MarkerAllowSecret on a line tells the secret scanner that the line
+// holds no secret, e.g. a documented example key.
+const MarkerAllowSecret = "ctx:allow-secret"
+
+// PrivateKeyEnd starts the last line of a PEM private key block, as in
+// "-----END RSA PRIVATE KEY-----".
+const PrivateKeyEnd = "-----END "
+
 // Context block markers for embedding context in files.
 const (
 	// CtxMarkerStart marks the beginning of an embedded context block.
@@ -46,19 +54,143 @@ const (
 	IndexEnd = "<!-- INDEX:END -->"
 )
 
+// Packet markers on decision/learning headers and convention bullets.
+const (
+	// MarkerPin always includes an entry in context packets.
+	MarkerPin = "#pin"
+	// MarkerNoPacket keeps an entry out of context packets.
+	MarkerNoPacket = "#nopacket"
+)
+
 // Task checkbox prefixes for Markdown task lists.
 const (
 	// PrefixTaskUndone is the prefix for an unchecked task item.
 	PrefixTaskUndone = "- [ ]"
 	// PrefixTaskDone is the prefix for a checked (completed) task item.
 	PrefixTaskDone = "- [x]"
+	// PrefixTaskBlockedReason starts the note "ctx tasks block" writes
+	// under a task to say why it is blocked.
+	PrefixTaskBlockedReason = "Blocked: "
 )
 
 const (
-	// MarkTaskComplete is the unchecked task marker.
+	// MarkTaskComplete is the checked task marker.
 	MarkTaskComplete = "x"
+	// MarkTaskSkipped is the marker of a task that will not be done.
+	MarkTaskSkipped = "-"
+)
+
+// Task tag keys, written inline as "#key:value" on task lines.
+const (
+	// TaskTagID holds a task's stable short ID (e.g., "#id:k3f9").
+	TaskTagID = "id"
+	// TaskTagAdded holds the time a task was added.
+	TaskTagAdded = "added"
+	// TaskTagStarted holds the time work on a task started.
+	TaskTagStarted = "started"
+	// TaskTagDone holds the time a task was completed.
+	TaskTagDone = "done"
+	// TaskTagPriority holds a task's priority level.
+	TaskTagPriority = "priority"
+	// TaskTagDepends names a task ID that must be done first; repeat the
+	// tag or separate IDs with commas for several.
+	TaskTagDepends = "depends"
+	// TaskTagBlocks names a task ID that cannot start until this one is
+	// done; the reverse of TaskTagDepends.
+	TaskTagBlocks = "blocks"
+	// TaskTagSource records where a task came from (e.g., "report-7").
+	TaskTagSource = "source"
+)
+
+// TaskSourceCode is the "#source:" value of tasks created by
+// "ctx tasks scan" from comments in source code.
+const TaskSourceCode = "code"
+
+// Task labels, written inline as "#label" on task lines.
+const (
+	// TaskLabelInProgress marks a task that is being worked on.
+	TaskLabelInProgress = "in-progress"
+	// TaskLabelBlocked marks a task that cannot proceed.
+	TaskLabelBlocked = "blocked"
+	// TaskLabelStaleRef marks a task that mentions a file deleted from
+	// the project, as found by "ctx drift --fix".
+	TaskLabelStaleRef = "stale-ref"
+)
+
+// Task statuses, as reported by "ctx tasks list".
+const (
+	// TaskStatusPending is an unchecked task ("- [ ]").
+	TaskStatusPending = "pending"
+	// TaskStatusInProgress is an unchecked task labeled #in-progress.
+	TaskStatusInProgress = "in-progress"
+	// TaskStatusDone is a checked task ("- [x]").
+	TaskStatusDone = "done"
+	// TaskStatusSkipped is a task that will not be done ("- [-]").
+	TaskStatusSkipped = "skipped"
 )
 
+// Task priority levels, from most to least urgent.
+var TaskPriorities = []string{"high", "medium", "low"}
+
+// Decision statuses, written as "**Status**: Accepted" in DECISIONS.md
+// entries and reported in lower case.
+const (
+	// DecisionStatusProposed is a decision under discussion.
+	DecisionStatusProposed = "proposed"
+	// DecisionStatusAccepted is a current, active decision.
+	DecisionStatusAccepted = "accepted"
+	// DecisionStatusDeprecated is a decision that no longer applies.
+	DecisionStatusDeprecated = "deprecated"
+	// DecisionStatusSuperseded is a decision replaced by a newer one.
+	DecisionStatusSuperseded = "superseded"
+)
+
+// DecisionStatuses lists the decision statuses in lifecycle order.
+var DecisionStatuses = []string{
+	DecisionStatusProposed, DecisionStatusAccepted,
+	DecisionStatusDeprecated, DecisionStatusSuperseded,
+}
+
+// Labels of the "**Label**: value" fields of a decision entry that
+// track its lifecycle.
+const (
+	// DecisionFieldStatus holds the decision status.
+	DecisionFieldStatus = "Status"
+	// DecisionFieldSupersedes links to the decision this one replaces.
+	DecisionFieldSupersedes = "Supersedes"
+	// DecisionFieldSupersededBy links to the decision that replaced this one.
+	DecisionFieldSupersededBy = "Superseded by"
+	// DecisionFieldSource identifies the ADR file an entry was imported from.
+	DecisionFieldSource = "Source"
+)
+
+// ADR import and export.
+const (
+	// ADRSourcePrefix starts the source ID of an imported ADR
+	// ("adr:0001-use-postgres.md").
+	ADRSourcePrefix = "adr:"
+	// ADRFormatMADR is the Markdown Any Decision Records export format.
+	ADRFormatMADR = "madr"
+	// ADRKeyCtxID is the MADR front matter key holding the timestamp of
+	// the exported entry, so re-exports and re-imports find it.
+	ADRKeyCtxID = "ctx-id"
+)
+
+// Consolidated entries.
+const (
+	// EntryFieldConsolidatedFrom records the originals a consolidated
+	// entry replaced and the archive file holding them.
+	EntryFieldConsolidatedFrom = "Consolidated from"
+	// ConsolidatedSuffix ends the title of a consolidated entry.
+	ConsolidatedSuffix = " (consolidated)"
+	// ArchiveConsolidated follows the file type in the name of the
+	// archive of consolidated originals ("learnings-consolidated").
+	ArchiveConsolidated = "-consolidated"
+)
+
+// TaskIDLen is the length of generated task IDs.
+const TaskIDLen = 4
+
 // System reminder tags injected by Claude Code into tool results.
 const (
 	// TagSystemReminderOpen is the opening tag for system reminders.
@@ -0,0 +1,47 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// Context packet section names, as used by agent profiles in .ctxrc,
+// explain output and delta snapshots.
+const (
+	// PacketSectionReadOrder is the "Read These Files" list.
+	PacketSectionReadOrder = "read_order"
+	// PacketSectionConstitution holds CONSTITUTION.md rules.
+	PacketSectionConstitution = "constitution"
+	// PacketSectionTasks holds active TASKS.md items.
+	PacketSectionTasks = "tasks"
+	// PacketSectionConventions holds CONVENTIONS.md bullets.
+	PacketSectionConventions = "conventions"
+	// PacketSectionDecisions holds DECISIONS.md entries.
+	PacketSectionDecisions = "decisions"
+	// PacketSectionLearnings holds LEARNINGS.md entries.
+	PacketSectionLearnings = "learnings"
+	// PacketSectionArchitecture holds ARCHITECTURE.md sections.
+	PacketSectionArchitecture = "architecture"
+	// PacketSectionGlossary holds GLOSSARY.md terms.
+	PacketSectionGlossary = "glossary"
+)
+
+// PacketSections lists every context packet section in render order.
+var PacketSections = []string{
+	PacketSectionReadOrder,
+	PacketSectionConstitution,
+	PacketSectionTasks,
+	PacketSectionConventions,
+	PacketSectionDecisions,
+	PacketSectionLearnings,
+	PacketSectionArchitecture,
+	PacketSectionGlossary,
+}
+
+// PacketWeightedSections lists the sections that share the entry budget
+// and accept a weight in an agent profile.
+var PacketWeightedSections = []string{
+	PacketSectionDecisions,
+	PacketSectionLearnings,
+}
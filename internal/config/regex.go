@@ -58,6 +58,14 @@ var RegExDecision = regexp.MustCompile(`(?m)^## \[\d{4}-\d{2}-\d{2}-\d{6}].*$`)
 // Use for finding learning positions without capturing groups.
 var RegExLearning = regexp.MustCompile(`(?m)^- \*\*\[\d{4}-\d{2}-\d{2}]\*\*.*$`)
 
+// RegExEntryField matches a labeled field in a decision or learning body
+// (e.g., "**Rationale**: Because ...").
+//
+// Groups:
+//   - 1: field label
+//   - 2: field text
+var RegExEntryField = regexp.MustCompile(`^\*\*([^*]+)\*\*:\s*(.*)$`)
+
 // RegExNonFileNameChar matches characters not allowed in file names.
 var RegExNonFileNameChar = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
 
@@ -71,6 +79,29 @@ var RegExEntryHeading = regexp.MustCompile(`(?m)^## \[`)
 //   - 1: file path
 var RegExPath = regexp.MustCompile("`([^`]+\\.[a-zA-Z]{1,5})`")
 
+// RegExCodeSpan matches inline code spans in Markdown.
+//
+// Groups:
+//   - 1: text between the backticks
+var RegExCodeSpan = regexp.MustCompile("`([^`\n]+)`")
+
+// RegExGoSymbol matches a qualified Go identifier filling an inline code
+// span, such as "rc.ContextDir", "Report.Status()" or
+// "drift.Report.Status".
+//
+// Groups:
+//   - 1: package or type name
+//   - 2: symbol, or type name if group 3 is set
+//   - 3: method or field of the type in group 2, if any
+//   - 4: "()" if the symbol is written as a call
+var RegExGoSymbol = regexp.MustCompile(
+	`^([A-Za-z_]\w*)\.([A-Za-z_]\w*)(?:\.([A-Za-z_]\w*))?(\(\))?$`,
+)
+
+// RegExDigits matches runs of digits, masked when drift issue messages
+// are fingerprinted for the baseline.
+var RegExDigits = regexp.MustCompile(`\d+`)
+
 // RegExContextUpdate matches context-update XML tags.
 //
 // Groups:
@@ -81,6 +112,18 @@ var RegExContextUpdate = regexp.MustCompile(`<context-update(\s+[^>]+)>([^<]+)</
 // RegExGlossary matches glossary definition entries (lines with **term**).
 var RegExGlossary = regexp.MustCompile(`(?m)(?:^|\n)\s*(?:-\s*)?\*\*[^*]+\*\*`)
 
+// RegExGlossaryTerm matches a single glossary definition line
+// ("- **Term**: definition").
+//
+// Groups:
+//   - 1: term
+//   - 2: definition text on the same line (may be empty)
+var RegExGlossaryTerm = regexp.MustCompile(`^\s*(?:[-*]\s+)?\*\*([^*]+)\*\*\s*[:—–-]?\s*(.*)$`)
+
+// RegExTableSeparator matches a Markdown table separator row
+// ("|---|:---:|").
+var RegExTableSeparator = regexp.MustCompile(`^\s*\|?(\s*:?-+:?\s*\|)+\s*:?-*:?\s*$`)
+
 // RegExDecisionPatterns detects decision-like phrases in text.
 var RegExDecisionPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`(?i)decided to\s+(.{20,100})`),
@@ -102,13 +145,13 @@ var RegExLearningPatterns = []*regexp.Regexp{
 
 // regExTaskPattern captures indent, checkbox state, and content.
 //
-// Pattern: ^(\s*)-\s*\[([x ]?)]\s*(.+)$
+// Pattern: ^(\s*)-\s*\[([x -]?)]\s*(.+)$
 //
 // Groups:
 //   - 1: indent (leading whitespace, may be empty)
-//   - 2: state ("x" for completed, " " or "" for pending)
+//   - 2: state ("x" for completed, "-" for skipped, " " or "" for pending)
 //   - 3: content (task text)
-const regExTaskPattern = `^(\s*)-\s*\[([x ]?)]\s*(.+)$`
+const regExTaskPattern = `^(\s*)-\s*\[([x -]?)]\s*(.+)$`
 
 // RegExTask matches a task item on a single line.
 //
@@ -127,6 +170,37 @@ var RegExTaskMultiline = regexp.MustCompile(`(?m)` + regExTaskPattern)
 //   - 1: timestamp (YYYY-MM-DD-HHMMSS)
 var RegExTaskDoneTimestamp = regexp.MustCompile(`#done:(\d{4}-\d{2}-\d{2}-\d{6})`)
 
+// RegExTaskTag matches an inline task tag: a label ("#in-progress") or
+// a key-value tag ("#priority:high"), optionally wrapped in backticks.
+// Tags must start with a letter and follow whitespace, so "#2" and URL
+// fragments are not tags.
+//
+// Groups:
+//   - 1: label or key
+//   - 2: value (empty for labels)
+var RegExTaskTag = regexp.MustCompile("(?:^|[\\s`])#([A-Za-z][\\w-]*)(?::([^\\s`]+))?`?")
+
+// RegExTaskArchiveFile matches task archive file names written by
+// "ctx tasks archive" and "ctx compact --archive" (not snapshots).
+var RegExTaskArchiveFile = regexp.MustCompile(`^tasks-\d{4}-\d{2}-\d{2}\.md$`)
+
+// RegExCodeMarker matches a TODO, FIXME or HACK marker in a line of
+// source code, with an optional "(owner)" and colon after it. Whether
+// the marker starts a comment is checked separately, per language.
+//
+// Groups:
+//   - 1: marker (e.g., "TODO")
+//   - 2: comment text after the marker
+var RegExCodeMarker = regexp.MustCompile(`\b(TODO|FIXME|HACK)\b(?:\([^)]*\))?:?\s*(.*)$`)
+
+// RegExCodeRef matches a backticked "path:line" source reference, as
+// written by "ctx tasks scan".
+//
+// Groups:
+//   - 1: path
+//   - 2: line number
+var RegExCodeRef = regexp.MustCompile("`([^`\\s]+):(\\d+)`")
+
 // RegExClaudeTag matches Claude Code internal markup tags that leak into
 // session titles via the first user message. This MUST remain an allowlist
 // of known Claude Code tags — do NOT replace with a blanket regex.
@@ -180,3 +254,67 @@ var RegExListStart = regexp.MustCompile(`^(\d+\.|[-*]) `)
 func RegExFromAttrName(name string) *regexp.Regexp {
 	return regexp.MustCompile(name + `="([^"]*)"`)
 }
+
+// RegExADRFile matches ADR file names like "0001-use-postgres.md".
+//
+// Groups:
+//   - 1: ADR number
+var RegExADRFile = regexp.MustCompile(`^(\d+)-.+\.md$`)
+
+// RegExADRTitleNumber matches the number prefix of an ADR title, as in
+// "1. Record decisions" or "ADR-0001: Record decisions".
+var RegExADRTitleNumber = regexp.MustCompile(`(?i)^(?:ADR[- ]?)?\d+[.:]?\s+`)
+
+// RegExADRSupersededBy matches the link in an ADR status like
+// "Superseded by [5. Use TOML](0005-use-toml.md)" or "superseded by ADR-0005".
+//
+// Groups:
+//   - 1: link target
+//   - 2: ADR number
+var RegExADRSupersededBy = regexp.MustCompile(
+	`(?i)superseded by\s*(?:\[[^\]]*\]\(([^)\s]+)\)|(?:ADR[- ]?)?(\d+))`,
+)
+
+// RegExProfileName matches valid agent profile names: lowercase letters,
+// digits, "-" and "_", starting with a letter or digit. Profile names end
+// up in hook command lines, so nothing a shell would interpret is allowed.
+var RegExProfileName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Credential formats found by the secret scanner (see internal/secret).
+var (
+	// RegExAWSAccessKey matches AWS access key IDs like "AKIA...".
+	RegExAWSAccessKey = regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)
+	// RegExAWSSecretKey matches an AWS secret access key assigned to a
+	// key named like one.
+	//
+	// Groups:
+	//   - 1: the secret key
+	RegExAWSSecretKey = regexp.MustCompile(
+		`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`,
+	)
+	// RegExGitHubToken matches GitHub personal access, OAuth, app and
+	// refresh tokens.
+	RegExGitHubToken = regexp.MustCompile(
+		`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`,
+	)
+	// RegExSlackToken matches Slack bot, user and app tokens.
+	RegExSlackToken = regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)
+	// RegExSlackWebhook matches Slack incoming webhook URLs.
+	RegExSlackWebhook = regexp.MustCompile(
+		`https://hooks\.slack\.com/services/T[A-Za-z0-9]+/B[A-Za-z0-9]+/[A-Za-z0-9]+`,
+	)
+	// RegExPrivateKey matches the first line of a PEM private key block.
+	RegExPrivateKey = regexp.MustCompile(
+		`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`,
+	)
+	// RegExJWT matches JSON web tokens (header, payload and signature).
+	RegExJWT = regexp.MustCompile(
+		`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`,
+	)
+	// RegExSecretCandidate matches runs of base64 and URL-safe
+	// characters long enough to be checked for high entropy.
+	RegExSecretCandidate = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+	// RegExHex matches strings of hex digits and dashes, such as commit
+	// hashes and UUIDs, which are never reported for their entropy.
+	RegExHex = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+)
@@ -0,0 +1,16 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package config
+
+// Tokenizer identifiers accepted by the "tokenizer" key in .ctxrc.
+const (
+	// TokenizerBPE selects byte-level BPE with the embedded cl100k_base
+	// vocabulary.
+	TokenizerBPE = "bpe"
+	// TokenizerHeuristic selects the len/4 character heuristic.
+	TokenizerHeuristic = "heuristic"
+)
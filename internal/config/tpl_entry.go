@@ -12,8 +12,8 @@ package config
 // by the add command. Each uses fmt.Sprintf verbs for interpolation.
 const (
 	// TplTask formats a task checkbox line.
-	// Args: content, priorityTag, timestamp.
-	TplTask = "- [ ] %s%s #added:%s\n"
+	// Args: content, priorityTag, timestamp, id.
+	TplTask = "- [ ] %s%s #added:%s #id:%s\n"
 
 	// TplTaskPriority formats the inline priority tag.
 	// Args: priority level.
@@ -35,10 +35,11 @@ const (
 	TplConvention = "- %s\n"
 
 	// TplDecision formats a decision section with all ADR fields.
-	// Args: timestamp, title, context, title (repeated), rationale, consequences.
+	// Args: timestamp, title, status, context, title (repeated), rationale,
+	// consequences.
 	TplDecision = `## [%s] %s
 
-**Status**: Accepted
+**Status**: %s
 
 **Context**: %s
 
@@ -48,4 +49,13 @@ const (
 
 **Consequences**: %s
 `
+
+	// TplEntryUpdate opens an addendum that "ctx add --append-to" folds
+	// into an existing decision or learning.
+	// Args: date, title.
+	TplEntryUpdate = "**Update (%s)**: %s\n"
+
+	// TplEntryUpdateField formats one field of an addendum.
+	// Args: field label, value.
+	TplEntryUpdateField = "- *%s*: %s\n"
 )
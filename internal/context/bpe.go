@@ -0,0 +1,175 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// bpeVocab holds the merge ranks of OpenAI's cl100k_base encoding in
+// tiktoken's file format: one "<base64 token> <rank>" pair per line.
+// The file is vendored unchanged from tiktoken; "make vocab" downloads
+// it again and checks its SHA-256.
+//
+//go:embed vocab/cl100k_base.tiktoken
+var bpeVocab []byte
+
+// maxPieceLen bounds the size of a single pre-token handed to the
+// merge loop. Longer pieces (minified blobs, base64) are split first so
+// that the quadratic merge stays cheap.
+const maxPieceLen = 256
+
+// BPETokenizer counts tokens with byte-level byte-pair encoding.
+//
+// Text is split into pre-tokens (see preTokenize), then each piece is
+// merged greedily by lowest rank, exactly like tiktoken. The token count
+// is the number of parts left after merging.
+type BPETokenizer struct {
+	ranks map[string]int
+}
+
+var (
+	bpeOnce    sync.Once
+	bpeDefault *BPETokenizer
+	bpeErr     error
+)
+
+// DefaultBPE returns the BPE tokenizer built from the embedded vocabulary.
+//
+// The vocabulary is parsed once and cached.
+//
+// Returns:
+//   - *BPETokenizer: Shared tokenizer instance
+//   - error: Non-nil if the embedded vocabulary is malformed
+func DefaultBPE() (*BPETokenizer, error) {
+	bpeOnce.Do(func() {
+		bpeDefault, bpeErr = NewBPETokenizer(bpeVocab)
+	})
+	return bpeDefault, bpeErr
+}
+
+// NewBPETokenizer parses a tiktoken-format rank file.
+//
+// Parameters:
+//   - data: Rank file content ("<base64 token> <rank>" per line)
+//
+// Returns:
+//   - *BPETokenizer: Tokenizer using the parsed ranks
+//   - error: Non-nil if a line is malformed or a single byte is missing
+func NewBPETokenizer(data []byte) (*BPETokenizer, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields := bytes.Fields(scanner.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("vocab line %d: expected token and rank", lineNum)
+		}
+		token, decErr := base64.StdEncoding.DecodeString(string(fields[0]))
+		if decErr != nil {
+			return nil, fmt.Errorf("vocab line %d: %w", lineNum, decErr)
+		}
+		rank, atoiErr := strconv.Atoi(string(fields[1]))
+		if atoiErr != nil {
+			return nil, fmt.Errorf("vocab line %d: %w", lineNum, atoiErr)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Every byte must be encodable on its own, or merging can't start.
+	for b := 0; b < 256; b++ {
+		if _, ok := ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("vocab is missing single byte 0x%02x", b)
+		}
+	}
+
+	return &BPETokenizer{ranks: ranks}, nil
+}
+
+// Name returns the tokenizer identifier used in .ctxrc.
+//
+// Returns:
+//   - string: config.TokenizerBPE
+func (t *BPETokenizer) Name() string {
+	return config.TokenizerBPE
+}
+
+// Count returns the number of BPE tokens in content.
+//
+// Parameters:
+//   - content: Byte slice to tokenize
+//
+// Returns:
+//   - int: Token count (0 for empty content)
+func (t *BPETokenizer) Count(content []byte) int {
+	total := 0
+	for _, piece := range preTokenize(string(content)) {
+		for len(piece) > maxPieceLen {
+			total += t.countPiece(piece[:maxPieceLen])
+			piece = piece[maxPieceLen:]
+		}
+		total += t.countPiece(piece)
+	}
+	return total
+}
+
+// countPiece merges a single pre-token and returns its token count.
+//
+// Uses tiktoken's byte_pair_merge: repeatedly join the adjacent pair
+// whose concatenation has the lowest rank until no pair is in the
+// vocabulary.
+//
+// Parameters:
+//   - piece: One pre-token
+//
+// Returns:
+//   - int: Number of tokens the piece encodes to
+func (t *BPETokenizer) countPiece(piece string) int {
+	if len(piece) <= 1 {
+		return len(piece)
+	}
+	if _, ok := t.ranks[piece]; ok {
+		return 1
+	}
+
+	// bounds[i] is the start offset of part i; the last entry is len(piece).
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	for len(bounds) > 2 {
+		best, bestRank := -1, math.MaxInt
+		for i := 0; i+2 < len(bounds); i++ {
+			if r, ok := t.ranks[piece[bounds[i]:bounds[i+2]]]; ok && r < bestRank {
+				best, bestRank = i, r
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+
+	return len(bounds) - 1
+}
@@ -0,0 +1,96 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// discoverLayers finds every context directory named name between the
+// repository root and the current working directory.
+//
+// The walk starts at the working directory and stops at the first
+// ancestor containing a .git entry. Outside a git repository only the
+// working directory is considered, so unrelated parent directories are
+// never picked up. Absolute names are never layered.
+//
+// Parameters:
+//   - name: Context directory name (e.g., ".context")
+//
+// Returns:
+//   - []string: Existing context directories, outermost (repo root) first;
+//     the working directory's layer keeps name as given
+func discoverLayers(name string) []string {
+	if filepath.IsAbs(name) {
+		return existingDirs(name)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return existingDirs(name)
+	}
+
+	// Collect candidate project directories, innermost first.
+	projects := []string{cwd}
+	for dir := cwd; ; {
+		if _, statErr := os.Stat(filepath.Join(dir, config.DirGit)); statErr == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// No repository root above cwd: don't layer.
+			projects = projects[:1]
+			break
+		}
+		dir = parent
+		projects = append(projects, dir)
+	}
+
+	var layers []string
+	for i := len(projects) - 1; i >= 0; i-- {
+		candidate := name
+		if i > 0 {
+			rel, relErr := filepath.Rel(cwd, filepath.Join(projects[i], name))
+			if relErr != nil {
+				continue
+			}
+			candidate = rel
+		}
+		layers = append(layers, existingDirs(candidate)...)
+	}
+	return layers
+}
+
+// existingDirs returns dir in a slice if it exists and is a directory.
+//
+// Parameters:
+//   - dir: Directory path to check
+//
+// Returns:
+//   - []string: []string{dir} if it is a directory, nil otherwise
+func existingDirs(dir string) []string {
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return []string{dir}
+	}
+	return nil
+}
+
+// LayerRoot returns the project directory a context layer belongs to.
+//
+// Relative path references inside a layer's files (e.g., in
+// ARCHITECTURE.md) are relative to this directory.
+//
+// Parameters:
+//   - layer: Context directory path (FileInfo.Layer)
+//
+// Returns:
+//   - string: Parent directory of the layer
+func LayerRoot(layer string) string {
+	return filepath.Dir(filepath.Clean(layer))
+}
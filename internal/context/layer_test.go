@@ -0,0 +1,169 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// setupLayeredRepo creates a repository with a root context and a nested
+// sub-project context, and changes into the sub-project.
+func setupLayeredRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	sub := filepath.Join(root, "services", "api")
+
+	writeFiles := map[string]string{
+		filepath.Join(root, ".git", "HEAD"):                      "ref: refs/heads/main\n",
+		filepath.Join(root, ".context", config.FileConstitution): "# Constitution\n\n- [ ] Root rule\n",
+		filepath.Join(root, ".context", config.FileTask):         "# Tasks\n\n- [ ] Root task\n",
+		filepath.Join(root, ".context", config.FileConvention):   "# Conventions\n\n- Root convention\n",
+		filepath.Join(sub, ".context", config.FileConstitution):  "# Constitution\n\n- [ ] Ignored rule\n",
+		filepath.Join(sub, ".context", config.FileTask):          "# Tasks\n\n- [ ] Service task\n",
+	}
+	for path, content := range writeFiles {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Chdir(sub)
+	rc.Reset()
+	t.Cleanup(rc.Reset)
+	return root
+}
+
+func TestDiscoverLayers(t *testing.T) {
+	setupLayeredRepo(t)
+
+	got := discoverLayers(config.DirContext)
+	want := []string{filepath.Join("..", "..", ".context"), ".context"}
+	if len(got) != len(want) {
+		t.Fatalf("discoverLayers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("layer %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDiscoverLayers_NoRepo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".context"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	got := discoverLayers(config.DirContext)
+	if len(got) != 1 || got[0] != ".context" {
+		t.Errorf("discoverLayers() = %v, want [.context]", got)
+	}
+}
+
+func TestLoad_Layered(t *testing.T) {
+	setupLayeredRepo(t)
+
+	ctx, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if !ctx.IsLayered() {
+		t.Fatalf("IsLayered() = false, layers = %v", ctx.Layers)
+	}
+	if ctx.Dir != ".context" {
+		t.Errorf("Dir = %q, want .context", ctx.Dir)
+	}
+
+	// Nearest layer wins for File.
+	tasks := ctx.FileLayers(config.FileTask)
+	if len(tasks) != 2 {
+		t.Fatalf("FileLayers(TASKS) = %d files, want 2", len(tasks))
+	}
+	if f := ctx.File(config.FileTask); f == nil || !ctx.IsLocal(f) {
+		t.Error("File(TASKS) should return the local layer's copy")
+	}
+	if ctx.IsLocal(tasks[1]) {
+		t.Error("parent TASKS.md should not be local")
+	}
+
+	// Inherited files keep their layer.
+	conv := ctx.File(config.FileConvention)
+	if conv == nil || conv.Layer != filepath.Join("..", "..", ".context") {
+		t.Errorf("CONVENTIONS.md layer = %v, want parent layer", conv)
+	}
+
+	// Only the outermost constitution is loaded.
+	constitutions := ctx.FileLayers(config.FileConstitution)
+	if len(constitutions) != 1 {
+		t.Fatalf("got %d constitutions, want 1", len(constitutions))
+	}
+	if ctx.IsLocal(constitutions[0]) {
+		t.Error("constitution should be inherited from the root layer")
+	}
+}
+
+func TestLoad_ExplicitDirIsSingleLayer(t *testing.T) {
+	setupLayeredRepo(t)
+
+	ctx, err := Load(".context")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if ctx.IsLayered() {
+		t.Errorf("explicit dir should not be layered, got %v", ctx.Layers)
+	}
+	if f := ctx.File(config.FileConstitution); f == nil {
+		t.Error("single-layer load should keep the local constitution")
+	}
+}
+
+func TestLoad_ParentOnly(t *testing.T) {
+	root := setupLayeredRepo(t)
+	if err := os.RemoveAll(filepath.Join(root, "services", "api", ".context")); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if ctx.Dir != filepath.Join("..", "..", ".context") {
+		t.Errorf("Dir = %q, want parent layer", ctx.Dir)
+	}
+}
+
+func TestLayerRoot(t *testing.T) {
+	if got := LayerRoot("../../.context"); got != "../.." {
+		t.Errorf("LayerRoot() = %q, want ../..", got)
+	}
+	if got := LayerRoot(".context"); got != "." {
+		t.Errorf("LayerRoot() = %q, want .", got)
+	}
+}
+
+func TestLoad_OverrideIsSingleLayer(t *testing.T) {
+	setupLayeredRepo(t)
+	rc.OverrideContextDir(".context")
+
+	ctx, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if ctx.IsLayered() {
+		t.Errorf("--context-dir should not be layered, got %v", ctx.Layers)
+	}
+}
@@ -19,19 +19,97 @@ import (
 // Load reads all context files from the specified directory.
 //
 // If dir is empty, it uses the configured context directory from .ctxrc,
-// CTX_DIR environment variable, or the default ".context".
+// CTX_DIR environment variable, or the default ".context", and loads it
+// as a stack of layers: every directory with that name between the
+// repository root and the working directory contributes its files (see
+// discoverLayers). Files are ordered nearest layer first, so
+// Context.File returns the child's copy. CONSTITUTION.md is the
+// exception: it is inherited from the outermost layer that defines one
+// and copies in nested layers are ignored.
+//
+// A non-empty dir, or a directory given with --context-dir, always loads
+// that single directory.
 //
 // Parameters:
-//   - dir: Directory path to load from, or empty string for default
+//   - dir: Directory path to load from, or empty string for the layered
+//     default
 //
 // Returns:
 //   - *Context: Loaded context with files, token counts, and metadata
-//   - error: NotFoundError if directory doesn't exist, or other IO errors
+//   - error: NotFoundError if no directory exists, or other IO errors
 func Load(dir string) (*Context, error) {
-	if dir == "" {
-		dir = rc.ContextDir()
+	if dir != "" {
+		return loadLayers(dir, []string{dir})
+	}
+	dir = rc.ContextDir()
+	if rc.ContextDirOverridden() {
+		return loadLayers(dir, []string{dir})
+	}
+	return loadLayers(dir, discoverLayers(dir))
+}
+
+// loadLayers reads the given context directories and merges them.
+//
+// Parameters:
+//   - dir: Directory name reported in NotFoundError when layers is empty
+//   - layers: Context directories, outermost first
+//
+// Returns:
+//   - *Context: Merged context; Dir is the nearest layer
+//   - error: NotFoundError if a layer is missing, or other IO errors
+func loadLayers(dir string, layers []string) (*Context, error) {
+	if len(layers) == 0 {
+		return nil, &NotFoundError{Dir: dir}
+	}
+
+	perLayer := make([][]FileInfo, len(layers))
+	hasConstitution := false
+	for i, layer := range layers {
+		files, err := loadLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		// Only the outermost constitution applies; nested ones are
+		// ignored so a sub-project cannot relax inherited rules.
+		kept := files[:0]
+		for _, f := range files {
+			if f.Name == config.FileConstitution {
+				if hasConstitution {
+					continue
+				}
+				hasConstitution = true
+			}
+			kept = append(kept, f)
+		}
+		perLayer[i] = kept
+	}
+
+	ctx := &Context{
+		Dir:    layers[len(layers)-1],
+		Layers: layers,
+		Files:  []FileInfo{},
+	}
+	for i := len(perLayer) - 1; i >= 0; i-- {
+		for _, f := range perLayer[i] {
+			ctx.Files = append(ctx.Files, f)
+			ctx.TotalTokens += f.Tokens
+			ctx.TotalSize += f.Size
+		}
 	}
 
+	return ctx, nil
+}
+
+// loadLayer reads all .md files from a single context directory.
+//
+// Parameters:
+//   - dir: Context directory path
+//
+// Returns:
+//   - []FileInfo: Loaded files with Layer set to dir
+//   - error: NotFoundError if the directory doesn't exist, or other IO
+//     errors
+func loadLayer(dir string) ([]FileInfo, error) {
 	// Check if the directory exists
 	info, statErr := os.Stat(dir)
 	if statErr != nil {
@@ -49,17 +127,13 @@ func Load(dir string) (*Context, error) {
 		return nil, err
 	}
 
-	ctx := &Context{
-		Dir:   dir,
-		Files: []FileInfo{},
-	}
-
 	// Read all .md files in the directory
 	entries, readErr := os.ReadDir(dir)
 	if readErr != nil {
 		return nil, readErr
 	}
 
+	var files []FileInfo
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -81,22 +155,18 @@ func Load(dir string) (*Context, error) {
 			continue
 		}
 
-		tokens := EstimateTokens(content)
-		fi := FileInfo{
+		files = append(files, FileInfo{
 			Name:    name,
 			Path:    filePath,
+			Layer:   dir,
 			Size:    fileInfo.Size(),
 			ModTime: fileInfo.ModTime(),
 			Content: content,
 			IsEmpty: len(content) == 0 || effectivelyEmpty(content),
-			Tokens:  tokens,
+			Tokens:  CountTokens(content),
 			Summary: generateSummary(name, content),
-		}
-
-		ctx.Files = append(ctx.Files, fi)
-		ctx.TotalTokens += tokens
-		ctx.TotalSize += fileInfo.Size()
+		})
 	}
 
-	return ctx, nil
+	return files, nil
 }
@@ -0,0 +1,144 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// contractions lists the English suffixes split off as their own
+// pre-token, matching the cl100k pattern (case-insensitive).
+var contractions = []string{"'ll", "'re", "'ve", "'s", "'t", "'m", "'d"}
+
+// preTokenize splits text into pre-tokens using cl100k rules.
+//
+// It is a hand-written equivalent of the cl100k_base split pattern
+// (Go's regexp has no lookahead):
+//
+//	(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}|
+//	 ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+(?!\S)|\s+
+//
+// BPE merges never cross pre-token boundaries.
+//
+// Parameters:
+//   - s: Text to split
+//
+// Returns:
+//   - []string: Pre-tokens in order; concatenated they equal s
+func preTokenize(s string) []string {
+	var pieces []string
+	for i := 0; i < len(s); {
+		n := nextPreToken(s[i:])
+		pieces = append(pieces, s[i:i+n])
+		i += n
+	}
+	return pieces
+}
+
+// nextPreToken returns the byte length of the pre-token at the start of s.
+//
+// Alternatives are tried in the same order as the cl100k pattern.
+//
+// Parameters:
+//   - s: Remaining text (non-empty)
+//
+// Returns:
+//   - int: Length in bytes of the first pre-token (always > 0)
+func nextPreToken(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	next, nextSize := utf8.DecodeRuneInString(s[size:])
+	hasNext := nextSize > 0
+
+	// 's 't 're 've 'm 'll 'd
+	if r == '\'' {
+		lower := strings.ToLower(s[:min(len(s), 3)])
+		for _, c := range contractions {
+			if strings.HasPrefix(lower, c) {
+				return len(c)
+			}
+		}
+	}
+
+	// [^\r\n\p{L}\p{N}]?\p{L}+
+	if unicode.IsLetter(r) {
+		return size + runLen(s[size:], unicode.IsLetter)
+	}
+	if !isNewline(r) && !unicode.IsNumber(r) && hasNext &&
+		unicode.IsLetter(next) {
+		return size + nextSize + runLen(s[size+nextSize:], unicode.IsLetter)
+	}
+
+	// \p{N}{1,3}
+	if unicode.IsNumber(r) {
+		n := size
+		for digits := 1; digits < 3 && n < len(s); digits++ {
+			d, dSize := utf8.DecodeRuneInString(s[n:])
+			if !unicode.IsNumber(d) {
+				break
+			}
+			n += dSize
+		}
+		return n
+	}
+
+	// ' ?[^\s\p{L}\p{N}]+[\r\n]*'
+	start := 0
+	if r == ' ' && hasNext && isPunct(next) {
+		start = size
+	}
+	if start > 0 || isPunct(r) {
+		n := start + runLen(s[start:], isPunct)
+		return n + runLen(s[n:], isNewline)
+	}
+
+	// Whitespace alternatives: \s*[\r\n]+ | \s+(?!\S) | \s+
+	ws := runLen(s, unicode.IsSpace)
+	if ws == 0 {
+		// Not reachable for valid categories, but never stall.
+		return size
+	}
+	if last := strings.LastIndexAny(s[:ws], "\r\n"); last >= 0 {
+		return last + 1
+	}
+	if ws == len(s) {
+		return ws
+	}
+	_, lastSize := utf8.DecodeLastRuneInString(s[:ws])
+	if ws-lastSize > 0 {
+		return ws - lastSize
+	}
+	return ws
+}
+
+// runLen returns the byte length of the leading run of runes matching f.
+//
+// Parameters:
+//   - s: Text to scan
+//   - f: Rune predicate
+//
+// Returns:
+//   - int: Length in bytes of the matching prefix
+func runLen(s string, f func(rune) bool) int {
+	for i, r := range s {
+		if !f(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// isNewline reports whether r is a carriage return or line feed.
+func isNewline(r rune) bool {
+	return r == '\r' || r == '\n'
+}
+
+// isPunct reports whether r belongs to [^\s\p{L}\p{N}].
+func isPunct(r rune) bool {
+	return !unicode.IsSpace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
@@ -12,6 +12,9 @@ package context
 // This is a conservative estimate for Claude/GPT-style tokenizers that
 // tends to slightly overestimate, which is safer for budgeting.
 //
+// This is the HeuristicTokenizer backend. Budget computations should call
+// CountTokens, which honors the "tokenizer" setting in .ctxrc.
+//
 // Parameters:
 //   - content: Byte slice to estimate tokens for
 //
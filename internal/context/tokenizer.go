@@ -0,0 +1,105 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// Tokenizer counts tokens for budget computations.
+//
+// Implementations must be safe for concurrent use and return 0 for
+// empty content.
+type Tokenizer interface {
+	// Name returns the identifier used by the "tokenizer" key in .ctxrc.
+	Name() string
+	// Count returns the number of tokens in content.
+	Count(content []byte) int
+}
+
+// HeuristicTokenizer estimates tokens as one per four bytes.
+//
+// It is the fallback when the BPE vocabulary cannot be loaded or when
+// .ctxrc selects "heuristic" explicitly.
+type HeuristicTokenizer struct{}
+
+// Name returns the tokenizer identifier used in .ctxrc.
+//
+// Returns:
+//   - string: config.TokenizerHeuristic
+func (HeuristicTokenizer) Name() string {
+	return config.TokenizerHeuristic
+}
+
+// Count estimates tokens with the len/4 heuristic.
+//
+// Parameters:
+//   - content: Byte slice to estimate
+//
+// Returns:
+//   - int: Estimated token count (see EstimateTokens)
+func (HeuristicTokenizer) Count(content []byte) int {
+	return EstimateTokens(content)
+}
+
+// TokenizerFor returns the tokenizer registered under name.
+//
+// An empty or unknown name selects BPE, the .ctxrc default, as loading
+// .ctxrc does (see rc.DefaultTokenizer). A BPE vocabulary that fails to
+// load falls back to the heuristic, so budget computations never fail.
+//
+// Parameters:
+//   - name: Tokenizer identifier (config.TokenizerBPE or
+//     config.TokenizerHeuristic)
+//
+// Returns:
+//   - Tokenizer: The selected tokenizer
+func TokenizerFor(name string) Tokenizer {
+	if name != config.TokenizerHeuristic {
+		if bpe, err := DefaultBPE(); err == nil {
+			return bpe
+		}
+	}
+	return HeuristicTokenizer{}
+}
+
+// ActiveTokenizer returns the tokenizer selected by .ctxrc.
+//
+// Returns:
+//   - Tokenizer: Tokenizer for rc.Tokenizer()
+func ActiveTokenizer() Tokenizer {
+	return TokenizerFor(rc.Tokenizer())
+}
+
+// CountTokens counts tokens in content with the active tokenizer.
+//
+// All budget computations (ctx agent, ctx load, ctx status) go through
+// this function so that the .ctxrc "tokenizer" setting applies everywhere.
+//
+// Parameters:
+//   - content: Byte slice to count
+//
+// Returns:
+//   - int: Token count (0 for empty content)
+func CountTokens(content []byte) int {
+	if len(content) == 0 {
+		return 0
+	}
+	return ActiveTokenizer().Count(content)
+}
+
+// CountTokensString counts tokens in a string with the active tokenizer.
+//
+// Parameters:
+//   - s: String to count
+//
+// Returns:
+//   - int: Token count
+func CountTokensString(s string) int {
+	return CountTokens([]byte(s))
+}
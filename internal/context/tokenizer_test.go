@@ -0,0 +1,224 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+func TestPreTokenize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "words keep leading space",
+			input: "Hello world",
+			want:  []string{"Hello", " world"},
+		},
+		{
+			name:  "contraction split",
+			input: "it's",
+			want:  []string{"it", "'s"},
+		},
+		{
+			name:  "digits in groups of three",
+			input: "12345",
+			want:  []string{"123", "45"},
+		},
+		{
+			name:  "punctuation with space",
+			input: "x := y",
+			want:  []string{"x", " :=", " y"},
+		},
+		{
+			name:  "whitespace before word leaves one space",
+			input: "a   b",
+			want:  []string{"a", "  ", " b"},
+		},
+		{
+			name:  "newlines grouped",
+			input: "a\n\n  b",
+			want:  []string{"a", "\n\n", " ", " b"},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := preTokenize(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("preTokenize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreTokenize_RoundTrip(t *testing.T) {
+	input := "## [2026-01-28-051426] Use `rc.ContextDir()`\n\n" +
+		"**Context**: CJK 日本語 text, tabs\tand émigré words.\r\n"
+	if got := strings.Join(preTokenize(input), ""); got != input {
+		t.Errorf("round trip mismatch:\n got %q\nwant %q", got, input)
+	}
+}
+
+func TestBPETokenizer_Count(t *testing.T) {
+	bpe, err := DefaultBPE()
+	if err != nil {
+		t.Fatalf("DefaultBPE() error = %v", err)
+	}
+
+	if got := bpe.Count(nil); got != 0 {
+		t.Errorf("Count(nil) = %d, want 0", got)
+	}
+	if got := bpe.Count([]byte("a")); got != 1 {
+		t.Errorf("Count(\"a\") = %d, want 1", got)
+	}
+
+	// Common words merge into far fewer tokens than bytes.
+	text := []byte("The context directory contains decisions and learnings.")
+	if got := bpe.Count(text); got >= len(text)/2 {
+		t.Errorf("Count(%q) = %d, expected merges to reduce below %d",
+			text, got, len(text)/2)
+	}
+
+	// CJK text costs more than the len/4 heuristic assumes.
+	cjk := []byte("日本語のテキスト")
+	if got := bpe.Count(cjk); got <= EstimateTokens(cjk) {
+		t.Errorf("Count(CJK) = %d, want more than heuristic %d",
+			got, EstimateTokens(cjk))
+	}
+
+	// Long unbroken pieces are chunked, not dropped.
+	blob := []byte(strings.Repeat("Z", maxPieceLen*3))
+	if got := bpe.Count(blob); got == 0 {
+		t.Error("Count(blob) = 0, want > 0")
+	}
+}
+
+func TestBPETokenizer_Vocab(t *testing.T) {
+	// SHA-256 of cl100k_base.tiktoken as published by tiktoken; it must
+	// match VOCAB_SHA256 in the Makefile.
+	const want = "223921b76ee99bde995b7ff738513eef100fb51d18c93597a113bcffe865b2a7"
+	sum := sha256.Sum256(bpeVocab)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("embedded vocabulary SHA-256 = %s, want cl100k_base %s", got, want)
+	}
+}
+
+func TestBPETokenizer_Reference(t *testing.T) {
+	bpe, err := DefaultBPE()
+	if err != nil {
+		t.Fatalf("DefaultBPE() error = %v", err)
+	}
+
+	// Token counts from tiktoken's cl100k_base encoding (its test suite,
+	// the OpenAI cookbook and tiktoken-go's comparison against it).
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"hello world", 2},
+		{"hello  world", 3},
+		{"hello   world", 3},
+		{"'RE", 1},
+		{"rer", 1},
+		{"'rer", 2},
+		{"today\n ", 3},
+		{"today\n \n", 2},
+		{"today\n  \n", 2},
+		{"supercalifragilistic", 7},
+		{"We know what we are, but know not what we may be.", 14},
+		{"tiktoken is great!", 6},
+		{"antidisestablishmentarianism", 6},
+		{"2 + 2 = 4", 7},
+		{"お誕生日おめでとう", 9},
+		{"hallo world!", 4},
+		{"你好世界！", 6},
+		{"こんにちは世界！", 5},
+		{"안녕하세요 세계!", 10},
+		{"Привет мир!", 6},
+		{"¡Hola mundo!", 4},
+		{"Hallo Welt!", 3},
+		{"Bonjour le monde!", 4},
+		{"Ciao mondo!", 4},
+		{"Hej världen!", 7},
+		{"Hallo wereld!", 3},
+		{"Hallo verden!", 4},
+	}
+	for _, tt := range tests {
+		if got := bpe.Count([]byte(tt.text)); got != tt.want {
+			t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNewBPETokenizer_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"bad field count", "YQ== 1 extra\n"},
+		{"bad base64", "!!! 1\n"},
+		{"bad rank", "YQ== x\n"},
+		{"missing bytes", "YQ== 0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBPETokenizer([]byte(tt.data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestTokenizerFor(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{config.TokenizerBPE, config.TokenizerBPE},
+		{config.TokenizerHeuristic, config.TokenizerHeuristic},
+		{"", config.TokenizerBPE},
+		{"unknown", rc.DefaultTokenizer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TokenizerFor(tt.name).Name(); got != tt.want {
+				t.Errorf("TokenizerFor(%q).Name() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeuristicTokenizer_MatchesEstimate(t *testing.T) {
+	content := []byte("This is a test sentence with multiple words.")
+	if got := (HeuristicTokenizer{}).Count(content); got != EstimateTokens(content) {
+		t.Errorf("Count() = %d, want %d", got, EstimateTokens(content))
+	}
+}
+
+func TestCountTokens_Empty(t *testing.T) {
+	if got := CountTokens(nil); got != 0 {
+		t.Errorf("CountTokens(nil) = %d, want 0", got)
+	}
+	if got := CountTokensString(""); got != 0 {
+		t.Errorf("CountTokensString(\"\") = %d, want 0", got)
+	}
+}
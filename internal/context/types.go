@@ -13,6 +13,7 @@ import "time"
 // Fields:
 //   - Name: Filename (e.g., "TASKS.md")
 //   - Path: Full path to the file
+//   - Layer: Context directory the file was loaded from
 //   - Size: File size in bytes
 //   - ModTime: Last modification time
 //   - Content: Raw file content
@@ -23,6 +24,7 @@ import "time"
 type FileInfo struct {
 	Name    string
 	Path    string
+	Layer   string
 	Size    int64
 	ModTime time.Time
 	Content []byte
@@ -34,18 +36,24 @@ type FileInfo struct {
 // Context represents the loaded context from a .context/ directory.
 //
 // Fields:
-//   - Dir: Path to the context directory
-//   - Files: All loaded context files with their metadata
+//   - Dir: Path to the context directory (the nearest layer)
+//   - Layers: Context directories that were merged, outermost first;
+//     a single entry when the context is not nested
+//   - Files: All loaded context files with their metadata, nearest
+//     layer first
 //   - TotalTokens: Sum of estimated tokens across all files
 //   - TotalSize: Sum of file sizes in bytes
 type Context struct {
 	Dir         string
+	Layers      []string
 	Files       []FileInfo
 	TotalTokens int
 	TotalSize   int64
 }
 
 // File returns the FileInfo with the given name, or nil if not found.
+//
+// When several layers define the file, the nearest layer's copy wins.
 func (c *Context) File(name string) *FileInfo {
 	for i := range c.Files {
 		if c.Files[i].Name == name {
@@ -55,6 +63,45 @@ func (c *Context) File(name string) *FileInfo {
 	return nil
 }
 
+// FileLayers returns every loaded copy of the named file.
+//
+// Parameters:
+//   - name: Filename (e.g., "TASKS.md")
+//
+// Returns:
+//   - []*FileInfo: Matching files, nearest layer first; nil if none
+func (c *Context) FileLayers(name string) []*FileInfo {
+	var files []*FileInfo
+	for i := range c.Files {
+		if c.Files[i].Name == name {
+			files = append(files, &c.Files[i])
+		}
+	}
+	return files
+}
+
+// IsLayered reports whether more than one context directory was merged.
+//
+// Returns:
+//   - bool: True for nested (monorepo) contexts
+func (c *Context) IsLayered() bool {
+	return len(c.Layers) > 1
+}
+
+// IsLocal reports whether f belongs to the nearest layer.
+//
+// Files from parent layers are read-only from the perspective of
+// commands run in a nested project.
+//
+// Parameters:
+//   - f: File to check
+//
+// Returns:
+//   - bool: True if f was loaded from Dir (or has no layer recorded)
+func (c *Context) IsLocal(f *FileInfo) bool {
+	return f.Layer == "" || f.Layer == c.Dir
+}
+
 // NotFoundError is returned when the context directory doesn't exist.
 type NotFoundError struct {
 	Dir string
@@ -0,0 +1,159 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// fingerprintLen is the number of hex digits kept of a fingerprint.
+const fingerprintLen = 16
+
+// NewBaseline accepts all issues of a report.
+//
+// Parameters:
+//   - report: Drift report whose warnings and violations are accepted
+//
+// Returns:
+//   - *Baseline: Baseline holding one entry per issue
+func NewBaseline(report *Report) *Baseline {
+	b := &Baseline{
+		Created: time.Now().UTC().Format(time.RFC3339),
+		Issues:  []BaselineEntry{},
+	}
+	for _, issue := range slices.Concat(report.Violations, report.Warnings) {
+		b.Issues = append(b.Issues, Fingerprint(issue))
+	}
+	return b
+}
+
+// LoadBaseline reads a baseline file.
+//
+// Parameters:
+//   - path: Path to the baseline file
+//
+// Returns:
+//   - *Baseline: The baseline; nil if the file does not exist
+//   - error: Non-nil if the file cannot be read or parsed
+func LoadBaseline(path string) (*Baseline, error) {
+	data, readErr := os.ReadFile(filepath.Clean(path))
+	if errors.Is(readErr, os.ErrNotExist) {
+		return nil, nil
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	var b Baseline
+	if jsonErr := json.Unmarshal(data, &b); jsonErr != nil {
+		return nil, fmt.Errorf("%s: %w", path, jsonErr)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline as indented JSON.
+//
+// Parameters:
+//   - path: Path to the baseline file
+//
+// Returns:
+//   - error: Non-nil if encoding or writing fails
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, config.NewlineLF...), config.PermFile)
+}
+
+// Apply moves the issues the baseline accepts out of a report.
+//
+// Accepted issues go to Baselined, so Status only counts new ones.
+// Baseline entries that match no issue go to Resolved, unless the
+// checks that would report them did not run. Each entry accepts one
+// issue: a second issue with the same fingerprint is new.
+//
+// Parameters:
+//   - report: Drift report to filter
+func (b *Baseline) Apply(report *Report) {
+	if b == nil {
+		return
+	}
+	remaining := make(map[string]int)
+	for _, e := range b.Issues {
+		remaining[e.Fingerprint]++
+	}
+
+	keep := func(issues []Issue) []Issue {
+		kept := []Issue{}
+		for _, issue := range issues {
+			fp := Fingerprint(issue).Fingerprint
+			if remaining[fp] > 0 {
+				remaining[fp]--
+				report.Baselined = append(report.Baselined, issue)
+				continue
+			}
+			kept = append(kept, issue)
+		}
+		return kept
+	}
+	report.Violations = keep(report.Violations)
+	report.Warnings = keep(report.Warnings)
+
+	for _, e := range b.Issues {
+		if remaining[e.Fingerprint] == 0 {
+			continue
+		}
+		remaining[e.Fingerprint]--
+		if report.checked == nil || slices.Contains(report.checked, e.Type) {
+			report.Resolved = append(report.Resolved, e)
+		}
+	}
+}
+
+// Fingerprint identifies an issue for the baseline.
+//
+// The fingerprint covers the issue's type, file, rule, path and
+// message. The message is lowercased, with runs of digits masked as "#"
+// and whitespace collapsed; the line number is left out. The entry keeps
+// the message as reported, for display.
+//
+// Parameters:
+//   - issue: Drift issue
+//
+// Returns:
+//   - BaselineEntry: The issue's entry, with its fingerprint
+func Fingerprint(issue Issue) BaselineEntry {
+	file := issue.File
+	if issue.Layer != "" {
+		file = filepath.ToSlash(filepath.Join(issue.Layer, issue.File))
+	}
+	msg := strings.ToLower(config.RegExDigits.ReplaceAllString(issue.Message, "#"))
+	e := BaselineEntry{
+		Type:    issue.Type,
+		File:    file,
+		Rule:    issue.Rule,
+		Message: issue.Message,
+		Path:    issue.Path,
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(e.Type), e.File, e.Rule, e.Path,
+		strings.Join(strings.Fields(msg), " "),
+	}, "\x00")))
+	e.Fingerprint = hex.EncodeToString(sum[:])[:fingerprintLen]
+	return e
+}
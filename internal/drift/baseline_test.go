@@ -0,0 +1,123 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	base := Issue{
+		File: "GLOSSARY.md", Line: 3, Type: IssueStaleAge,
+		Message: "has not been modified in 45 days",
+	}
+	fp := Fingerprint(base).Fingerprint
+
+	moved := base
+	moved.Line = 9
+	moved.Message = "has not been  modified in 46 days"
+	if got := Fingerprint(moved).Fingerprint; got != fp {
+		t.Error("line numbers, counts and spacing should not change the fingerprint")
+	}
+	if got := Fingerprint(moved).Message; got != moved.Message {
+		t.Errorf("Message = %q, want the original %q", got, moved.Message)
+	}
+
+	for name, change := range map[string]func(*Issue){
+		"type":  func(i *Issue) { i.Type = IssueStaleness },
+		"file":  func(i *Issue) { i.File = "TASKS.md" },
+		"rule":  func(i *Issue) { i.Rule = ruleNoSecrets },
+		"path":  func(i *Issue) { i.Path = "old.go" },
+		"layer": func(i *Issue) { i.Layer = "../.context" },
+	} {
+		other := base
+		change(&other)
+		if Fingerprint(other).Fingerprint == fp {
+			t.Errorf("a different %s should change the fingerprint", name)
+		}
+	}
+}
+
+func TestBaseline_Apply(t *testing.T) {
+	glossary := Issue{File: "GLOSSARY.md", Type: IssueStaleAge, Message: "is 40 days old"}
+	deadA := Issue{File: "ARCHITECTURE.md", Line: 4, Type: IssueDeadPath, Message: "dead", Path: "a.go"}
+	deadB := Issue{File: "ARCHITECTURE.md", Line: 5, Type: IssueDeadPath, Message: "dead", Path: "b.go"}
+	secretIssue := Issue{File: ".env", Type: IssueSecret, Message: "may contain secrets"}
+
+	b := NewBaseline(&Report{
+		Warnings:   []Issue{glossary, deadA, deadB},
+		Violations: []Issue{secretIssue},
+	})
+	if len(b.Issues) != 4 || b.Created == "" {
+		t.Fatalf("baseline = %+v", b)
+	}
+
+	// The glossary aged, a.go was fixed, and c.go and a second .env
+	// finding are new.
+	deadC := Issue{File: "ARCHITECTURE.md", Line: 4, Type: IssueDeadPath, Message: "dead", Path: "c.go"}
+	glossary.Message = "is 75 days old"
+	report := &Report{
+		Warnings:   []Issue{glossary, deadB, deadC},
+		Violations: []Issue{secretIssue, secretIssue},
+	}
+	b.Apply(report)
+
+	if len(report.Warnings) != 1 || report.Warnings[0].Path != "c.go" {
+		t.Errorf("warnings = %+v, want only c.go", report.Warnings)
+	}
+	if len(report.Violations) != 1 {
+		t.Errorf("violations = %+v, want the second finding", report.Violations)
+	}
+	if len(report.Baselined) != 3 {
+		t.Errorf("baselined = %+v, want 3", report.Baselined)
+	}
+	if len(report.Resolved) != 1 || report.Resolved[0].Path != "a.go" {
+		t.Errorf("resolved = %+v, want a.go", report.Resolved)
+	}
+	if report.Status() != StatusViolation {
+		t.Errorf("Status() = %q", report.Status())
+	}
+
+	// Entries for checks that did not run are not resolved.
+	report = &Report{checked: []IssueType{IssueStaleAge}}
+	b.Apply(report)
+	if len(report.Resolved) != 1 || report.Resolved[0].Type != IssueStaleAge {
+		t.Errorf("resolved = %+v, want only stale_age", report.Resolved)
+	}
+
+	var none *Baseline
+	none.Apply(report)
+}
+
+func TestLoadBaseline(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "drift-baseline.json")
+
+	if b, err := LoadBaseline(path); b != nil || err != nil {
+		t.Errorf("missing baseline = %v, %v; want nil, nil", b, err)
+	}
+
+	want := NewBaseline(&Report{Warnings: []Issue{
+		{File: "GLOSSARY.md", Type: IssueStaleAge, Message: "old"},
+	}})
+	if err := want.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := LoadBaseline(path)
+	if err != nil || got == nil || len(got.Issues) != 1 || got.Issues[0] != want.Issues[0] {
+		t.Errorf("LoadBaseline() = %+v, %v; want %+v", got, err, want)
+	}
+
+	if writeErr := os.WriteFile(path, []byte("{"), 0600); writeErr != nil {
+		t.Fatal(writeErr)
+	}
+	if _, err = LoadBaseline(path); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
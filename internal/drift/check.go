@@ -0,0 +1,365 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// Check options, as set under a check in the "drift:" section of .ctxrc.
+const (
+	optMaxCompleted = "max_completed"
+	optMaxAgeDays   = "max_age_days"
+	optMaxLearnings = "max_learnings"
+	optMaxDecisions = "max_decisions"
+	optScanJournal  = "scan_journal"
+)
+
+// registeredChecks holds all drift checks in the order they run.
+// Add new checks here.
+var registeredChecks = []Check{
+	builtinCheck{
+		name:        CheckPathReferences,
+		description: "Backticked paths in ARCHITECTURE.md and CONVENTIONS.md exist",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueDeadPath},
+		run:         checkPathReferences,
+	},
+	builtinCheck{
+		name:        CheckSymbolReferences,
+		description: "Backticked Go symbols like `pkg.Name` and `Type.Method` exist",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueDeadSymbol},
+		applies: func(*context.Context) bool {
+			return hasGoModule("")
+		},
+		run: checkSymbolReferences,
+	},
+	builtinCheck{
+		name:        CheckStaleness,
+		description: "TASKS.md does not pile up completed tasks",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueStaleness},
+		options: func() []Option {
+			return []Option{{
+				Name:        optMaxCompleted,
+				Default:     10,
+				Description: "Completed tasks TASKS.md may hold before archiving",
+			}}
+		},
+		run: checkStaleness,
+	},
+	builtinCheck{
+		name:        CheckTaskDependencies,
+		description: "Task dependency tags form no cycles and name existing tasks",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueDependencyCycle, IssueDanglingDependency},
+		run:         checkTaskDependencies,
+	},
+	builtinCheck{
+		name:        CheckConstitution,
+		description: "No credentials in context files or secret-named files in the working directory",
+		severity:    StatusViolation,
+		issues:      []IssueType{IssueSecret},
+		options: func() []Option {
+			return []Option{{
+				Name:        optScanJournal,
+				Description: "Also scan .context/journal/ for credentials",
+				Switch:      true,
+			}}
+		},
+		run: checkConstitution,
+	},
+	builtinCheck{
+		name:        CheckRequiredFiles,
+		description: "All required context files are present",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueMissing},
+		run:         checkRequiredFiles,
+	},
+	builtinCheck{
+		name:        CheckFileAge,
+		description: "Context files have been modified recently",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueStaleAge},
+		options: func() []Option {
+			return []Option{{
+				Name:        optMaxAgeDays,
+				Default:     30,
+				Description: "Days without changes before a file is stale",
+			}}
+		},
+		run: checkFileAge,
+	},
+	builtinCheck{
+		name:        CheckEntryCount,
+		description: "LEARNINGS.md and DECISIONS.md do not grow too long",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueEntryCount},
+		options: func() []Option {
+			return []Option{
+				{
+					Name:        optMaxLearnings,
+					Default:     rc.EntryCountLearnings(),
+					Description: "Entries LEARNINGS.md may hold (0 disables)",
+				},
+				{
+					Name:        optMaxDecisions,
+					Default:     rc.EntryCountDecisions(),
+					Description: "Entries DECISIONS.md may hold (0 disables)",
+				},
+			}
+		},
+		run: checkEntryCount,
+	},
+	builtinCheck{
+		name:        CheckLayers,
+		description: "Nested context layers define no ignored CONSTITUTION.md",
+		severity:    StatusWarning,
+		issues:      []IssueType{IssueShadowedConstitution},
+		applies:     (*context.Context).IsLayered,
+		run:         checkLayers,
+	},
+}
+
+// builtinCheck implements Check for the checks of this package.
+//
+// Fields:
+//   - name: Check identifier
+//   - description: One-line summary
+//   - severity: Default severity
+//   - issues: Issue types the check reports
+//   - options: Returns the options with their defaults; nil for none
+//   - applies: Reports whether the check applies; nil for always
+//   - run: Finds the issues
+type builtinCheck struct {
+	name        CheckName
+	description string
+	severity    StatusType
+	issues      []IssueType
+	options     func() []Option
+	applies     func(ctx *context.Context) bool
+	run         func(ctx *context.Context, opts Options) []Issue
+}
+
+// Name implements Check.
+func (c builtinCheck) Name() CheckName { return c.name }
+
+// Description implements Check.
+func (c builtinCheck) Description() string { return c.description }
+
+// Severity implements Check.
+func (c builtinCheck) Severity() StatusType { return c.severity }
+
+// Issues implements Check.
+func (c builtinCheck) Issues() []IssueType { return c.issues }
+
+// Options implements Check.
+func (c builtinCheck) Options() []Option {
+	if c.options == nil {
+		return nil
+	}
+	return c.options()
+}
+
+// Applies implements Check.
+func (c builtinCheck) Applies(ctx *context.Context) bool {
+	return c.applies == nil || c.applies(ctx)
+}
+
+// Run implements Check.
+func (c builtinCheck) Run(ctx *context.Context, opts Options) []Issue {
+	return c.run(ctx, opts)
+}
+
+// Checks returns all registered drift checks in the order they run.
+//
+// Returns:
+//   - []Check: The registered checks
+func Checks() []Check {
+	return slices.Clone(registeredChecks)
+}
+
+// Lookup finds the check a name refers to.
+//
+// Parameters:
+//   - name: Check name (e.g., "file_age_check") or a type of issue the
+//     check reports (e.g., "stale_age")
+//
+// Returns:
+//   - Check: The check; nil if the name is unknown
+//   - IssueType: The issue type if name is one; empty for a check name
+func Lookup(name string) (Check, IssueType) {
+	for _, c := range registeredChecks {
+		if string(c.Name()) == name {
+			return c, ""
+		}
+	}
+	for _, c := range registeredChecks {
+		for _, t := range c.Issues() {
+			if string(t) == name {
+				return c, t
+			}
+		}
+	}
+	return nil, ""
+}
+
+// Enabled reports whether a check runs by default.
+//
+// Checks are enabled unless "enabled: false" is set under their name in
+// the "drift:" section of .ctxrc.
+//
+// Parameters:
+//   - c: The check
+//
+// Returns:
+//   - bool: False if .ctxrc disables the check
+func Enabled(c Check) bool {
+	return enabled(string(c.Name()))
+}
+
+// IssueEnabled reports whether issues of a type are reported by default.
+//
+// Issue types are enabled unless "enabled: false" is set under their
+// name in the "drift:" section of .ctxrc.
+//
+// Parameters:
+//   - t: Issue type
+//
+// Returns:
+//   - bool: False if .ctxrc disables the issue type
+func IssueEnabled(t IssueType) bool {
+	return enabled(string(t))
+}
+
+// SeverityOf returns the effective severity of an issue.
+//
+// Settings for the issue type win over those for the check, which win
+// over the check's default.
+//
+// Parameters:
+//   - c: The check that reported the issue
+//   - t: Type of the issue
+//
+// Returns:
+//   - StatusType: StatusWarning or StatusViolation
+func SeverityOf(c Check, t IssueType) StatusType {
+	for _, key := range []string{string(t), string(c.Name())} {
+		if cfg := rc.Drift(key); cfg != nil && cfg.Severity != "" {
+			return StatusType(cfg.Severity)
+		}
+	}
+	return c.Severity()
+}
+
+// OptionsOf returns the effective option values of a check.
+//
+// Values set in .ctxrc under the check's name, or under one of its issue
+// types, replace the defaults.
+//
+// Parameters:
+//   - c: The check
+//
+// Returns:
+//   - Options: Value of every option the check declares
+func OptionsOf(c Check) Options {
+	opts := make(Options)
+	keys := []string{string(c.Name())}
+	for _, t := range c.Issues() {
+		keys = append(keys, string(t))
+	}
+	for _, o := range c.Options() {
+		opts[o.Name] = o.Default
+		for _, key := range keys {
+			if cfg := rc.Drift(key); cfg != nil {
+				if v, ok := cfg.Options[o.Name]; ok {
+					opts[o.Name] = v
+				}
+			}
+		}
+	}
+	return opts
+}
+
+// ValidateConfig checks the "drift:" section of .ctxrc for names that
+// are neither a check nor an issue type, and for unknown options.
+//
+// Returns:
+//   - error: All problems found, joined; nil if the section is valid
+func ValidateConfig() error {
+	var errs []error
+	for _, name := range rc.DriftNames() {
+		c, _ := Lookup(name)
+		if c == nil {
+			errs = append(errs, fmt.Errorf(
+				"drift.%s: unknown check or issue type", name,
+			))
+			continue
+		}
+		for opt := range rc.Drift(name).Options {
+			if !slices.ContainsFunc(c.Options(), func(o Option) bool {
+				return o.Name == opt
+			}) {
+				errs = append(errs, fmt.Errorf(
+					"drift.%s: %s has no option %q", name, c.Name(), opt,
+				))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// enabled reports whether .ctxrc leaves a check or issue type enabled.
+//
+// Parameters:
+//   - key: Check name or issue type
+//
+// Returns:
+//   - bool: False only if "enabled: false" is set for key
+func enabled(key string) bool {
+	cfg := rc.Drift(key)
+	return cfg == nil || cfg.Enabled == nil || *cfg.Enabled
+}
+
+// selectChecks resolves the names passed to "ctx drift --only".
+//
+// Parameters:
+//   - names: Check names and issue types
+//
+// Returns:
+//   - map[CheckName][]IssueType: Issue types to keep by selected check;
+//     a nil list keeps all of the check's issues
+//   - error: Non-nil if a name is neither a check nor an issue type
+func selectChecks(names []string) (map[CheckName][]IssueType, error) {
+	selected := make(map[CheckName][]IssueType)
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		c, t := Lookup(name)
+		if c == nil {
+			return nil, fmt.Errorf(
+				"unknown drift check or issue type %q (see ctx drift --list-checks)",
+				name,
+			)
+		}
+		types, seen := selected[c.Name()]
+		switch {
+		case t == "":
+			selected[c.Name()] = nil
+		case !seen || types != nil:
+			selected[c.Name()] = append(types, t)
+		}
+	}
+	return selected, nil
+}
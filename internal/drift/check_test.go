@@ -0,0 +1,202 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+	"github.com/ActiveMemory/ctx/internal/context"
+	"github.com/ActiveMemory/ctx/internal/rc"
+)
+
+// runCheck runs a single registered check through DetectOnly.
+func runCheck(t *testing.T, ctx *context.Context, name CheckName) *Report {
+	t.Helper()
+	report, err := DetectOnly(ctx, []string{string(name)})
+	if err != nil {
+		t.Fatalf("DetectOnly(%s) error = %v", name, err)
+	}
+	return report
+}
+
+// setupRC writes .ctxrc in a temporary working directory.
+func setupRC(t *testing.T, content string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile(config.FileContextRC, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rc.Reset()
+	t.Cleanup(rc.Reset)
+}
+
+// staleContext returns a context whose TASKS.md is 40 days old and
+// holds three completed tasks.
+func staleContext() *context.Context {
+	return &context.Context{
+		Dir: config.DirContext,
+		Files: []context.FileInfo{{
+			Name:    config.FileTask,
+			Content: []byte("# Tasks\n\n- [x] One\n- [x] Two\n- [x] Three\n"),
+			ModTime: time.Now().AddDate(0, 0, -40),
+		}},
+	}
+}
+
+func TestChecks(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range Checks() {
+		if c.Description() == "" || len(c.Issues()) == 0 {
+			t.Errorf("%s needs a description and issue types", c.Name())
+		}
+		if s := c.Severity(); s != StatusWarning && s != StatusViolation {
+			t.Errorf("%s has severity %q", c.Name(), s)
+		}
+		names := []string{string(c.Name())}
+		for _, it := range c.Issues() {
+			names = append(names, string(it))
+		}
+		for _, n := range names {
+			if seen[n] {
+				t.Errorf("name %q is used twice", n)
+			}
+			seen[n] = true
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if c, it := Lookup("file_age_check"); c == nil || c.Name() != CheckFileAge || it != "" {
+		t.Errorf("Lookup(file_age_check) = %v, %q", c, it)
+	}
+	if c, it := Lookup("dangling_dependency"); c == nil ||
+		c.Name() != CheckTaskDependencies || it != IssueDanglingDependency {
+		t.Errorf("Lookup(dangling_dependency) = %v, %q", c, it)
+	}
+	if c, _ := Lookup("nope"); c != nil {
+		t.Errorf("Lookup(nope) = %v, want nil", c)
+	}
+}
+
+func TestDetectOnly(t *testing.T) {
+	setupRC(t, "")
+	ctx := staleContext()
+
+	report, err := DetectOnly(ctx, []string{"stale_age"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0].Type != IssueStaleAge {
+		t.Errorf("warnings = %+v, want one stale_age", report.Warnings)
+	}
+	if len(report.Passed) != 0 {
+		t.Errorf("passed = %v, want only the selected check to run", report.Passed)
+	}
+
+	report, err = DetectOnly(ctx, []string{"dead_path", "required_files"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(report.Passed, []CheckName{CheckPathReferences}) {
+		t.Errorf("passed = %v, want path_references", report.Passed)
+	}
+	if len(report.Warnings) != len(config.FilesRequired)-1 {
+		t.Errorf("got %d warnings, want one per missing file", len(report.Warnings))
+	}
+
+	if _, err = DetectOnly(ctx, []string{"dead_paths"}); err == nil ||
+		!strings.Contains(err.Error(), `"dead_paths"`) {
+		t.Errorf("unknown name error = %v", err)
+	}
+}
+
+func TestDetect_Config(t *testing.T) {
+	setupRC(t, `drift:
+  staleness_check:
+    max_completed: 2
+    severity: violation
+  file_age_check:
+    enabled: false
+  missing_file:
+    enabled: false
+  potential_secret:
+    severity: warning
+`)
+	ctx := staleContext()
+	report := Detect(ctx)
+
+	if len(report.Violations) != 1 || report.Violations[0].Type != IssueStaleness {
+		t.Errorf("violations = %+v, want the promoted staleness issue", report.Violations)
+	}
+	for _, w := range report.Warnings {
+		if w.Type == IssueStaleAge || w.Type == IssueMissing {
+			t.Errorf("disabled issue reported: %+v", w)
+		}
+	}
+	if slices.Contains(report.Passed, CheckFileAge) {
+		t.Error("a disabled check should not be listed as passed")
+	}
+	if !slices.Contains(report.Passed, CheckRequiredFiles) {
+		t.Error("a check whose only issue type is disabled should pass")
+	}
+
+	c, _ := Lookup(string(CheckConstitution))
+	if got := SeverityOf(c, IssueSecret); got != StatusWarning {
+		t.Errorf("SeverityOf(potential_secret) = %q, want warning", got)
+	}
+
+	// Naming a disabled check runs it anyway.
+	only, _ := DetectOnly(ctx, []string{"file_age_check"})
+	if len(only.Warnings) != 1 {
+		t.Errorf("--only should run disabled checks, got %+v", only.Warnings)
+	}
+}
+
+func TestOptionsOf(t *testing.T) {
+	setupRC(t, "entry_count_learnings: 5\ndrift:\n  entry_count:\n    max_decisions: 7\n")
+	c, _ := Lookup(string(CheckEntryCount))
+	opts := OptionsOf(c)
+	if opts[optMaxLearnings] != 5 || opts[optMaxDecisions] != 7 {
+		t.Errorf("OptionsOf(entry_count_check) = %v", opts)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	setupRC(t, `drift:
+  file_age_check:
+    max_age_days: 60
+  stale_age:
+    max_days: 60
+  dead_paths:
+    enabled: false
+  staleness:
+    severity: fatal
+`)
+	err := ValidateConfig()
+	if err == nil {
+		t.Fatal("ValidateConfig() = nil, want errors")
+	}
+	for _, want := range []string{
+		`drift.stale_age: file_age_check has no option "max_days"`,
+		"drift.dead_paths: unknown check or issue type",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "file_age_check:") {
+		t.Errorf("valid settings reported: %v", err)
+	}
+	if rc.Drift("staleness") != nil {
+		t.Error("settings with an unknown severity should be dropped at load")
+	}
+}
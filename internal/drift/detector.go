@@ -9,22 +9,30 @@ package drift
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/ActiveMemory/ctx/internal/config"
 	"github.com/ActiveMemory/ctx/internal/context"
 	"github.com/ActiveMemory/ctx/internal/index"
-	"github.com/ActiveMemory/ctx/internal/rc"
+	"github.com/ActiveMemory/ctx/internal/secret"
+	"github.com/ActiveMemory/ctx/internal/task"
 )
 
-const staleAgeDays = 30
-
 var staleAgeExclude = []string{config.FileConstitution}
 
+// ruleNoSecrets is the constitution rule recorded on secret issues.
+const ruleNoSecrets = "no_secrets"
+
 // Status returns the overall status of the report.
 //
+// Only Warnings and Violations count; issues accepted in a baseline
+// (see Baseline.Apply) do not.
+//
 // Returns:
 //   - StatusType: StatusViolation if any violations, StatusWarning if only
 //     warnings, StatusOk otherwise
@@ -38,10 +46,13 @@ func (r *Report) Status() StatusType {
 	return StatusOk
 }
 
-// Detect runs all drift detection checks on the given context.
+// Detect runs all enabled drift checks on the given context.
 //
-// Performs multiple validation checks including path references, staleness
-// indicators, constitution compliance, and required file presence.
+// Checks run in registration order (see Checks); checks disabled in
+// .ctxrc, issue types disabled there and checks that do not apply to
+// the context are skipped. Each issue is filed as a warning or a
+// violation by its effective severity (see SeverityOf), and a check
+// that reports nothing is listed as passed.
 //
 // Parameters:
 //   - ctx: Loaded context containing files to check
@@ -49,49 +60,105 @@ func (r *Report) Status() StatusType {
 // Returns:
 //   - *Report: Drift report with warnings, violations, and passed checks
 func Detect(ctx *context.Context) *Report {
+	report, _ := DetectOnly(ctx, nil)
+	return report
+}
+
+// DetectOnly runs the named drift checks on the given context.
+//
+// Names are check names or issue types; an issue type selects the check
+// that reports it and keeps only issues of that type. Named checks run
+// even if .ctxrc disables them. With no names, DetectOnly behaves like
+// Detect.
+//
+// Parameters:
+//   - ctx: Loaded context containing files to check
+//   - names: Checks and issue types to run (e.g., "dead_path",
+//     "file_age_check"); empty runs all enabled checks
+//
+// Returns:
+//   - *Report: Drift report with warnings, violations, and passed checks
+//   - error: Non-nil if a name is neither a check nor an issue type
+func DetectOnly(ctx *context.Context, names []string) (*Report, error) {
+	selected, err := selectChecks(names)
+	if err != nil {
+		return nil, err
+	}
+	all := len(selected) == 0
+
 	report := &Report{
 		Warnings:   []Issue{},
 		Violations: []Issue{},
 		Passed:     []CheckName{},
 	}
 
-	// Check path references in context files
-	checkPathReferences(ctx, report)
-
-	// Check for staleness indicators
-	checkStaleness(ctx, report)
-
-	// Check constitution rules (basic heuristics)
-	checkConstitution(ctx, report)
-
-	// Check for empty required files
-	checkRequiredFiles(ctx, report)
+	for _, c := range registeredChecks {
+		types, ok := selected[c.Name()]
+		if all {
+			ok = Enabled(c)
+		}
+		if !ok || !c.Applies(ctx) {
+			continue
+		}
 
-	// Check for files not modified recently
-	checkFileAge(ctx, report)
+		for _, t := range c.Issues() {
+			if (types == nil && (!all || IssueEnabled(t))) ||
+				slices.Contains(types, t) {
+				report.checked = append(report.checked, t)
+			}
+		}
 
-	// Check for excessive entry counts in knowledge files
-	checkEntryCount(ctx, report)
+		found := false
+		for _, issue := range c.Run(ctx, OptionsOf(c)) {
+			if all && !IssueEnabled(issue.Type) {
+				continue
+			}
+			if types != nil && !slices.Contains(types, issue.Type) {
+				continue
+			}
+			if SeverityOf(c, issue.Type) == StatusViolation {
+				report.Violations = append(report.Violations, issue)
+			} else {
+				report.Warnings = append(report.Warnings, issue)
+			}
+			found = true
+		}
+		if !found {
+			report.Passed = append(report.Passed, c.Name())
+		}
+	}
 
-	return report
+	return report, nil
 }
 
 // checkPathReferences scans ARCHITECTURE.md and CONVENTIONS.md for dead paths.
 //
 // Looks for backtick-enclosed file paths and verifies they exist on disk.
-// Skips URLs, template patterns, and glob patterns.
+// Skips URLs, template patterns, glob patterns, and references to symbols
+// of the project's Go module. Paths in files from a parent layer are
+// resolved relative to that layer's project directory.
 //
 // Parameters:
 //   - ctx: Loaded context containing files to scan
-//   - report: Report to append warnings to (modified in place)
-func checkPathReferences(ctx *context.Context, report *Report) {
-	foundDeadPaths := false
+//   - opts: Check options (none)
+//
+// Returns:
+//   - []Issue: One dead_path issue per missing path
+func checkPathReferences(ctx *context.Context, _ Options) []Issue {
+	var issues []Issue
+	indexes := make(map[string]*symbolIndex)
 
 	for _, f := range ctx.Files {
 		if f.Name != config.FileArchitecture && f.Name != config.FileConvention {
 			continue
 		}
 
+		layer := issueLayer(ctx, &f)
+		root := ""
+		if layer != "" {
+			root = context.LayerRoot(layer)
+		}
+
 		lines := strings.Split(string(f.Content), config.NewlineLF)
 		for lineNum, line := range lines {
 			matches := config.RegExPath.FindAllStringSubmatch(line, -1)
@@ -106,64 +173,211 @@ func checkPathReferences(ctx *context.Context, report *Report) {
 					continue
 				}
 				// Check if the file exists
-				if _, err := os.Stat(path); os.IsNotExist(err) {
-					report.Warnings = append(report.Warnings, Issue{
+				if _, err := os.Stat(filepath.Join(root, path)); os.IsNotExist(err) {
+					// Go symbols like `svc.New` are left to the
+					// symbol_references check
+					if isGoSymbol(indexes, root, path) {
+						continue
+					}
+					issues = append(issues, Issue{
 						File:    f.Name,
 						Line:    lineNum + 1,
 						Type:    IssueDeadPath,
 						Message: "references path that does not exist",
 						Path:    path,
+						Layer:   layer,
 					})
-					foundDeadPaths = true
 				}
 			}
 		}
 	}
 
-	if !foundDeadPaths {
-		report.Passed = append(report.Passed, CheckPathReferences)
+	return issues
+}
+
+// checkSymbolReferences scans context files for dead Go symbol references.
+//
+// Inline code spans like `rc.ContextDir`, `Report.Status()` or
+// `drift.Report.Status` are resolved against the declarations of the Go
+// module in the project directory. Files from a parent layer are
+// resolved against the module of that layer's project, if it has one.
+//
+// Parameters:
+//   - ctx: Loaded context containing files to scan
+//   - opts: Check options (none)
+//
+// Returns:
+//   - []Issue: One dead_symbol issue per unresolved reference, with near
+//     matches in the message
+func checkSymbolReferences(ctx *context.Context, _ Options) []Issue {
+	var issues []Issue
+	indexes := make(map[string]*symbolIndex)
+
+	for _, f := range ctx.Files {
+		layer := issueLayer(ctx, &f)
+		root := ""
+		if layer != "" {
+			root = context.LayerRoot(layer)
+		}
+		idx := symbolsAt(indexes, root)
+		if idx == nil {
+			continue
+		}
+
+		lines := strings.Split(string(f.Content), config.NewlineLF)
+		for lineNum, line := range lines {
+			for _, span := range config.RegExCodeSpan.FindAllStringSubmatch(line, -1) {
+				m := config.RegExGoSymbol.FindStringSubmatch(span[1])
+				if m == nil {
+					continue
+				}
+				dead, suggestions := idx.resolve(m)
+				if !dead {
+					continue
+				}
+				msg := fmt.Sprintf("references `%s`, which does not exist", span[1])
+				if len(suggestions) > 0 {
+					msg += fmt.Sprintf(
+						" (did you mean `%s`?)",
+						strings.Join(suggestions, "`, `"),
+					)
+				}
+				issues = append(issues, Issue{
+					File:    f.Name,
+					Line:    lineNum + 1,
+					Type:    IssueDeadSymbol,
+					Message: msg,
+					Path:    span[1],
+					Layer:   layer,
+				})
+			}
+		}
 	}
+
+	return issues
 }
 
 // checkStaleness detects signs that context files need maintenance.
 //
-// Currently checks for excessive completed tasks (>10) in TASKS.md,
-// which indicates the file should be compacted.
+// Currently checks for excessive completed tasks (more than the
+// max_completed option) in TASKS.md, which indicates the file should be
+// compacted. Every layer's TASKS.md is checked on its own.
 //
 // Parameters:
 //   - ctx: Loaded context containing files to scan
-//   - report: Report to append warnings to (modified in place)
-func checkStaleness(ctx *context.Context, report *Report) {
-	staleness := false
+//   - opts: Check options (max_completed)
+//
+// Returns:
+//   - []Issue: One staleness issue per TASKS.md over the limit
+func checkStaleness(ctx *context.Context, opts Options) []Issue {
+	var issues []Issue
 
-	if f := ctx.File(config.FileTask); f != nil {
+	for _, f := range ctx.FileLayers(config.FileTask) {
 		// Count completed tasks
 		completedCount := strings.Count(string(f.Content), "- [x]")
-		if completedCount > 10 {
-			report.Warnings = append(report.Warnings, Issue{
+		if completedCount > opts[optMaxCompleted] {
+			issues = append(issues, Issue{
 				File:    f.Name,
 				Type:    IssueStaleness,
 				Message: "has many completed items (consider archiving)",
 				Path:    "",
+				Layer:   issueLayer(ctx, f),
 			})
-			staleness = true
 		}
 	}
 
-	if !staleness {
-		report.Passed = append(report.Passed, CheckStaleness)
+	return issues
+}
+
+// checkTaskDependencies validates the dependency tags in TASKS.md.
+//
+// Reports groups of tasks that depend on each other in a loop and
+// "#depends:"/"#blocks:" tags naming an ID that matches no task. IDs of
+// tasks in the layer's archive directory are known (and done). Every
+// layer's TASKS.md is checked on its own.
+//
+// Parameters:
+//   - ctx: Loaded context containing files to scan
+//   - opts: Check options (none)
+//
+// Returns:
+//   - []Issue: dependency_cycle and dangling_dependency issues
+func checkTaskDependencies(ctx *context.Context, _ Options) []Issue {
+	var issues []Issue
+
+	for _, f := range ctx.FileLayers(config.FileTask) {
+		dir := f.Layer
+		if dir == "" {
+			dir = ctx.Dir
+		}
+		// An unreadable archive only means more references look dangling
+		archived, _ := task.ParseArchive(filepath.Join(dir, config.DirArchive))
+
+		tasks := task.Parse(strings.Split(string(f.Content), config.NewlineLF))
+		g := task.NewGraph(tasks, task.IDs(archived))
+		layer := issueLayer(ctx, f)
+
+		for _, c := range g.Cycles() {
+			issue := Issue{
+				File:    f.Name,
+				Type:    IssueDependencyCycle,
+				Message: "has a dependency cycle between " + strings.Join(c, ", "),
+				Layer:   layer,
+			}
+			if t := g.Task(c[0]); t != nil {
+				issue.Line = t.Line + 1
+			}
+			issues = append(issues, issue)
+		}
+		for _, r := range g.Dangling {
+			issues = append(issues, Issue{
+				File: f.Name,
+				Line: r.Task.Line + 1,
+				Type: IssueDanglingDependency,
+				Message: fmt.Sprintf(
+					"#%s:%s names a task that does not exist", r.Tag, r.ID,
+				),
+				Layer: layer,
+			})
+		}
 	}
+
+	return issues
 }
 
 // checkConstitution performs heuristic checks for constitution violations.
 //
-// Currently, it scans the working directory for files that may contain secrets
-// (e.g., .env, credentials, api_key) and flags them as violations.
+// It scans the working directory for files whose names suggest secrets
+// (e.g., .env, credentials, api_key), and the content of every loaded
+// context file for credentials (see secret.Scan). With the scan_journal
+// option, .context/journal/ is scanned too. Patterns in the context
+// directory's secrets allowlist are not reported; an allowlist that
+// cannot be read is ignored, so nothing is hidden by mistake.
 //
 // Parameters:
-//   - ctx: Loaded context (currently unused, reserved for future checks)
-//   - report: Report to append violations to (modified in place)
-func checkConstitution(_ *context.Context, report *Report) {
+//   - ctx: Loaded context containing files to scan
+//   - opts: Check options (scan_journal)
+//
+// Returns:
+//   - []Issue: One potential_secret issue per suspicious file or finding
+func checkConstitution(ctx *context.Context, opts Options) []Issue {
+	allow, allowErr := secret.LoadAllowlist(
+		filepath.Join(ctx.Dir, config.FileSecretAllowlist),
+	)
+	if allowErr != nil {
+		allow = nil
+	}
+
+	var issues []Issue
+	for _, f := range ctx.Files {
+		issues = append(issues, secretIssues(
+			f.Name, string(f.Content), issueLayer(ctx, &f), allow,
+		)...)
+	}
+	if opts[optScanJournal] != 0 {
+		issues = append(issues, scanJournal(ctx.Dir, allow)...)
+	}
+
 	// Basic heuristic checks for constitution violations
 	// Check for potential secrets in common config files
 
@@ -179,10 +393,8 @@ func checkConstitution(_ *context.Context, report *Report) {
 	// Look for common secret file patterns in the working directory
 	entries, readErr := os.ReadDir(".")
 	if readErr != nil {
-		return
+		return issues
 	}
-
-	foundViolation := false
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -198,32 +410,32 @@ func checkConstitution(_ *context.Context, report *Report) {
 					continue
 				}
 				if len(content) > 0 && !isTemplateFile(content) {
-					report.Violations = append(report.Violations, Issue{
+					issues = append(issues, Issue{
 						File:    entry.Name(),
 						Type:    IssueSecret,
 						Message: "may contain secrets (constitution violation)",
-						Rule:    "no_secrets",
+						Rule:    ruleNoSecrets,
 					})
-					foundViolation = true
 				}
 			}
 		}
 	}
 
-	if !foundViolation {
-		report.Passed = append(report.Passed, CheckConstitution)
-	}
+	return issues
 }
 
 // checkRequiredFiles verifies that all required context files are present.
 //
-// Checks against config.FilesRequired and adds a warning for each missing file.
+// Checks against config.FilesRequired and reports each missing file.
 //
 // Parameters:
 //   - ctx: Loaded context containing existing files
-//   - report: Report to append warnings to (modified in place)
-func checkRequiredFiles(ctx *context.Context, report *Report) {
-	allPresent := true
+//   - opts: Check options (none)
+//
+// Returns:
+//   - []Issue: One missing_file issue per missing file
+func checkRequiredFiles(ctx *context.Context, _ Options) []Issue {
+	var issues []Issue
 
 	existingFiles := make(map[string]bool)
 	for _, f := range ctx.Files {
@@ -232,31 +444,32 @@ func checkRequiredFiles(ctx *context.Context, report *Report) {
 
 	for _, name := range config.FilesRequired {
 		if !existingFiles[name] {
-			report.Warnings = append(report.Warnings, Issue{
+			issues = append(issues, Issue{
 				File:    name,
 				Type:    IssueMissing,
 				Message: "required context file is missing",
 			})
-			allPresent = false
 		}
 	}
 
-	if allPresent {
-		report.Passed = append(report.Passed, CheckRequiredFiles)
-	}
+	return issues
 }
 
-// checkFileAge flags context files whose ModTime is older than staleAgeDays.
+// checkFileAge flags context files whose ModTime is older than the
+// max_age_days option.
 //
 // Files listed in staleAgeExclude (e.g., CONSTITUTION.md) are skipped because
 // they are expected to be static.
 //
 // Parameters:
 //   - ctx: Loaded context containing files to check
-//   - report: Report to append warnings to (modified in place)
-func checkFileAge(ctx *context.Context, report *Report) {
-	foundStale := false
-	cutoff := time.Now().AddDate(0, 0, -staleAgeDays)
+//   - opts: Check options (max_age_days)
+//
+// Returns:
+//   - []Issue: One stale_age issue per stale file
+func checkFileAge(ctx *context.Context, opts Options) []Issue {
+	var issues []Issue
+	cutoff := time.Now().AddDate(0, 0, -opts[optMaxAgeDays])
 
 	for _, f := range ctx.Files {
 		excluded := false
@@ -272,63 +485,170 @@ func checkFileAge(ctx *context.Context, report *Report) {
 
 		if f.ModTime.Before(cutoff) {
 			days := int(time.Since(f.ModTime).Hours() / 24)
-			report.Warnings = append(report.Warnings, Issue{
+			issues = append(issues, Issue{
 				File:    f.Name,
 				Type:    IssueStaleAge,
 				Message: fmt.Sprintf("last modified %d days ago", days),
+				Layer:   issueLayer(ctx, &f),
 			})
-			foundStale = true
 		}
 	}
 
-	if !foundStale {
-		report.Passed = append(report.Passed, CheckFileAge)
-	}
+	return issues
 }
 
 // checkEntryCount warns when LEARNINGS.md or DECISIONS.md have too many entries.
 //
-// Uses index.ParseEntryBlocks for counting and rc thresholds for limits.
-// A threshold of 0 disables the check for that file.
+// Uses index.ParseEntryBlocks for counting and the max_learnings and
+// max_decisions options for limits. A threshold of 0 disables the check
+// for that file.
 //
 // Parameters:
 //   - ctx: Loaded context containing files to check
-//   - report: Report to append warnings to (modified in place)
-func checkEntryCount(ctx *context.Context, report *Report) {
+//   - opts: Check options (max_learnings, max_decisions)
+//
+// Returns:
+//   - []Issue: One entry_count issue per file over its limit
+func checkEntryCount(ctx *context.Context, opts Options) []Issue {
 	checks := []struct {
 		file      string
 		threshold int
 	}{
-		{config.FileLearning, rc.EntryCountLearnings()},
-		{config.FileDecision, rc.EntryCountDecisions()},
+		{config.FileLearning, opts[optMaxLearnings]},
+		{config.FileDecision, opts[optMaxDecisions]},
 	}
 
-	found := false
+	var issues []Issue
 	for _, c := range checks {
 		if c.threshold <= 0 {
 			continue // disabled
 		}
-		f := ctx.File(c.file)
-		if f == nil {
-			continue
+		for _, f := range ctx.FileLayers(c.file) {
+			blocks := index.ParseEntryBlocks(string(f.Content))
+			if len(blocks) > c.threshold {
+				issues = append(issues, Issue{
+					File: f.Name,
+					Type: IssueEntryCount,
+					Message: fmt.Sprintf(
+						"has %d entries (recommended: ≤%d)",
+						len(blocks), c.threshold,
+					),
+					Layer: issueLayer(ctx, f),
+				})
+			}
 		}
-		blocks := index.ParseEntryBlocks(string(f.Content))
-		if len(blocks) > c.threshold {
-			report.Warnings = append(report.Warnings, Issue{
-				File: f.Name,
-				Type: IssueEntryCount,
+	}
+
+	return issues
+}
+
+// checkLayers warns about nested layers whose CONSTITUTION.md is ignored.
+//
+// The constitution is inherited from the outermost layer that defines
+// one; copies in nested layers never take effect, which is easy to miss
+// when editing them.
+//
+// Parameters:
+//   - ctx: Loaded layered context
+//   - opts: Check options (none)
+//
+// Returns:
+//   - []Issue: One shadowed_constitution issue per ignored copy
+func checkLayers(ctx *context.Context, _ Options) []Issue {
+	var issues []Issue
+
+	if inherited := ctx.File(config.FileConstitution); inherited != nil {
+		for _, layer := range ctx.Layers {
+			if layer == inherited.Layer {
+				continue
+			}
+			path := filepath.Join(layer, config.FileConstitution)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			issues = append(issues, Issue{
+				File: config.FileConstitution,
+				Type: IssueShadowedConstitution,
 				Message: fmt.Sprintf(
-					"has %d entries (recommended: ≤%d)",
-					len(blocks), c.threshold,
+					"is ignored; the constitution is inherited from %s",
+					inherited.Layer,
 				),
+				Layer: issueLayer(ctx, &context.FileInfo{Layer: layer}),
 			})
-			found = true
 		}
 	}
 
-	if !found {
-		report.Passed = append(report.Passed, CheckEntryCount)
+	return issues
+}
+
+// secretIssues reports the credentials found in a file.
+//
+// Parameters:
+//   - name: File name to report
+//   - content: File content
+//   - layer: Layer to record on the issues
+//   - allow: Secrets allowlist; nil allows nothing
+//
+// Returns:
+//   - []Issue: One potential_secret issue per finding
+func secretIssues(
+	name, content, layer string, allow *secret.Allowlist,
+) []Issue {
+	var issues []Issue
+	for _, f := range secret.Scan(content, allow) {
+		issues = append(issues, Issue{
+			File:    name,
+			Line:    f.Line,
+			Type:    IssueSecret,
+			Message: "contains a " + f.Describe(),
+			Rule:    ruleNoSecrets,
+			Layer:   layer,
+		})
+	}
+	return issues
+}
+
+// scanJournal reports the credentials found in journal entries.
+//
+// Parameters:
+//   - dir: Context directory holding the journal
+//   - allow: Secrets allowlist; nil allows nothing
+//
+// Returns:
+//   - []Issue: One potential_secret issue per finding, with the file
+//     relative to the context directory; nil if there is no journal
+func scanJournal(dir string, allow *secret.Allowlist) []Issue {
+	var issues []Issue
+	root := filepath.Join(dir, config.DirJournal)
+	// An unreadable journal has nothing to report
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != config.ExtMarkdown {
+			return nil
+		}
+		content, readErr := os.ReadFile(filepath.Clean(path))
+		if readErr != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		issues = append(issues, secretIssues(rel, string(content), "", allow)...)
+		return nil
+	})
+	return issues
+}
+
+// issueLayer returns the layer to record on an issue for f.
+//
+// Parameters:
+//   - ctx: Loaded context
+//   - f: File the issue was found in
+//
+// Returns:
+//   - string: f.Layer for files from a parent layer; empty for local files
+func issueLayer(ctx *context.Context, f *context.FileInfo) string {
+	if ctx.IsLocal(f) {
+		return ""
 	}
+	return f.Layer
 }
 
 // isTemplateFile checks if file content appears to be a template.
@@ -0,0 +1,569 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Limits for the near matches offered for a dead symbol.
+const (
+	// maxSuggestions caps the number of matches.
+	maxSuggestions = 3
+	// minAffixLen is the shortest name offered because it is part of
+	// the dead one, or the dead one is part of it.
+	minAffixLen = 4
+)
+
+// symbolIndex holds the declarations of a Go module.
+//
+// Fields:
+//   - pkgs: Top-level names by package name; package main is left out
+//   - members: Fields and methods by type name, merged across packages
+//   - embeds: Embedded type names by type name, whose members are
+//     promoted
+//   - std: Exported names of standard library packages, loaded on demand
+type symbolIndex struct {
+	pkgs    map[string]map[string]bool
+	members map[string]map[string]bool
+	embeds  map[string][]string
+	std     map[string]map[string]bool
+}
+
+// hasGoModule reports whether a directory is the root of a Go module.
+//
+// Parameters:
+//   - root: Directory to check; empty for the working directory
+//
+// Returns:
+//   - bool: True if root holds a go.mod file
+func hasGoModule(root string) bool {
+	_, err := os.Stat(filepath.Join(root, config.FileGoMod))
+	return err == nil
+}
+
+// symbolsAt returns the declarations of the Go module rooted at root,
+// parsing it on first use.
+//
+// Parameters:
+//   - indexes: Indexes already loaded, by root; updated in place
+//   - root: Module root directory; empty for the working directory
+//
+// Returns:
+//   - *symbolIndex: Declarations of the module; nil if root holds no
+//     go.mod
+func symbolsAt(indexes map[string]*symbolIndex, root string) *symbolIndex {
+	idx, ok := indexes[root]
+	if !ok {
+		if hasGoModule(root) {
+			idx = loadSymbols(root)
+		}
+		indexes[root] = idx
+	}
+	return idx
+}
+
+// isGoSymbol reports whether a code span names a symbol of the Go module
+// rooted at root rather than a file.
+//
+// Parameters:
+//   - indexes: Indexes already loaded, by root; updated in place
+//   - root: Module root directory; empty for the working directory
+//   - ref: Text of the code span
+//
+// Returns:
+//   - bool: True if the symbol check handles ref, whether or not the
+//     symbol exists
+func isGoSymbol(indexes map[string]*symbolIndex, root, ref string) bool {
+	m := config.RegExGoSymbol.FindStringSubmatch(ref)
+	if m == nil {
+		return false
+	}
+	idx := symbolsAt(indexes, root)
+	return idx != nil && idx.checks(m)
+}
+
+// loadSymbols parses the Go files of the module rooted at root.
+//
+// Vendored code, test data, hidden directories and nested modules are
+// skipped. Files that fail to parse contribute what the parser
+// recovered. Only the local source tree is read; nothing is fetched.
+//
+// Parameters:
+//   - root: Module root directory; empty for the working directory
+//
+// Returns:
+//   - *symbolIndex: Declarations of the module
+func loadSymbols(root string) *symbolIndex {
+	idx := &symbolIndex{
+		pkgs:    make(map[string]map[string]bool),
+		members: make(map[string]map[string]bool),
+		embeds:  make(map[string][]string),
+		std:     make(map[string]map[string]bool),
+	}
+	if root == "" {
+		root = "."
+	}
+	fset := token.NewFileSet()
+
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") ||
+				slices.Contains(config.GoSkipDirs, name) ||
+				hasGoModule(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != config.ExtGo {
+			return nil
+		}
+		file, _ := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if file != nil {
+			idx.add(file)
+		}
+		return nil
+	})
+
+	return idx
+}
+
+// add records the declarations of a parsed file.
+//
+// Parameters:
+//   - file: Parsed Go file
+func (idx *symbolIndex) add(file *ast.File) {
+	var scope map[string]bool
+	if file.Name.Name != "main" {
+		scope = idx.pkgs[file.Name.Name]
+		if scope == nil {
+			scope = make(map[string]bool)
+			idx.pkgs[file.Name.Name] = scope
+		}
+	}
+	declare := func(name string) {
+		if scope != nil && name != "_" {
+			scope[name] = true
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				declare(d.Name.Name)
+				continue
+			}
+			if recv := typeName(d.Recv.List[0].Type); recv != "" {
+				idx.member(recv, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					declare(s.Name.Name)
+					idx.addType(s.Name.Name, s.Type)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						declare(n.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// addType records the fields, interface methods and embedded types of a
+// type declaration.
+//
+// Parameters:
+//   - name: Declared type name
+//   - expr: Type expression of the declaration
+func (idx *symbolIndex) addType(name string, expr ast.Expr) {
+	var fields *ast.FieldList
+	switch t := expr.(type) {
+	case *ast.StructType:
+		fields = t.Fields
+	case *ast.InterfaceType:
+		fields = t.Methods
+	default:
+		return
+	}
+	for _, f := range fields.List {
+		if len(f.Names) == 0 {
+			if embedded := typeName(f.Type); embedded != "" {
+				idx.member(name, embedded)
+				idx.embeds[name] = append(idx.embeds[name], embedded)
+			}
+			continue
+		}
+		for _, n := range f.Names {
+			idx.member(name, n.Name)
+		}
+	}
+}
+
+// member records a field or method of a type.
+//
+// Parameters:
+//   - typ: Type name
+//   - name: Field or method name
+func (idx *symbolIndex) member(typ, name string) {
+	if idx.members[typ] == nil {
+		idx.members[typ] = make(map[string]bool)
+	}
+	idx.members[typ][name] = true
+}
+
+// typeName returns the name of the type a receiver or embedded field
+// refers to, without pointer, package or type parameters.
+//
+// Parameters:
+//   - expr: Type expression
+//
+// Returns:
+//   - string: The type name; empty if expr names no type
+func typeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return typeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return typeName(t.X)
+	case *ast.IndexListExpr:
+		return typeName(t.X)
+	}
+	return ""
+}
+
+// membersOf returns the fields and methods of a type, including those
+// promoted from embedded types.
+//
+// Parameters:
+//   - typ: Type name
+//
+// Returns:
+//   - map[string]bool: Member names; nil if the type is unknown
+func (idx *symbolIndex) membersOf(typ string) map[string]bool {
+	if idx.members[typ] == nil {
+		return nil
+	}
+	all := make(map[string]bool)
+	seen := map[string]bool{}
+	queue := []string{typ}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		for m := range idx.members[t] {
+			all[m] = true
+		}
+		queue = append(queue, idx.embeds[t]...)
+	}
+	return all
+}
+
+// stdlib returns the exported names of a standard library package that
+// shares its name with a module package, such as "context".
+//
+// Parameters:
+//   - pkg: Package name
+//
+// Returns:
+//   - map[string]bool: Exported names; nil if there is no such package
+//     or GOROOT is unavailable
+func (idx *symbolIndex) stdlib(pkg string) map[string]bool {
+	if names, ok := idx.std[pkg]; ok {
+		return names
+	}
+	var names map[string]bool
+	if build.Default.GOROOT != "" {
+		dir := filepath.Join(build.Default.GOROOT, "src", pkg)
+		if entries, err := os.ReadDir(dir); err == nil {
+			fset := token.NewFileSet()
+			for _, e := range entries {
+				name := e.Name()
+				if filepath.Ext(name) != config.ExtGo ||
+					strings.HasSuffix(name, "_test.go") {
+					continue
+				}
+				file, _ := parser.ParseFile(
+					fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution,
+				)
+				if file == nil {
+					continue
+				}
+				for n := range exportedNames(file) {
+					if names == nil {
+						names = make(map[string]bool)
+					}
+					names[n] = true
+				}
+			}
+		}
+	}
+	idx.std[pkg] = names
+	return names
+}
+
+// exportedNames returns the exported top-level names of a file.
+//
+// Parameters:
+//   - file: Parsed Go file
+//
+// Returns:
+//   - map[string]bool: Exported function, type, variable and constant
+//     names
+func exportedNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && d.Name.IsExported() {
+				names[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Name.IsExported() {
+						names[s.Name.Name] = true
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if n.IsExported() {
+							names[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// resolve checks a qualified reference against the module.
+//
+// The left part is read as a package of the module, or else as one of
+// its types. References whose left part is neither, such as "os.Exit"
+// or "README.md", are not the module's and are not checked.
+//
+// Parameters:
+//   - m: Submatches of config.RegExGoSymbol
+//
+// Returns:
+//   - bool: True if the reference is checked and does not resolve
+//   - []string: Near matches for a dead reference, best first
+func (idx *symbolIndex) resolve(m []string) (bool, []string) {
+	if !idx.checks(m) {
+		return false, nil
+	}
+	left, name, member := m[1], m[2], m[3]
+
+	scope, isPkg := idx.pkgs[left]
+	if member != "" {
+		if !scope[name] {
+			return true, idx.suggestInPackage(left, name, "")
+		}
+		members := idx.membersOf(name)
+		if members == nil || members[member] {
+			return false, nil
+		}
+		return true, prefix(left+"."+name+".", nearest(member, members))
+	}
+
+	members := idx.membersOf(left)
+	if scope[name] || members[name] || (isPkg && idx.stdlib(left)[name]) {
+		return false, nil
+	}
+	if isPkg {
+		return true, idx.suggestInPackage(left, name, left)
+	}
+	return true, prefix(left+".", nearest(name, members))
+}
+
+// checks reports whether a qualified reference is one of the module's,
+// and so is checked by resolve.
+//
+// The last part must look like a Go identifier rather than a file
+// extension or a domain: mixed case or written as a call. The left part
+// must be a package of the module or, for a two-part reference, one of
+// its types.
+//
+// Parameters:
+//   - m: Submatches of config.RegExGoSymbol
+//
+// Returns:
+//   - bool: True if the reference names a symbol of the module
+func (idx *symbolIndex) checks(m []string) bool {
+	left, name, member, call := m[1], m[2], m[3], m[4] != ""
+	last := name
+	if member != "" {
+		last = member
+	}
+	if !call && !hasUpper(last) {
+		return false
+	}
+	if _, isPkg := idx.pkgs[left]; isPkg {
+		return true
+	}
+	return member == "" && idx.members[left] != nil
+}
+
+// suggestInPackage offers replacements for a name missing from a
+// package: the same name in other packages, or else similar names in
+// the package itself.
+//
+// Parameters:
+//   - pkg: Package the name was looked up in
+//   - name: Missing name
+//   - skip: Package not to offer the name from
+//
+// Returns:
+//   - []string: Qualified suggestions, best first
+func (idx *symbolIndex) suggestInPackage(pkg, name, skip string) []string {
+	var moved []string
+	for p, scope := range idx.pkgs {
+		if p != skip && p != pkg && scope[name] {
+			moved = append(moved, p+"."+name)
+		}
+	}
+	if len(moved) > 0 {
+		sort.Strings(moved)
+		if len(moved) > maxSuggestions {
+			moved = moved[:maxSuggestions]
+		}
+		return moved
+	}
+	return prefix(pkg+".", nearest(name, idx.pkgs[pkg]))
+}
+
+// nearest returns the candidates closest to name by edit distance,
+// ignoring case.
+//
+// Parameters:
+//   - name: Name to match
+//   - candidates: Names to choose from
+//
+// Returns:
+//   - []string: Up to maxSuggestions names within a distance of a
+//     third of name's length (at least 2), or containing or contained
+//     in name, closest first
+func nearest(name string, candidates map[string]bool) []string {
+	limit := max(2, len(name)/3)
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	lower := strings.ToLower(name)
+	for c := range candidates {
+		lc := strings.ToLower(c)
+		d := editDistance(lower, lc)
+		// A name extended or cut short, like Open and OpenStore, ranks
+		// after the close spellings.
+		if d > limit && min(len(lc), len(lower)) >= minAffixLen &&
+			(strings.Contains(lower, lc) || strings.Contains(lc, lower)) {
+			d = limit
+		}
+		if d <= limit {
+			matches = append(matches, match{c, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].name < matches[j].name
+	})
+	var names []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+//
+// Parameters:
+//   - a: First string
+//   - b: Second string
+//
+// Returns:
+//   - int: Number of single-character edits turning a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// hasUpper reports whether s contains an upper-case letter.
+//
+// Parameters:
+//   - s: String to check
+//
+// Returns:
+//   - bool: True if any rune of s is upper case
+func hasUpper(s string) bool {
+	return strings.IndexFunc(s, unicode.IsUpper) >= 0
+}
+
+// prefix qualifies names with a common prefix.
+//
+// Parameters:
+//   - p: Prefix to add
+//   - names: Names to qualify
+//
+// Returns:
+//   - []string: The qualified names
+func prefix(p string, names []string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = p + n
+	}
+	return out
+}
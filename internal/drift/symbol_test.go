@@ -0,0 +1,167 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/context"
+)
+
+// symbolModule writes a small Go module in a temporary working directory.
+func symbolModule(t *testing.T) {
+	t.Helper()
+	setupRC(t, "")
+	files := map[string]string{
+		"go.mod": "module example.com/demo\n\ngo 1.22\n",
+		"internal/store/store.go": `package store
+
+type Base struct{ ID string }
+
+func (b *Base) Key() string { return b.ID }
+
+type Store struct {
+	Base
+	Path string
+}
+
+func (s *Store) Load() error { return nil }
+
+func Open(path string) *Store { return &Store{Path: path} }
+
+const DefaultPath = "data"
+`,
+		"internal/config/config.go":   "package config\n\nconst ContextDir = \".context\"\n",
+		"internal/context/context.go": "package context\n\ntype Context struct{ Dir string }\n",
+		"cmd/demo/main.go":            "package main\n\nfunc run() {}\n",
+		"vendor/ext/ext.go":           "package ext\n\nfunc Hidden() {}\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckSymbolReferences(t *testing.T) {
+	symbolModule(t)
+
+	tests := []struct {
+		name    string
+		ref     string
+		dead    bool
+		suggest string
+	}{
+		{"package function", "store.Open", false, ""},
+		{"package constant", "config.ContextDir", false, ""},
+		{"method", "Store.Load()", false, ""},
+		{"promoted method", "Store.Key", false, ""},
+		{"field", "Store.Path", false, ""},
+		{"qualified method", "store.Store.Load", false, ""},
+		{"stdlib shadowed by module package", "context.WithCancel", false, ""},
+		{"foreign package", "os.Exit", false, ""},
+		{"file name", "config.yaml", false, ""},
+		{"package main", "main.run()", false, ""},
+		{"vendored package", "ext.Missing", false, ""},
+		{"moved symbol", "store.ContextDir", true, "`config.ContextDir`"},
+		{"renamed function", "store.OpenStore", true, "`store.Open`"},
+		{"misspelled constant", "store.DefaultPth", true, "`store.DefaultPath`"},
+		{"dead method", "Store.Save()", true, ""},
+		{"dead qualified method", "store.Store.Loads", true, "`store.Store.Load`"},
+		{"dead type", "store.Cache.Load", true, ""},
+		{"unexported call", "store.open()", true, "`store.Open`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &context.Context{
+				Dir: ".context",
+				Files: []context.FileInfo{{
+					Name:    "ARCHITECTURE.md",
+					Content: []byte("# Architecture\n\nSee `" + tt.ref + "` for details.\n"),
+				}},
+			}
+			report := runCheck(t, ctx, CheckSymbolReferences)
+
+			if !tt.dead {
+				if len(report.Warnings) != 0 {
+					t.Errorf("warnings = %+v, want none", report.Warnings)
+				}
+				return
+			}
+			if len(report.Warnings) != 1 {
+				t.Fatalf("warnings = %+v, want one", report.Warnings)
+			}
+			w := report.Warnings[0]
+			if w.Type != IssueDeadSymbol || w.Line != 3 || w.Path != tt.ref {
+				t.Errorf("warning = %+v", w)
+			}
+			if tt.suggest != "" && !strings.Contains(w.Message, tt.suggest) {
+				t.Errorf("message %q should suggest %s", w.Message, tt.suggest)
+			}
+		})
+	}
+}
+
+func TestCheckSymbolReferences_NoModule(t *testing.T) {
+	setupRC(t, "")
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{{
+			Name: "ARCHITECTURE.md", Content: []byte("`store.Missing`\n"),
+		}},
+	}
+	report := runCheck(t, ctx, CheckSymbolReferences)
+	if len(report.Warnings) != 0 || len(report.Passed) != 0 {
+		t.Errorf("check should not apply without go.mod: %+v", report)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"FileTask", "FileTask", 0},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPathReferences_GoSymbols(t *testing.T) {
+	symbolModule(t)
+	ctx := &context.Context{
+		Dir: ".context",
+		Files: []context.FileInfo{{
+			Name: "ARCHITECTURE.md",
+			Content: []byte("# Architecture\n\n" +
+				"`store.Open` returns a `Store.Path` store.\n" +
+				"`store.Nope` is a symbol, `docs/store.md` a file.\n"),
+		}},
+	}
+
+	report := runCheck(t, ctx, CheckPathReferences)
+	if len(report.Warnings) != 1 || report.Warnings[0].Path != "docs/store.md" {
+		t.Errorf("warnings = %+v, want only docs/store.md", report.Warnings)
+	}
+
+	report = runCheck(t, ctx, CheckSymbolReferences)
+	if len(report.Warnings) != 1 || report.Warnings[0].Path != "store.Nope" {
+		t.Errorf("warnings = %+v, want only store.Nope", report.Warnings)
+	}
+}
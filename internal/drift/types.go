@@ -1,5 +1,7 @@
 package drift
 
+import "github.com/ActiveMemory/ctx/internal/context"
+
 // IssueType categorizes a drift issue for grouping and filtering.
 type IssueType string
 
@@ -16,6 +18,18 @@ const (
 	IssueStaleAge IssueType = "stale_age"
 	// IssueEntryCount indicates a knowledge file has too many entries.
 	IssueEntryCount IssueType = "entry_count"
+	// IssueShadowedConstitution indicates a nested context layer defines a
+	// CONSTITUTION.md that is ignored in favor of the inherited one.
+	IssueShadowedConstitution IssueType = "shadowed_constitution"
+	// IssueDependencyCycle indicates tasks that depend on each other in a
+	// loop.
+	IssueDependencyCycle IssueType = "dependency_cycle"
+	// IssueDanglingDependency indicates a #depends or #blocks tag naming
+	// a task ID that does not exist.
+	IssueDanglingDependency IssueType = "dangling_dependency"
+	// IssueDeadSymbol indicates a Go symbol reference that no longer
+	// resolves.
+	IssueDeadSymbol IssueType = "dead_symbol"
 )
 
 // StatusType represents the overall status of a drift report.
@@ -46,6 +60,13 @@ const (
 	CheckFileAge CheckName = "file_age_check"
 	// CheckEntryCount checks whether knowledge files have excessive entries.
 	CheckEntryCount CheckName = "entry_count_check"
+	// CheckLayers validates how nested context layers are merged.
+	CheckLayers CheckName = "layer_check"
+	// CheckTaskDependencies validates #depends and #blocks task tags.
+	CheckTaskDependencies CheckName = "task_dependencies"
+	// CheckSymbolReferences validates that Go symbols in context files
+	// exist.
+	CheckSymbolReferences CheckName = "symbol_references"
 )
 
 // Issue represents a detected drift issue.
@@ -60,6 +81,8 @@ const (
 //   - Message: Human-readable description of the issue
 //   - Path: Referenced path that caused the issue, if applicable
 //   - Rule: Constitution rule that was violated, if applicable
+//   - Layer: Context directory the file belongs to, set only for files
+//     inherited from a parent layer of a nested context
 type Issue struct {
 	File    string    `json:"file"`
 	Line    int       `json:"line,omitempty"`
@@ -67,6 +90,7 @@ type Issue struct {
 	Message string    `json:"message"`
 	Path    string    `json:"path,omitempty"`
 	Rule    string    `json:"rule,omitempty"`
+	Layer   string    `json:"layer,omitempty"`
 }
 
 // Report represents the complete drift detection report.
@@ -77,8 +101,98 @@ type Issue struct {
 //   - Warnings: Non-critical issues that should be addressed
 //   - Violations: Critical issues that indicate constitution violations
 //   - Passed: Names of checks that are completed without issues
+//   - Baselined: Issues accepted in the baseline, left out of Warnings
+//     and Violations
+//   - Resolved: Baseline entries for issues that are no longer found
+//   - checked: Issue types the checks that ran could report; nil if
+//     unknown, as for a report built by hand
 type Report struct {
-	Warnings   []Issue     `json:"warnings"`
-	Violations []Issue     `json:"violations"`
-	Passed     []CheckName `json:"passed"`
+	Warnings   []Issue         `json:"warnings"`
+	Violations []Issue         `json:"violations"`
+	Passed     []CheckName     `json:"passed"`
+	Baselined  []Issue         `json:"baselined,omitempty"`
+	Resolved   []BaselineEntry `json:"resolved,omitempty"`
+	checked    []IssueType
+}
+
+// Baseline lists accepted drift issues, as written by
+// "ctx drift --update-baseline".
+//
+// Fields:
+//   - Created: RFC3339 time the baseline was written
+//   - Issues: The accepted issues
+type Baseline struct {
+	Created string          `json:"created"`
+	Issues  []BaselineEntry `json:"issues"`
+}
+
+// BaselineEntry identifies an accepted issue.
+//
+// Line numbers are left out so that an issue stays accepted when the
+// lines around it change, and numbers in the message are masked so that
+// it stays accepted as counts and ages grow.
+//
+// Fields:
+//   - Fingerprint: Hash of the other fields, used for matching
+//   - Type: Issue type
+//   - File: Context file, qualified with its layer if inherited
+//   - Rule: Constitution rule, if any
+//   - Message: Message as reported when the issue was accepted
+//   - Path: Referenced path or symbol, if any
+type BaselineEntry struct {
+	Fingerprint string    `json:"fingerprint"`
+	Type        IssueType `json:"type"`
+	File        string    `json:"file"`
+	Rule        string    `json:"rule,omitempty"`
+	Message     string    `json:"message"`
+	Path        string    `json:"path,omitempty"`
+}
+
+// Check is a named drift check.
+//
+// Checks are listed in registeredChecks and run in that order. Each one
+// only finds issues; Detect files them as warnings or violations by the
+// check's severity, which .ctxrc can override.
+type Check interface {
+	// Name returns the check's identifier, listed in Report.Passed when
+	// the check finds nothing.
+	Name() CheckName
+
+	// Description returns a one-line summary of what the check looks for.
+	Description() string
+
+	// Severity returns the default severity of the check's issues:
+	// StatusWarning or StatusViolation.
+	Severity() StatusType
+
+	// Issues returns the issue types the check reports.
+	Issues() []IssueType
+
+	// Options returns the check's tunable thresholds with their defaults.
+	Options() []Option
+
+	// Applies reports whether the check is relevant to the context; a
+	// check that does not apply is skipped and not listed as passed.
+	Applies(ctx *context.Context) bool
+
+	// Run inspects the context and returns the issues found.
+	Run(ctx *context.Context, opts Options) []Issue
+}
+
+// Option describes a tunable threshold of a check.
+//
+// Fields:
+//   - Name: Key under the check in the "drift:" section of .ctxrc
+//   - Default: Value used when .ctxrc does not set one
+//   - Description: What the value controls
+//   - Switch: The option is off (0) or on (1), set in .ctxrc as false
+//     or true
+type Option struct {
+	Name        string `json:"name"`
+	Default     int    `json:"default"`
+	Description string `json:"description"`
+	Switch      bool   `json:"switch,omitempty"`
 }
+
+// Options holds the resolved option values of a check, by name.
+type Options map[string]int
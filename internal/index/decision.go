@@ -0,0 +1,194 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Decision is a DECISIONS.md entry with its lifecycle fields.
+//
+// Fields:
+//   - EntryBlock: The parsed entry
+//   - Status: Lower-case status (see config.DecisionStatuses); entries
+//     without a status are accepted
+//   - Supersedes: Value of the "**Supersedes**:" field, if any
+//   - SupersededBy: Value of the "**Superseded by**:" field, if any
+type Decision struct {
+	EntryBlock
+	Status       string
+	Supersedes   string
+	SupersededBy string
+}
+
+// ParseDecisions parses the entries of DECISIONS.md content.
+//
+// Parameters:
+//   - content: The full content of DECISIONS.md
+//
+// Returns:
+//   - []Decision: Decisions in file order (may be empty)
+func ParseDecisions(content string) []Decision {
+	blocks := ParseEntryBlocks(content)
+	decisions := make([]Decision, 0, len(blocks))
+	for _, eb := range blocks {
+		status := eb.Status()
+		if status == "" {
+			status = config.DecisionStatusAccepted
+		}
+		supersedes, _ := eb.Field(config.DecisionFieldSupersedes)
+		supersededBy, _ := eb.Field(config.DecisionFieldSupersededBy)
+		decisions = append(decisions, Decision{
+			EntryBlock:   eb,
+			Status:       status,
+			Supersedes:   supersedes,
+			SupersededBy: supersededBy,
+		})
+	}
+	return decisions
+}
+
+// StatusLabel returns a status as written in a "**Status**:" field.
+//
+// Parameters:
+//   - status: Status in any case (e.g., "accepted")
+//
+// Returns:
+//   - string: Capitalized status (e.g., "Accepted")
+func StatusLabel(status string) string {
+	if status == "" {
+		return ""
+	}
+	return strings.ToUpper(status[:1]) + strings.ToLower(status[1:])
+}
+
+// Field returns the value of a "**Label**: value" line of the entry.
+//
+// Parameters:
+//   - label: Field label, matched case-insensitively (e.g., "Status")
+//
+// Returns:
+//   - string: Trimmed value; empty if the entry has no such field
+//   - int: Index of the field's line in Lines; -1 if there is none
+func (eb *EntryBlock) Field(label string) (string, int) {
+	for i, line := range eb.Lines {
+		m := config.RegExEntryField.FindStringSubmatch(strings.TrimSpace(line))
+		if m != nil && strings.EqualFold(strings.TrimSpace(m[1]), label) {
+			return strings.TrimSpace(m[2]), i
+		}
+	}
+	return "", -1
+}
+
+// FieldText returns the full text of a "**Label**: value" field.
+//
+// Unlike Field, which reads one line, the text runs on to the next
+// field line, so multi-paragraph values and lists are kept. Trailing
+// blank lines and an entry separator ("---") are dropped.
+//
+// Parameters:
+//   - label: Field label, matched case-insensitively
+//
+// Returns:
+//   - string: Field text; empty if the entry has no such field
+func (eb *EntryBlock) FieldText(label string) string {
+	value, start := eb.Field(label)
+	if start < 0 {
+		return ""
+	}
+	lines := []string{value}
+	for _, line := range eb.Lines[start+1:] {
+		if config.RegExEntryField.MatchString(strings.TrimSpace(line)) {
+			break
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) > 1 {
+		last := strings.TrimSpace(lines[len(lines)-1])
+		if last != "" && last != config.Separator {
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+	return strings.TrimSpace(strings.Join(lines, config.NewlineLF))
+}
+
+// Status returns the lifecycle status of a decision entry.
+//
+// The status is the first word of the "**Status**:" field, so the
+// template's "Accepted | Superseded | Deprecated" reads as accepted. A
+// "~~Superseded" marker in the body takes precedence over the field.
+//
+// Returns:
+//   - string: Lower-case status; empty if the entry states none
+func (eb *EntryBlock) Status() string {
+	if eb.hasSupersededMarker() {
+		return config.DecisionStatusSuperseded
+	}
+	value, _ := eb.Field(config.DecisionFieldStatus)
+	word, _, _ := strings.Cut(value, " ")
+	return strings.ToLower(strings.Trim(word, ".,;|"))
+}
+
+// WithField sets a "**Label**: value" field of the entry.
+//
+// An existing field is rewritten in place. A new field goes in its own
+// paragraph after the status line, or after the header if the entry has
+// no status.
+//
+// Parameters:
+//   - label: Field label (e.g., config.DecisionFieldSupersedes)
+//   - value: Field value
+//
+// Returns:
+//   - []string: Updated entry lines; Lines itself is not modified
+func (eb *EntryBlock) WithField(label, value string) []string {
+	line := "**" + label + "**: " + value
+	lines := slices.Clone(eb.Lines)
+	if _, i := eb.Field(label); i >= 0 {
+		lines[i] = line
+		return lines
+	}
+	at := 1
+	if _, i := eb.Field(config.DecisionFieldStatus); i >= 0 {
+		at = i + 1
+	}
+	return slices.Insert(lines, min(at, len(lines)), "", line)
+}
+
+// GenerateDecisionTable creates the Markdown index of DECISIONS.md.
+//
+// Like GenerateTable, with a third column for the decision status.
+//
+// Parameters:
+//   - decisions: Decisions to include
+//
+// Returns:
+//   - string: Markdown table (without markers) or empty string
+func GenerateDecisionTable(decisions []Decision) string {
+	if len(decisions) == 0 {
+		return ""
+	}
+
+	nl := config.NewlineLF
+	var sb strings.Builder
+	sb.WriteString("| Date | " + config.ColumnDecision + " | " +
+		config.ColumnStatus + " |" + nl)
+	sb.WriteString("|------|" + strings.Repeat("-", len(config.ColumnDecision)) +
+		"|" + strings.Repeat("-", len(config.ColumnStatus)) + "|" + nl)
+
+	for _, d := range decisions {
+		title := strings.ReplaceAll(d.Entry.Title, "|", "\\|")
+		sb.WriteString("| " + d.Entry.Date + " | " + title + " | " +
+			d.Status + " |" + nl)
+	}
+
+	return sb.String()
+}
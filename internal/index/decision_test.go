@@ -0,0 +1,143 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package index
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDecisions(t *testing.T) {
+	content := `# Decisions
+
+## [2026-02-10-093000] Use TOML
+
+**Status**: Proposed
+
+**Supersedes**: [2026-01-28-051426] Use YAML
+
+## [2026-01-28-051426] Use YAML
+
+**Status**: Superseded
+
+**Superseded by**: [2026-02-10-093000] Use TOML
+
+## [2026-01-20-120000] Old format
+
+**Status**: Accepted | Superseded | Deprecated
+
+## [2026-01-10-120000] No status
+
+Body.
+
+## [2026-01-05-120000] Struck out
+
+~~Superseded by something else~~
+`
+	got := ParseDecisions(content)
+	want := []struct {
+		status, supersedes, supersededBy string
+	}{
+		{"proposed", "[2026-01-28-051426] Use YAML", ""},
+		{"superseded", "", "[2026-02-10-093000] Use TOML"},
+		{"accepted", "", ""},
+		{"accepted", "", ""},
+		{"superseded", "", ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseDecisions() = %d decisions, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		d := got[i]
+		if d.Status != w.status || d.Supersedes != w.supersedes ||
+			d.SupersededBy != w.supersededBy {
+			t.Errorf("decision %d (%s) = %q/%q/%q, want %q/%q/%q", i,
+				d.Entry.Title, d.Status, d.Supersedes, d.SupersededBy,
+				w.status, w.supersedes, w.supersededBy)
+		}
+	}
+	if ref := got[0].Ref(); ref != "[2026-02-10-093000] Use TOML" {
+		t.Errorf("Ref() = %q", ref)
+	}
+}
+
+func TestEntryBlock_WithField(t *testing.T) {
+	eb := EntryBlock{Lines: []string{
+		"## [2026-01-28-051426] Use YAML",
+		"",
+		"**Status**: Accepted",
+		"",
+		"**Context**: Config",
+	}}
+
+	got := strings.Join(eb.WithField("Status", "Superseded"), "\n")
+	if !strings.Contains(got, "**Status**: Superseded") ||
+		strings.Contains(got, "Accepted") {
+		t.Errorf("WithField() should rewrite the status:\n%s", got)
+	}
+
+	got = strings.Join(eb.WithField("Superseded by", "[x] New"), "\n")
+	want := "**Status**: Accepted\n\n**Superseded by**: [x] New\n\n**Context**"
+	if !strings.Contains(got, want) {
+		t.Errorf("WithField() should add the field after the status:\n%s", got)
+	}
+	if len(eb.Lines) != 5 {
+		t.Error("WithField() must not modify Lines")
+	}
+
+	bare := EntryBlock{Lines: []string{"## [2026-01-28-051426] Use YAML"}}
+	got = strings.Join(bare.WithField("Status", "Deprecated"), "\n")
+	if got != "## [2026-01-28-051426] Use YAML\n\n**Status**: Deprecated" {
+		t.Errorf("WithField() on a bare entry = %q", got)
+	}
+}
+
+func TestGenerateDecisionTable(t *testing.T) {
+	if got := GenerateDecisionTable(nil); got != "" {
+		t.Errorf("GenerateDecisionTable(nil) = %q, want empty", got)
+	}
+
+	got := GenerateDecisionTable([]Decision{{
+		EntryBlock: EntryBlock{Entry: Entry{Date: "2026-01-28", Title: "A | B"}},
+		Status:     "deprecated",
+	}})
+	want := `| Date | Decision | Status |
+|------|--------|------|
+| 2026-01-28 | A \| B | deprecated |
+`
+	if got != want {
+		t.Errorf("GenerateDecisionTable() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEntryBlock_FieldText(t *testing.T) {
+	eb := EntryBlock{Lines: strings.Split(`## [2026-01-28-051426] Use YAML
+
+**Status**: Accepted
+
+**Context**: First paragraph.
+
+Second paragraph.
+
+**Consequences**:
+
+* Good
+* Bad
+
+---
+`, "\n")}
+
+	if got := eb.FieldText("Context"); got != "First paragraph.\n\nSecond paragraph." {
+		t.Errorf("FieldText(Context) = %q", got)
+	}
+	if got := eb.FieldText("consequences"); got != "* Good\n* Bad" {
+		t.Errorf("FieldText(consequences) = %q", got)
+	}
+	if got := eb.FieldText("Rationale"); got != "" {
+		t.Errorf("FieldText(Rationale) = %q, want empty", got)
+	}
+}
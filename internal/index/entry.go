@@ -30,7 +30,8 @@ type EntryBlock struct {
 // ParseEntryBlocks splits file content into discrete entry blocks.
 //
 // Each block starts at a "## [YYYY-MM-DD-HHMMSS] Title" header and extends
-// to the line before the next entry header or end of content.
+// to the line before the next entry header or end of content. A #pin or
+// #nopacket marker in the header sets Entry.Pinned or Entry.NoPacket.
 //
 // Parameters:
 //   - content: The full file content
@@ -57,11 +58,7 @@ func ParseEntryBlocks(content string) []EntryBlock {
 		if len(matches) == 4 {
 			headers = append(headers, headerPos{
 				lineIdx: i,
-				entry: Entry{
-					Timestamp: matches[1] + "-" + matches[2],
-					Date:      matches[1],
-					Title:     matches[3],
-				},
+				entry:   newEntry(matches[1], matches[2], matches[3]),
 			})
 		}
 	}
@@ -94,14 +91,56 @@ func ParseEntryBlocks(content string) []EntryBlock {
 	return blocks
 }
 
+// Ref returns the reference other entries use to link to the entry.
+//
+// Returns:
+//   - string: "[YYYY-MM-DD-HHMMSS] Title"
+func (eb *EntryBlock) Ref() string {
+	return "[" + eb.Entry.Timestamp + "] " + eb.Entry.Title
+}
+
+// IsRef reports whether a reference names the entry by timestamp and
+// title, which tells apart entries written in the same second.
+//
+// The brackets around the timestamp are optional; the title is
+// compared ignoring case and extra whitespace.
+//
+// Parameters:
+//   - ref: Reference such as "[2026-01-28-143022] Use SQLite"
+//
+// Returns:
+//   - bool: True if ref is the entry's timestamp followed by its title
+func (eb *EntryBlock) IsRef(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if rest, ok := strings.CutPrefix(ref, "["); ok {
+		ts, title, found := strings.Cut(rest, "]")
+		if !found {
+			return false
+		}
+		ref = ts + " " + title
+	}
+	ts, title, _ := strings.Cut(ref, " ")
+	norm := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	return ts == eb.Entry.Timestamp &&
+		strings.EqualFold(norm(title), norm(eb.Entry.Title))
+}
+
 // IsSuperseded checks whether this entry has been marked as superseded.
 //
 // An entry is superseded when its body contains a line starting with
-// "~~Superseded" (strikethrough prefix).
+// "~~Superseded" (strikethrough prefix), or its status is Superseded.
 //
 // Returns:
-//   - bool: True if the entry contains a superseded marker
+//   - bool: True if the entry contains a superseded marker or status
 func (eb *EntryBlock) IsSuperseded() bool {
+	return eb.Status() == config.DecisionStatusSuperseded
+}
+
+// hasSupersededMarker checks for a line starting with "~~Superseded".
+//
+// Returns:
+//   - bool: True if the entry contains the strikethrough marker
+func (eb *EntryBlock) hasSupersededMarker() bool {
 	for _, line := range eb.Lines {
 		trimmed := strings.TrimSpace(line)
 		if strings.HasPrefix(trimmed, "~~Superseded") {
@@ -111,6 +150,46 @@ func (eb *EntryBlock) IsSuperseded() bool {
 	return false
 }
 
+// ContentEnd returns the line after the entry's last content line.
+//
+// Unlike EndIndex, this excludes the "---" separator that "ctx add"
+// writes between entries, so text appended here stays inside the entry.
+//
+// Returns:
+//   - int: Zero-based line index in the file (exclusive end)
+func (eb *EntryBlock) ContentEnd() int {
+	end := len(eb.Lines)
+	for end > 1 {
+		last := strings.TrimSpace(eb.Lines[end-1])
+		if last != "" && last != config.Separator {
+			break
+		}
+		end--
+	}
+	return eb.StartIndex + end
+}
+
+// Text returns the words of the entry: the title and the field
+// values, without field labels and separators. Similarity between
+// entries is computed on this text.
+//
+// Returns:
+//   - string: Text of the entry
+func (eb *EntryBlock) Text() string {
+	parts := []string{eb.Entry.Title}
+	for _, line := range eb.Lines[min(1, len(eb.Lines)):] {
+		line = strings.TrimSpace(line)
+		if line == config.Separator {
+			continue
+		}
+		if m := config.RegExEntryField.FindStringSubmatch(line); m != nil {
+			line = m[2]
+		}
+		parts = append(parts, line)
+	}
+	return strings.Join(parts, " ")
+}
+
 // BlockContent joins the entry's lines into a single string.
 //
 // Returns:
@@ -121,6 +121,16 @@ func TestEntryBlock_IsSuperseded(t *testing.T) {
 			lines: []string{"## [2026-01-15-120000] Test", "  ~~Superseded by newer~~"},
 			want:  true,
 		},
+		{
+			name:  "superseded status",
+			lines: []string{"## [2026-01-15-120000] Test", "", "**Status**: Superseded"},
+			want:  true,
+		},
+		{
+			name:  "accepted status",
+			lines: []string{"## [2026-01-15-120000] Test", "", "**Status**: Accepted"},
+			want:  false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -150,3 +160,56 @@ func TestEntryBlock_BlockContent(t *testing.T) {
 		t.Error("BlockContent should contain the body")
 	}
 }
+
+func TestEntryBlock_ContentEnd(t *testing.T) {
+	eb := &EntryBlock{
+		Lines: []string{
+			"## [2026-01-15-120000] Test",
+			"",
+			"Body text here.",
+			"",
+			"---",
+			"",
+		},
+		StartIndex: 10,
+	}
+	if got := eb.ContentEnd(); got != 13 {
+		t.Errorf("ContentEnd() = %d, want 13", got)
+	}
+
+	eb.Lines = eb.Lines[:1]
+	if got := eb.ContentEnd(); got != 11 {
+		t.Errorf("ContentEnd() of a bare header = %d, want 11", got)
+	}
+}
+
+func TestParseEntryBlocks_PacketMarkers(t *testing.T) {
+	content := `# Decisions
+
+## [2026-01-15-120000] Always use YAML #pin
+
+Body.
+
+## [2026-01-14-120000] Old workaround #nopacket
+
+Body.
+
+## [2026-01-13-120000] Keep #pinned words
+`
+	blocks := ParseEntryBlocks(content)
+	if len(blocks) != 3 {
+		t.Fatalf("ParseEntryBlocks() = %d blocks, want 3", len(blocks))
+	}
+	if e := blocks[0].Entry; !e.Pinned || e.NoPacket || e.Title != "Always use YAML" {
+		t.Errorf("pinned entry = %+v", e)
+	}
+	if e := blocks[1].Entry; e.Pinned || !e.NoPacket || e.Title != "Old workaround" {
+		t.Errorf("nopacket entry = %+v", e)
+	}
+	if e := blocks[2].Entry; e.Pinned || e.Title != "Keep #pinned words" {
+		t.Errorf("unmarked entry = %+v", e)
+	}
+	if !strings.HasSuffix(blocks[0].Lines[0], "#pin") {
+		t.Error("markers should stay in the block lines")
+	}
+}
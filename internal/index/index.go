@@ -23,17 +23,22 @@ import (
 // Fields:
 //   - Timestamp: Full timestamp (YYYY-MM-DD-HHMMSS)
 //   - Date: Date only (YYYY-MM-DD)
-//   - Title: Entry title
+//   - Title: Entry title, without packet markers
+//   - Pinned: True if the header carries a #pin marker
+//   - NoPacket: True if the header carries a #nopacket marker
 type Entry struct {
 	Timestamp string
 	Date      string
 	Title     string
+	Pinned    bool
+	NoPacket  bool
 }
 
 // ParseHeaders extracts all entries from file content.
 //
 // It scans for headers matching the pattern "## [YYYY-MM-DD-HHMMSS] Title"
-// and returns them in the order they appear in the file.
+// and returns them in the order they appear in the file. Packet markers
+// (#pin, #nopacket) are stripped from titles and recorded as flags.
 //
 // Parameters:
 //   - content: The full content of a context file
@@ -46,20 +51,65 @@ func ParseHeaders(content string) []Entry {
 	matches := config.RegExEntryHeader.FindAllStringSubmatch(content, -1)
 	for _, match := range matches {
 		if len(match) == 4 {
-			date := match[1]
-			time := match[2]
-			title := match[3]
-			entries = append(entries, Entry{
-				Timestamp: date + "-" + time,
-				Date:      date,
-				Title:     title,
-			})
+			entries = append(entries, newEntry(match[1], match[2], match[3]))
 		}
 	}
 
 	return entries
 }
 
+// newEntry builds an Entry from the parts of a matched header.
+//
+// Parameters:
+//   - date: Date part (YYYY-MM-DD)
+//   - time: Time part (HHMMSS)
+//   - title: Raw title, possibly with packet markers
+//
+// Returns:
+//   - Entry: Parsed entry with markers stripped from the title
+func newEntry(date, time, title string) Entry {
+	title, pinned, noPacket := ParsePacketMarkers(title)
+	return Entry{
+		Timestamp: date + "-" + time,
+		Date:      date,
+		Title:     title,
+		Pinned:    pinned,
+		NoPacket:  noPacket,
+	}
+}
+
+// ParsePacketMarkers strips #pin and #nopacket markers from text.
+//
+// Markers must be whole words; "#pinned" is left alone. The remaining
+// words are joined with single spaces.
+//
+// Parameters:
+//   - text: Entry title or convention bullet text
+//
+// Returns:
+//   - string: Text without markers
+//   - bool: True if a #pin marker was present
+//   - bool: True if a #nopacket marker was present
+func ParsePacketMarkers(text string) (string, bool, bool) {
+	if !strings.Contains(text, config.MarkerPin) &&
+		!strings.Contains(text, config.MarkerNoPacket) {
+		return text, false, false
+	}
+	var words []string
+	pinned, noPacket := false, false
+	for _, w := range strings.Fields(text) {
+		switch w {
+		case config.MarkerPin:
+			pinned = true
+		case config.MarkerNoPacket:
+			noPacket = true
+		default:
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, " "), pinned, noPacket
+}
+
 // GenerateTable creates a Markdown table index from entries.
 //
 // The table has two columns: Date and the specified column header.
@@ -112,8 +162,21 @@ func GenerateTable(entries []Entry, columnHeader string) string {
 // Returns:
 //   - string: Updated content with regenerated index
 func Update(content, fileHeader, columnHeader string) string {
-	entries := ParseHeaders(content)
-	indexContent := GenerateTable(entries, columnHeader)
+	return updateTable(
+		content, fileHeader, GenerateTable(ParseHeaders(content), columnHeader),
+	)
+}
+
+// updateTable replaces the index in file content with a generated table.
+//
+// Parameters:
+//   - content: The full content of the file
+//   - fileHeader: The main header to insert after (e.g., "# Decisions")
+//   - indexContent: Table to write; empty removes the index
+//
+// Returns:
+//   - string: Updated content
+func updateTable(content, fileHeader, indexContent string) string {
 	nl := config.NewlineLF
 
 	// Check if markers already exist
@@ -178,13 +241,18 @@ func Update(content, fileHeader, columnHeader string) string {
 
 // UpdateDecisions regenerates the decision index in DECISIONS.md content.
 //
+// The decision index has a status column (see GenerateDecisionTable).
+//
 // Parameters:
 //   - content: The full content of DECISIONS.md
 //
 // Returns:
 //   - string: Updated content with regenerated index
 func UpdateDecisions(content string) string {
-	return Update(content, config.HeadingDecisions, config.ColumnDecision)
+	return updateTable(
+		content, config.HeadingDecisions,
+		GenerateDecisionTable(ParseDecisions(content)),
+	)
 }
 
 // UpdateLearnings regenerates the learning index in LEARNINGS.md content.
@@ -196,8 +196,8 @@ func TestUpdateDecisions(t *testing.T) {
 			wantHas: []string{
 				config.IndexStart,
 				config.IndexEnd,
-				"| Date | Decision |",
-				"| 2026-01-28 | Test decision |",
+				"| Date | Decision | Status |",
+				"| 2026-01-28 | Test decision | accepted |",
 				"## [2026-01-28-051426] Test decision",
 			},
 		},
@@ -431,3 +431,25 @@ func TestUpdateLearnings_Idempotent(t *testing.T) {
 		t.Errorf("UpdateLearnings is not idempotent\nFirst:\n%s\nSecond:\n%s", first, second)
 	}
 }
+
+func TestParsePacketMarkers(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     string
+		pinned   bool
+		noPacket bool
+	}{
+		{"Plain title", "Plain title", false, false},
+		{"Title #pin", "Title", true, false},
+		{"#nopacket Use tabs", "Use tabs", false, true},
+		{"Both #pin #nopacket", "Both", true, true},
+		{"Mentions #pinned", "Mentions #pinned", false, false},
+	}
+	for _, tt := range tests {
+		got, pinned, noPacket := ParsePacketMarkers(tt.in)
+		if got != tt.want || pinned != tt.pinned || noPacket != tt.noPacket {
+			t.Errorf("ParsePacketMarkers(%q) = %q, %v, %v; want %q, %v, %v",
+				tt.in, got, pinned, noPacket, tt.want, tt.pinned, tt.noPacket)
+		}
+	}
+}
@@ -6,6 +6,8 @@
 
 package rc
 
+import "github.com/ActiveMemory/ctx/internal/config"
+
 // DefaultTokenBudget is the default token budget when not configured.
 const DefaultTokenBudget = 8000
 
@@ -23,3 +25,13 @@ const DefaultEntryCountDecisions = 20
 // DefaultConventionLineCount is the line count threshold for CONVENTIONS.md.
 // Conventions lack dated entry headers, so line count is used instead.
 const DefaultConventionLineCount = 200
+
+// DefaultTokenizer is the tokenizer used for budget computations when
+// .ctxrc does not set one.
+const DefaultTokenizer = config.TokenizerBPE
+
+// DefaultDuplicateThreshold is the shingle similarity above which a new
+// learning or decision is a near-duplicate of an existing entry.
+// Rewordings of one entry score about 0.35-0.6; unrelated entries of
+// the same project rarely pass 0.2.
+const DefaultDuplicateThreshold = 0.3
@@ -0,0 +1,122 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package rc
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Keys of a drift check's settings that are not options.
+const (
+	driftKeyEnabled  = "enabled"
+	driftKeySeverity = "severity"
+)
+
+// Drift returns the .ctxrc settings for a drift check or issue type.
+//
+// Parameters:
+//   - name: Check name (e.g., "file_age_check") or issue type
+//     (e.g., "stale_age")
+//
+// Returns:
+//   - *DriftCheck: The settings; nil if none are configured (or they
+//     were dropped as invalid at load time)
+func Drift(name string) *DriftCheck {
+	return RC().Drift[name]
+}
+
+// DriftNames returns the check names and issue types configured under
+// "drift:" in .ctxrc.
+//
+// Returns:
+//   - []string: Sorted keys; nil if none are configured
+func DriftNames() []string {
+	var names []string
+	for name := range RC().Drift {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnmarshalYAML reads the settings of a drift check.
+//
+// Option values are integers; true and false are read as 1 and 0, so
+// switches like "scan_journal: true" read naturally.
+//
+// Parameters:
+//   - value: YAML mapping under the check's name
+//
+// Returns:
+//   - error: Non-nil if the node is not a mapping or a value has the
+//     wrong type
+func (d *DriftCheck) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]yaml.Node
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	for key, node := range raw {
+		switch key {
+		case driftKeyEnabled:
+			var enabled bool
+			if err := node.Decode(&enabled); err != nil {
+				return err
+			}
+			d.Enabled = &enabled
+		case driftKeySeverity:
+			if err := node.Decode(&d.Severity); err != nil {
+				return err
+			}
+		default:
+			var v int
+			var on bool
+			if err := node.Decode(&v); err != nil {
+				if boolErr := node.Decode(&on); boolErr != nil {
+					return fmt.Errorf("%s: want a number or true/false", key)
+				}
+				if on {
+					v = 1
+				}
+			}
+			if d.Options == nil {
+				d.Options = make(map[string]int)
+			}
+			d.Options[key] = v
+		}
+	}
+	return nil
+}
+
+// Validate checks the settings for an unknown severity and negative
+// options.
+//
+// Option names are not checked here; only the drift package knows
+// which options a check takes.
+//
+// Returns:
+//   - error: Description of the first problem found; nil if valid
+func (d *DriftCheck) Validate() error {
+	if d.Severity != "" && !slices.Contains(config.DriftSeverities, d.Severity) {
+		return fmt.Errorf(
+			"unknown severity %q (valid: %s)",
+			d.Severity, strings.Join(config.DriftSeverities, ", "),
+		)
+	}
+	for name, v := range d.Options {
+		if v < 0 {
+			return fmt.Errorf("%s must not be negative, got %d", name, v)
+		}
+	}
+	return nil
+}
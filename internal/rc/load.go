@@ -33,6 +33,39 @@ func loadRC() *CtxRC {
 		}
 	}
 
+	switch cfg.Tokenizer {
+	case "", config.TokenizerBPE, config.TokenizerHeuristic:
+	default:
+		fmt.Fprintf(os.Stderr, "ctx: warning: unknown tokenizer %q in %s (using %s)\n",
+			cfg.Tokenizer, config.FileContextRC, DefaultTokenizer)
+		cfg.Tokenizer = DefaultTokenizer
+	}
+
+	for name, profile := range cfg.Profiles {
+		if profile == nil {
+			profile = &AgentProfile{}
+			cfg.Profiles[name] = profile
+		}
+		profile.Name = name
+		if err := profile.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "ctx: warning: ignoring profile %q in %s: %v\n",
+				name, config.FileContextRC, err)
+			delete(cfg.Profiles, name)
+		}
+	}
+
+	for name, check := range cfg.Drift {
+		if check == nil {
+			check = &DriftCheck{}
+			cfg.Drift[name] = check
+		}
+		if err := check.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "ctx: warning: ignoring drift.%s in %s: %v\n",
+				name, config.FileContextRC, err)
+			delete(cfg.Drift, name)
+		}
+	}
+
 	// Apply environment variable overrides
 	if envDir := os.Getenv(config.EnvCtxDir); envDir != "" {
 		cfg.ContextDir = envDir
@@ -0,0 +1,112 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package rc
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Profile returns a named agent profile from .ctxrc.
+//
+// Parameters:
+//   - name: Profile name (e.g., "reviewer")
+//
+// Returns:
+//   - *AgentProfile: The profile; nil if it is not defined (or was
+//     dropped as invalid at load time)
+func Profile(name string) *AgentProfile {
+	return RC().Profiles[name]
+}
+
+// ProfileNames returns the names of the valid agent profiles.
+//
+// Returns:
+//   - []string: Sorted profile names; nil if none are defined
+func ProfileNames() []string {
+	var names []string
+	for name := range RC().Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Includes reports whether the profile includes a packet section.
+//
+// A nil profile, or one without a sections list, includes everything.
+//
+// Parameters:
+//   - section: Section name (see config.PacketSections)
+//
+// Returns:
+//   - bool: True if the section belongs in the packet
+func (p *AgentProfile) Includes(section string) bool {
+	if p == nil || len(p.Sections) == 0 {
+		return true
+	}
+	return slices.Contains(p.Sections, section)
+}
+
+// Weight returns the profile's weight for an entry section.
+//
+// Parameters:
+//   - section: config.PacketSectionDecisions or
+//     config.PacketSectionLearnings
+//
+// Returns:
+//   - float64: Configured weight; 1 for a nil profile or a missing entry
+func (p *AgentProfile) Weight(section string) float64 {
+	if p == nil {
+		return 1
+	}
+	if w, ok := p.Weights[section]; ok {
+		return w
+	}
+	return 1
+}
+
+// Validate checks a profile for an invalid name, unknown sections,
+// weights for sections that do not share a budget, non-positive weights
+// and a negative budget.
+//
+// Returns:
+//   - error: Description of the first problem found; nil if valid
+func (p *AgentProfile) Validate() error {
+	if !config.RegExProfileName.MatchString(p.Name) {
+		return fmt.Errorf(
+			"invalid name %q: use lowercase letters, digits, - and _", p.Name,
+		)
+	}
+	for _, s := range p.Sections {
+		if !slices.Contains(config.PacketSections, s) {
+			return fmt.Errorf(
+				"unknown section %q (valid: %s)",
+				s, strings.Join(config.PacketSections, ", "),
+			)
+		}
+	}
+	for s, w := range p.Weights {
+		if !slices.Contains(config.PacketWeightedSections, s) {
+			return fmt.Errorf(
+				"weight for %q: only %s can be weighted",
+				s, strings.Join(config.PacketWeightedSections, " and "),
+			)
+		}
+		if w <= 0 {
+			return fmt.Errorf("weight for %q must be positive, got %g", s, w)
+		}
+	}
+	if p.Budget < 0 {
+		return fmt.Errorf("budget must not be negative, got %d", p.Budget)
+	}
+	return nil
+}
@@ -28,6 +28,8 @@ func Default() *CtxRC {
 		EntryCountLearnings: DefaultEntryCountLearnings,
 		EntryCountDecisions: DefaultEntryCountDecisions,
 		ConventionLineCount: DefaultConventionLineCount,
+		Tokenizer:           DefaultTokenizer,
+		DuplicateThreshold:  DefaultDuplicateThreshold,
 	}
 }
 
@@ -139,6 +141,31 @@ func ConventionLineCount() int {
 	return RC().ConventionLineCount
 }
 
+// Tokenizer returns the configured token counting backend.
+//
+// Returns "bpe" (default) when the field is not set in .ctxrc.
+//
+// Returns:
+//   - string: Tokenizer identifier (config.TokenizerBPE or
+//     config.TokenizerHeuristic)
+func Tokenizer() string {
+	if t := RC().Tokenizer; t != "" {
+		return t
+	}
+	return DefaultTokenizer
+}
+
+// DuplicateThreshold returns the near-duplicate similarity threshold.
+//
+// Returns 0 if the check is disabled. Default: 0.3.
+//
+// Returns:
+//   - float64: Similarity (0-1) at or above which a new entry is
+//     refused as a near-duplicate
+func DuplicateThreshold() float64 {
+	return max(RC().DuplicateThreshold, 0)
+}
+
 // AllowOutsideCwd returns whether boundary validation should be skipped.
 //
 // Returns false (default) when the field is not set in .ctxrc.
@@ -161,6 +188,17 @@ func OverrideContextDir(dir string) {
 	rcOverrideDir = dir
 }
 
+// ContextDirOverridden reports whether the context directory was set
+// explicitly via the --context-dir CLI flag.
+//
+// Returns:
+//   - bool: True if OverrideContextDir was called with a non-empty path
+func ContextDirOverridden() bool {
+	rcMu.RLock()
+	defer rcMu.RUnlock()
+	return rcOverrideDir != ""
+}
+
 // Reset clears the cached configuration, forcing reload on the next access.
 // This is primarily useful for testing.
 func Reset() {
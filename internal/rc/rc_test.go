@@ -9,8 +9,11 @@ package rc
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/ActiveMemory/ctx/internal/config"
 )
 
@@ -501,3 +504,254 @@ func TestGetRC_NegativeEnvBudget(t *testing.T) {
 		t.Errorf("TokenBudget = %d, want %d (default on negative env)", rc.TokenBudget, DefaultTokenBudget)
 	}
 }
+
+func TestTokenizer_Default(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	Reset()
+
+	if got := Tokenizer(); got != config.TokenizerBPE {
+		t.Errorf("Tokenizer() = %q, want %q", got, config.TokenizerBPE)
+	}
+}
+
+func TestTokenizer_FromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	_ = os.WriteFile(
+		filepath.Join(tempDir, ".ctxrc"), []byte("tokenizer: heuristic\n"), 0600,
+	)
+	Reset()
+
+	if got := Tokenizer(); got != config.TokenizerHeuristic {
+		t.Errorf("Tokenizer() = %q, want %q", got, config.TokenizerHeuristic)
+	}
+}
+
+func TestTokenizer_UnknownFallsBack(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	_ = os.WriteFile(
+		filepath.Join(tempDir, ".ctxrc"), []byte("tokenizer: sentencepiece\n"), 0600,
+	)
+	Reset()
+
+	if got := Tokenizer(); got != DefaultTokenizer {
+		t.Errorf("Tokenizer() = %q, want %q", got, DefaultTokenizer)
+	}
+}
+
+func TestDuplicateThreshold(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	Reset()
+	if got := DuplicateThreshold(); got != DefaultDuplicateThreshold {
+		t.Errorf("DuplicateThreshold() = %v, want %v", got, DefaultDuplicateThreshold)
+	}
+
+	_ = os.WriteFile(
+		filepath.Join(tempDir, ".ctxrc"), []byte("duplicate_threshold: 0\n"), 0600,
+	)
+	Reset()
+	if got := DuplicateThreshold(); got != 0 {
+		t.Errorf("DuplicateThreshold() = %v, want 0 (disabled)", got)
+	}
+}
+
+func TestProfiles_FromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	rcContent := `profiles:
+  reviewer:
+    sections: [constitution, conventions, decisions]
+    weights:
+      decisions: 2
+    budget: 3000
+    instruction: Review against the conventions.
+  docs:
+    sections: [glossary, architecture]
+  broken:
+    sections: [constitution, secrets]
+  docs writer:
+    sections: [glossary]
+`
+	_ = os.WriteFile(filepath.Join(tempDir, ".ctxrc"), []byte(rcContent), 0600)
+	Reset()
+
+	if got := strings.Join(ProfileNames(), ","); got != "docs,reviewer" {
+		t.Errorf("ProfileNames() = %q, want %q", got, "docs,reviewer")
+	}
+
+	p := Profile("reviewer")
+	if p == nil {
+		t.Fatal("Profile(reviewer) = nil")
+	}
+	if p.Name != "reviewer" || p.Budget != 3000 ||
+		p.Instruction != "Review against the conventions." {
+		t.Errorf("reviewer = %+v", p)
+	}
+	if !p.Includes(config.PacketSectionDecisions) ||
+		p.Includes(config.PacketSectionTasks) {
+		t.Error("reviewer sections not applied")
+	}
+	if p.Weight(config.PacketSectionDecisions) != 2 ||
+		p.Weight(config.PacketSectionLearnings) != 1 {
+		t.Error("reviewer weights not applied")
+	}
+
+	if Profile("broken") != nil {
+		t.Error("invalid profile should be dropped at load time")
+	}
+	if Profile("docs writer") != nil {
+		t.Error("profile with an invalid name should be dropped at load time")
+	}
+}
+
+func TestAgentProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile AgentProfile
+		wantErr string
+	}{
+		{"empty", AgentProfile{Name: "docs"}, ""},
+		{"all sections", AgentProfile{Name: "docs", Sections: config.PacketSections}, ""},
+		{"unknown section", AgentProfile{Name: "docs", Sections: []string{"secrets"}}, "unknown section"},
+		{
+			"unweighted section",
+			AgentProfile{Name: "docs", Weights: map[string]float64{"tasks": 2}},
+			"only decisions and learnings",
+		},
+		{
+			"zero weight",
+			AgentProfile{Name: "docs", Weights: map[string]float64{"learnings": 0}},
+			"must be positive",
+		},
+		{"negative budget", AgentProfile{Name: "docs", Budget: -1}, "must not be negative"},
+		{"name with digits", AgentProfile{Name: "code-review_2"}, ""},
+		{"name with space", AgentProfile{Name: "docs writer"}, "invalid name"},
+		{"name with shell", AgentProfile{Name: "x;rm -rf ~"}, "invalid name"},
+		{"uppercase name", AgentProfile{Name: "Docs"}, "invalid name"},
+		{"leading dash", AgentProfile{Name: "-docs"}, "invalid name"},
+		{"empty name", AgentProfile{}, "invalid name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgentProfile_NilDefaults(t *testing.T) {
+	var p *AgentProfile
+	if !p.Includes(config.PacketSectionTasks) {
+		t.Error("nil profile should include every section")
+	}
+	if p.Weight(config.PacketSectionDecisions) != 1 {
+		t.Error("nil profile should weigh every section 1")
+	}
+}
+
+func TestDrift_FromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	_ = os.Chdir(tempDir)
+	defer func() { _ = os.Chdir(origDir) }()
+
+	rcContent := `drift:
+  file_age_check:
+    max_age_days: 60
+    severity: violation
+  dead_path:
+    enabled: false
+  staleness:
+    severity: fatal
+`
+	_ = os.WriteFile(filepath.Join(tempDir, ".ctxrc"), []byte(rcContent), 0600)
+	Reset()
+	defer Reset()
+
+	if got := strings.Join(DriftNames(), ","); got != "dead_path,file_age_check" {
+		t.Errorf("DriftNames() = %q, want %q", got, "dead_path,file_age_check")
+	}
+
+	age := Drift("file_age_check")
+	if age == nil || age.Severity != config.DriftSeverityViolation ||
+		age.Options["max_age_days"] != 60 || age.Enabled != nil {
+		t.Errorf("file_age_check = %+v", age)
+	}
+	if dead := Drift("dead_path"); dead == nil || dead.Enabled == nil || *dead.Enabled {
+		t.Errorf("dead_path = %+v, want disabled", dead)
+	}
+	if Drift("staleness") != nil {
+		t.Error("settings with an unknown severity should be dropped at load time")
+	}
+}
+
+func TestDriftCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   DriftCheck
+		wantErr string
+	}{
+		{"empty", DriftCheck{}, ""},
+		{"warning", DriftCheck{Severity: config.DriftSeverityWarning}, ""},
+		{"unknown severity", DriftCheck{Severity: "error"}, "unknown severity"},
+		{
+			"negative option",
+			DriftCheck{Options: map[string]int{"max_age_days": -1}},
+			"must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDriftCheck_UnmarshalYAML(t *testing.T) {
+	var d DriftCheck
+	if err := yaml.Unmarshal([]byte("scan_journal: true\nmax_age_days: 7\n"), &d); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if d.Options["scan_journal"] != 1 || d.Options["max_age_days"] != 7 {
+		t.Errorf("Options = %v", d.Options)
+	}
+	if err := yaml.Unmarshal([]byte("max_age_days: soon\n"), &d); err == nil ||
+		!strings.Contains(err.Error(), "max_age_days") {
+		t.Errorf("Unmarshal(soon) error = %v", err)
+	}
+}
@@ -16,6 +16,12 @@ package rc
 //   - ArchiveAfterDays: Days before archiving completed tasks (default 7)
 //   - ScratchpadEncrypt: Whether to encrypt the scratchpad (default true)
 //   - AllowOutsideCwd: Skip boundary validation for external context dirs (default false)
+//   - Tokenizer: Token counting backend, "bpe" or "heuristic" (default "bpe")
+//   - DuplicateThreshold: Similarity above which "ctx add" treats a
+//     learning or decision as a duplicate (default 0.3, 0 disables)
+//   - Profiles: Named ctx agent profiles (see AgentProfile)
+//   - Drift: Drift check settings by check name or issue type (see
+//     DriftCheck)
 type CtxRC struct {
 	ContextDir          string   `yaml:"context_dir"`
 	TokenBudget         int      `yaml:"token_budget"`
@@ -27,4 +33,46 @@ type CtxRC struct {
 	EntryCountLearnings int      `yaml:"entry_count_learnings"`
 	EntryCountDecisions int      `yaml:"entry_count_decisions"`
 	ConventionLineCount int      `yaml:"convention_line_count"`
+	Tokenizer           string   `yaml:"tokenizer"`
+	DuplicateThreshold  float64  `yaml:"duplicate_threshold"`
+
+	Profiles map[string]*AgentProfile `yaml:"profiles"`
+	Drift    map[string]*DriftCheck   `yaml:"drift"`
+}
+
+// AgentProfile configures the context packet for one kind of agent
+// (e.g., implementer, reviewer, docs writer).
+//
+// Fields:
+//   - Name: Profile name (the key under "profiles" in .ctxrc)
+//   - Sections: Packet sections to include (see config.PacketSections);
+//     empty means all
+//   - Weights: Relative weight per entry section (decisions, learnings)
+//     when the entry budget is split; missing sections weigh 1
+//   - Budget: Token budget; 0 means the default budget
+//   - Instruction: Instruction that closes the packet; empty means the
+//     default instruction
+type AgentProfile struct {
+	Name        string             `yaml:"-"`
+	Sections    []string           `yaml:"sections"`
+	Weights     map[string]float64 `yaml:"weights"`
+	Budget      int                `yaml:"budget"`
+	Instruction string             `yaml:"instruction"`
+}
+
+// DriftCheck configures one drift check, or the issues of one type.
+//
+// Any key other than enabled and severity sets a check option, such as
+// max_age_days for file_age_check (see UnmarshalYAML).
+//
+// Fields:
+//   - Enabled: Whether the check runs, or the issues are reported; nil
+//     keeps the default (enabled)
+//   - Severity: "warning" or "violation"; empty keeps the check's
+//     default
+//   - Options: Check options by name
+type DriftCheck struct {
+	Enabled  *bool          `yaml:"enabled"`
+	Severity string         `yaml:"severity"`
+	Options  map[string]int `yaml:"-"`
 }
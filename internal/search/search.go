@@ -0,0 +1,226 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package search provides a small in-memory full-text index ranked with
+// Okapi BM25.
+//
+// Text is split into lowercase terms (identifiers are also split at
+// camelCase and snake_case boundaries), stop words are dropped, and
+// each term is reduced with the Porter stemmer, so "parsing", "parsed"
+// and "parses" all match a query for "parse".
+package search
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// BM25 tuning constants (the common Lucene/Elasticsearch defaults).
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// stopWords are common English words that carry no ranking signal.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true,
+	"at": true, "be": true, "but": true, "by": true, "for": true,
+	"from": true, "has": true, "have": true, "how": true, "i": true,
+	"if": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "of": true, "on": true, "or": true, "so": true,
+	"that": true, "the": true, "their": true, "then": true,
+	"there": true, "these": true, "this": true, "to": true, "was": true,
+	"we": true, "were": true, "what": true, "when": true, "which": true,
+	"will": true, "with": true, "you": true,
+}
+
+// Index is an inverted index over a set of documents.
+//
+// Documents are identified by the order in which they were added,
+// starting at 0. The zero value is not usable; create one with NewIndex.
+type Index struct {
+	postings map[string]map[int]int
+	lengths  []int
+	totalLen int
+}
+
+// NewIndex creates an empty index.
+//
+// Returns:
+//   - *Index: Index ready for Add
+func NewIndex() *Index {
+	return &Index{postings: make(map[string]map[int]int)}
+}
+
+// Add indexes a document.
+//
+// Parameters:
+//   - text: Document text
+//
+// Returns:
+//   - int: Document ID (its position in insertion order)
+func (ix *Index) Add(text string) int {
+	id := len(ix.lengths)
+	terms := Terms(text)
+	for _, t := range terms {
+		docs := ix.postings[t]
+		if docs == nil {
+			docs = make(map[int]int)
+			ix.postings[t] = docs
+		}
+		docs[id]++
+	}
+	ix.lengths = append(ix.lengths, len(terms))
+	ix.totalLen += len(terms)
+	return id
+}
+
+// Len returns the number of indexed documents.
+//
+// Returns:
+//   - int: Document count
+func (ix *Index) Len() int {
+	return len(ix.lengths)
+}
+
+// Score ranks every document against a query with BM25.
+//
+// Repeated query terms count once. Documents sharing no term with the
+// query score 0.
+//
+// Parameters:
+//   - query: Free-text query
+//
+// Returns:
+//   - []float64: Score per document ID (len == Len())
+func (ix *Index) Score(query string) []float64 {
+	scores := make([]float64, len(ix.lengths))
+	if len(ix.lengths) == 0 {
+		return scores
+	}
+
+	n := float64(len(ix.lengths))
+	avgLen := float64(ix.totalLen) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	for _, term := range uniqueTerms(query) {
+		docs := ix.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range docs {
+			f := float64(tf)
+			norm := 1 - bm25B + bm25B*float64(ix.lengths[id])/avgLen
+			scores[id] += idf * f * (bm25K1 + 1) / (f + bm25K1*norm)
+		}
+	}
+	return scores
+}
+
+// Matches returns the query terms that occur in a document.
+//
+// Parameters:
+//   - id: Document ID
+//   - query: Free-text query
+//
+// Returns:
+//   - []string: Stemmed query terms present in the document, in query
+//     order
+func (ix *Index) Matches(id int, query string) []string {
+	var matched []string
+	for _, term := range uniqueTerms(query) {
+		if ix.postings[term][id] > 0 {
+			matched = append(matched, term)
+		}
+	}
+	return matched
+}
+
+// Terms splits text into stemmed index terms.
+//
+// Text is split on anything that is not a letter or digit; identifiers
+// are additionally split at camelCase boundaries ("ReadInput" yields
+// "readinput", "read" and "input"). Stop words and single characters
+// are dropped.
+//
+// Parameters:
+//   - text: Text to tokenize
+//
+// Returns:
+//   - []string: Terms in document order, with repetitions
+func Terms(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	emit := func(w string) {
+		w = strings.ToLower(w)
+		if len([]rune(w)) < 2 || stopWords[w] {
+			return
+		}
+		terms = append(terms, Stem(w))
+	}
+	for _, w := range words {
+		parts := splitCamel(w)
+		if len(parts) > 1 {
+			emit(w)
+		}
+		for _, p := range parts {
+			emit(p)
+		}
+	}
+	return terms
+}
+
+// uniqueTerms returns the distinct terms of a query in order.
+//
+// Parameters:
+//   - query: Free-text query
+//
+// Returns:
+//   - []string: Deduplicated stemmed terms
+func uniqueTerms(query string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, t := range Terms(query) {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// splitCamel splits an identifier at lower-to-upper and acronym
+// boundaries ("parseHTTPRequest" -> "parse", "HTTP", "Request").
+//
+// Parameters:
+//   - word: Word without separators
+//
+// Returns:
+//   - []string: Parts; a single element if there is no boundary
+func splitCamel(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		lowerToUpper := unicode.IsLower(prev) && unicode.IsUpper(cur)
+		acronymEnd := unicode.IsUpper(prev) && unicode.IsUpper(cur) &&
+			i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
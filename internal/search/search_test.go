@@ -0,0 +1,97 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"hopping":        "hop",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"conditional":    "condit",
+		"digitizer":      "digit",
+		"hopefulness":    "hope",
+		"electrical":     "electr",
+		"adjustment":     "adjust",
+		"adoption":       "adopt",
+		"controll":       "control",
+		"parsing":        "pars",
+		"parsed":         "pars",
+		"generalization": "gener",
+		"go":             "go",
+		"utf8":           "utf8",
+	}
+	for in, want := range tests {
+		if got := Stem(in); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := Terms("Parsing the hook's stdin via readInput_JSON")
+	want := []string{"pars", "hook", "stdin", "via", "readinput", "read", "input", "json"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Terms() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitCamel(t *testing.T) {
+	got := splitCamel("parseHTTPRequest")
+	if strings.Join(got, ",") != "parse,HTTP,Request" {
+		t.Errorf("splitCamel() = %v", got)
+	}
+}
+
+func TestIndex_Score(t *testing.T) {
+	ix := NewIndex()
+	hook := ix.Add("Hook scripts read JSON from stdin and parse the tool input.")
+	budget := ix.Add("The agent packet respects a token budget.")
+	mixed := ix.Add("Hooks are configured in settings; budget is separate.")
+
+	if ix.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", ix.Len())
+	}
+
+	scores := ix.Score("hook stdin parsing")
+	if scores[hook] <= scores[mixed] {
+		t.Errorf("hook doc %.3f should outrank mixed doc %.3f",
+			scores[hook], scores[mixed])
+	}
+	if scores[mixed] <= 0 {
+		t.Errorf("mixed doc should match 'hook', got %.3f", scores[mixed])
+	}
+	if scores[budget] != 0 {
+		t.Errorf("budget doc should not match, got %.3f", scores[budget])
+	}
+
+	if got := ix.Matches(hook, "hook stdin parsing"); strings.Join(got, ",") != "hook,stdin,pars" {
+		t.Errorf("Matches() = %v", got)
+	}
+}
+
+func TestIndex_Empty(t *testing.T) {
+	ix := NewIndex()
+	if got := ix.Score("anything"); len(got) != 0 {
+		t.Errorf("Score() on empty index = %v", got)
+	}
+	ix.Add("")
+	if got := ix.Score("anything"); got[0] != 0 {
+		t.Errorf("Score() = %v, want [0]", got)
+	}
+}
@@ -0,0 +1,64 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import "strings"
+
+// shingleSize is the length, in characters, of the shingles compared
+// by Jaccard. Four characters tolerate rewording while keeping the
+// overlap of unrelated English text low.
+const shingleSize = 4
+
+// Shingles returns the character shingles of a text.
+//
+// The text is first reduced to its terms (see Terms), so case,
+// punctuation, stop words and word endings do not count: "Always run
+// gofmt before committing" and "run gofmt before every commit" share
+// most of their shingles.
+//
+// Parameters:
+//   - text: Text to shingle
+//
+// Returns:
+//   - map[string]bool: Set of shingles; empty for text without terms
+func Shingles(text string) map[string]bool {
+	runes := []rune(strings.Join(Terms(text), " "))
+	set := make(map[string]bool)
+	if len(runes) > 0 && len(runes) < shingleSize {
+		set[string(runes)] = true
+	}
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		set[string(runes[i:i+shingleSize])] = true
+	}
+	return set
+}
+
+// Jaccard returns the Jaccard similarity of two shingle sets.
+//
+// Parameters:
+//   - a: Shingles of the first text
+//   - b: Shingles of the second text
+//
+// Returns:
+//   - float64: |a ∩ b| / |a ∪ b|, from 0 (nothing shared) to 1
+//     (identical); 0 if both sets are empty
+func Jaccard(a, b map[string]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for s := range a {
+		if b[s] {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
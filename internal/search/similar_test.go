@@ -0,0 +1,44 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package search
+
+import "testing"
+
+func TestShingles(t *testing.T) {
+	if got := Shingles("the and of"); len(got) != 0 {
+		t.Errorf("stop words only: got %d shingles, want 0", len(got))
+	}
+	if got := Shingles("Go"); !got["go"] || len(got) != 1 {
+		t.Errorf("short text should be one shingle, got %v", got)
+	}
+	if got := Shingles("parsing"); !got["pars"] || len(got) != 1 {
+		t.Errorf("Shingles(parsing) = %v, want {pars}", got)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	a := Shingles("Always run gofmt before committing Go code")
+	if got := Jaccard(a, a); got != 1 {
+		t.Errorf("identical texts: got %v, want 1", got)
+	}
+	if got := Jaccard(map[string]bool{}, map[string]bool{}); got != 0 {
+		t.Errorf("empty sets: got %v, want 0", got)
+	}
+
+	reworded := Jaccard(
+		Shingles("Run gofmt before every commit: the CI formatting check failed"),
+		Shingles("Always run gofmt before committing, CI failed on formatting"),
+	)
+	unrelated := Jaccard(
+		Shingles("Run gofmt before every commit: the CI formatting check failed"),
+		Shingles("go:embed only sees files in the package directory"),
+	)
+	if reworded < 0.3 || unrelated > 0.1 {
+		t.Errorf("reworded = %.2f (want >= 0.3), unrelated = %.2f (want <= 0.1)",
+			reworded, unrelated)
+	}
+}
@@ -0,0 +1,72 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// LoadAllowlist reads an allowlist file.
+//
+// Each line is a regular expression; a finding is ignored if the
+// expression matches the secret. Blank lines and lines starting with
+// "#" are skipped. A missing file is an empty allowlist.
+//
+// Parameters:
+//   - path: Path to the file (usually .context/.secrets-allow)
+//
+// Returns:
+//   - *Allowlist: The allowlist
+//   - error: Non-nil if the file cannot be read or a pattern is invalid
+func LoadAllowlist(path string) (*Allowlist, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return &Allowlist{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	a := &Allowlist{}
+	for i, line := range strings.Split(string(data), config.NewlineLF) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		re, compileErr := regexp.Compile(line)
+		if compileErr != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, i+1, compileErr)
+		}
+		a.patterns = append(a.patterns, re)
+	}
+	return a, nil
+}
+
+// Allows reports whether a matched secret is allowlisted.
+//
+// Parameters:
+//   - match: The matched text
+//
+// Returns:
+//   - bool: True if a pattern matches; false for a nil allowlist
+func (a *Allowlist) Allows(match string) bool {
+	if a == nil {
+		return false
+	}
+	for _, re := range a.patterns {
+		if re.MatchString(match) {
+			return true
+		}
+	}
+	return false
+}
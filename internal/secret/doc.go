@@ -0,0 +1,17 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+// Package secret finds credentials in text.
+//
+// It detects known credential formats (AWS keys, GitHub and Slack
+// tokens, private key blocks, JSON web tokens) and high-entropy strings
+// that look like generated keys. Context files and journals are
+// committed to git, so "ctx drift" scans them and "ctx add" refuses
+// entries that contain a secret.
+//
+// Known false positives go in an allowlist file (see LoadAllowlist), or
+// are marked on their line with config.MarkerAllowSecret.
+package secret
@@ -0,0 +1,215 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Rule names reported in findings.
+const (
+	RuleAWSAccessKey = "aws_access_key"
+	RuleAWSSecretKey = "aws_secret_key" //nolint:gosec // G101: rule name, not a credential
+	RuleGitHubToken  = "github_token"
+	RuleSlackToken   = "slack_token"
+	RuleSlackWebhook = "slack_webhook"
+	RulePrivateKey   = "private_key"
+	RuleJWT          = "jwt"
+	RuleHighEntropy  = "high_entropy"
+)
+
+// rules lists the credential formats in the order they are tried.
+var rules = []rule{
+	{RuleAWSAccessKey, "AWS access key", config.RegExAWSAccessKey},
+	{RuleAWSSecretKey, "AWS secret key", config.RegExAWSSecretKey},
+	{RuleGitHubToken, "GitHub token", config.RegExGitHubToken},
+	{RuleSlackToken, "Slack token", config.RegExSlackToken},
+	{RuleSlackWebhook, "Slack webhook URL", config.RegExSlackWebhook},
+	{RulePrivateKey, "private key", config.RegExPrivateKey},
+	{RuleJWT, "JSON web token", config.RegExJWT},
+}
+
+// Scan finds possible secrets in a text.
+//
+// Each line is matched against the known credential formats. Runs of
+// at least 20 base64 or URL-safe characters are then reported if they
+// look random: upper case, lower case and digits mixed throughout, with
+// high entropy (see highEntropy). Hex strings such as commit hashes are
+// not reported.
+// A private key block is reported once, at its first line. Lines
+// carrying config.MarkerAllowSecret are skipped, as are matches the
+// allowlist allows.
+//
+// Parameters:
+//   - text: Text to scan
+//   - allow: Allowlist; nil allows nothing
+//
+// Returns:
+//   - []Finding: Findings in line order; nil if none
+func Scan(text string, allow *Allowlist) []Finding {
+	var findings []Finding
+	inKey := false
+	for i, line := range strings.Split(text, config.NewlineLF) {
+		// The body of a private key block was reported with its first line
+		if inKey {
+			inKey = !strings.Contains(line, config.PrivateKeyEnd)
+			continue
+		}
+		if strings.Contains(line, config.MarkerAllowSecret) {
+			continue
+		}
+
+		var found []string
+		for _, r := range rules {
+			for _, m := range r.re.FindAllStringSubmatch(line, -1) {
+				match := m[len(m)-1]
+				found = append(found, m[0])
+				if r.name == RulePrivateKey {
+					inKey = !strings.Contains(line, config.PrivateKeyEnd)
+				}
+				if !allow.Allows(match) {
+					findings = append(findings, Finding{
+						Line: i + 1, Rule: r.name, Match: match,
+					})
+				}
+			}
+		}
+
+		for _, candidate := range config.RegExSecretCandidate.FindAllString(line, -1) {
+			if covered(candidate, found) || !highEntropy(candidate) ||
+				allow.Allows(candidate) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Line: i + 1, Rule: RuleHighEntropy, Match: candidate,
+			})
+		}
+	}
+	return findings
+}
+
+// Describe returns a description of the finding that does not repeat
+// the secret.
+//
+// Returns:
+//   - string: Description like "possible GitHub token (ghp_…)"
+func (f Finding) Describe() string {
+	label := "high-entropy string"
+	for _, r := range rules {
+		if r.name == f.Rule {
+			label = r.label
+		}
+	}
+	return fmt.Sprintf("possible %s (%s)", label, redact(f.Match))
+}
+
+// Entropy returns the Shannon entropy of a string.
+//
+// Parameters:
+//   - s: String to measure
+//
+// Returns:
+//   - float64: Bits per character; 0 for an empty string
+func Entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// highEntropy reports whether a candidate looks like a generated key.
+//
+// Parameters:
+//   - s: Candidate string
+//
+// Returns:
+//   - bool: True if s mixes upper case, lower case and digits, is not a
+//     hex string, and reaches config.SecretMinEntropyRatio and
+//     config.SecretMinClassChanges
+func highEntropy(s string) bool {
+	if config.RegExHex.MatchString(s) {
+		return false
+	}
+
+	var upper, lower, digit bool
+	var classes []int
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+			classes = append(classes, 0)
+		case unicode.IsLower(r):
+			lower = true
+			classes = append(classes, 1)
+		case unicode.IsDigit(r):
+			digit = true
+			classes = append(classes, 2)
+		}
+	}
+	if !upper || !lower || !digit {
+		return false
+	}
+
+	changes := 0
+	for i := 1; i < len(classes); i++ {
+		if classes[i] != classes[i-1] {
+			changes++
+		}
+	}
+	if float64(changes) < config.SecretMinClassChanges*float64(len(classes)-1) {
+		return false
+	}
+
+	// The highest entropy of n characters from the 64 base64 characters
+	maxEntropy := math.Log2(float64(min(len(s), 64)))
+	return Entropy(s) >= config.SecretMinEntropyRatio*maxEntropy
+}
+
+// covered reports whether a candidate is part of a known-format match.
+//
+// Parameters:
+//   - candidate: High-entropy candidate
+//   - found: Known-format matches on the same line
+//
+// Returns:
+//   - bool: True if a match contains the candidate or the reverse
+func covered(candidate string, found []string) bool {
+	for _, f := range found {
+		if strings.Contains(f, candidate) || strings.Contains(candidate, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact masks all but the first characters of a secret.
+//
+// Parameters:
+//   - s: Secret
+//
+// Returns:
+//   - string: The first config.SecretPreviewLen characters and "…"
+func redact(s string) string {
+	runes := []rune(s)
+	if len(runes) <= config.SecretPreviewLen {
+		return "…"
+	}
+	return string(runes[:config.SecretPreviewLen]) + "…"
+}
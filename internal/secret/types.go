@@ -0,0 +1,42 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package secret
+
+import "regexp"
+
+// Finding is a possible secret found in a text.
+//
+// Fields:
+//   - Line: 1-based line number
+//   - Rule: Kind of secret (e.g., "aws_access_key", "high_entropy")
+//   - Match: The matched text
+type Finding struct {
+	Line  int
+	Rule  string
+	Match string
+}
+
+// Allowlist holds patterns of text that is not a secret.
+//
+// Fields:
+//   - patterns: Compiled patterns; a finding whose match any of them
+//     matches is ignored
+type Allowlist struct {
+	patterns []*regexp.Regexp
+}
+
+// rule is a credential format.
+//
+// Fields:
+//   - name: Rule name reported in findings
+//   - label: Human-readable name of the credential
+//   - re: Pattern; if it has a group, the group is the secret
+type rule struct {
+	name  string
+	label string
+	re    *regexp.Regexp
+}
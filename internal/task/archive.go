@@ -0,0 +1,52 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// ParseArchive reads the tasks moved to a context archive directory.
+//
+// Only task archives ("tasks-YYYY-MM-DD.md") are read; snapshots are
+// copies of TASKS.md and would repeat pending tasks.
+//
+// Parameters:
+//   - dir: Archive directory (e.g., ".context/archive")
+//
+// Returns:
+//   - []*Task: Archived top-level tasks, oldest file first
+//   - error: Non-nil if the directory or a file cannot be read; a
+//     missing directory is not an error
+func ParseArchive(dir string) ([]*Task, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*Task
+	for _, e := range entries {
+		if e.IsDir() || !config.RegExTaskArchiveFile.MatchString(e.Name()) {
+			continue
+		}
+		content, readErr := os.ReadFile(filepath.Join(dir, e.Name()))
+		if readErr != nil {
+			return nil, readErr
+		}
+		tasks = append(
+			tasks, Parse(strings.Split(string(content), config.NewlineLF))...,
+		)
+	}
+	return tasks, nil
+}
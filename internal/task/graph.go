@@ -0,0 +1,299 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Edge is a dependency between two tasks: From must be done before To.
+//
+// Fields:
+//   - From: Key of the prerequisite task
+//   - To: Key of the dependent task
+type Edge struct {
+	From string
+	To   string
+}
+
+// Reference is a dependency tag that names a task ID nobody has.
+//
+// Fields:
+//   - Task: Task carrying the tag
+//   - Tag: Tag key (config.TaskTagDepends or config.TaskTagBlocks)
+//   - ID: The unknown ID
+type Reference struct {
+	Task *Task
+	Tag  string
+	ID   string
+}
+
+// Graph is the dependency graph of a task list.
+//
+// Nodes are keyed by task ID. Tasks without an ID can still declare
+// dependencies; they are keyed by line ("L12") so they can be drawn,
+// but nothing can point at them.
+//
+// Fields:
+//   - Edges: Dependencies in file order, without duplicates
+//   - Archived: IDs of tasks found in the archive (treated as done)
+//   - Dangling: Tags that name an ID that is neither a task nor archived
+type Graph struct {
+	Edges    []Edge
+	Archived map[string]bool
+	Dangling []Reference
+	tasks    []*Task
+	byKey    map[string]*Task
+}
+
+// NewGraph builds the dependency graph from "#depends:" and "#blocks:"
+// tags.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//   - archived: IDs of archived tasks; nil if unknown
+//
+// Returns:
+//   - *Graph: Dependency graph
+func NewGraph(tasks []*Task, archived map[string]bool) *Graph {
+	g := &Graph{
+		Archived: archived,
+		tasks:    All(tasks),
+		byKey:    make(map[string]*Task),
+	}
+	for _, t := range g.tasks {
+		g.byKey[Key(t)] = t
+	}
+
+	seen := make(map[Edge]bool)
+	add := func(t *Task, tag, id string, e Edge) {
+		if g.byKey[id] == nil && !g.Archived[id] {
+			g.Dangling = append(g.Dangling, Reference{Task: t, Tag: tag, ID: id})
+		}
+		if !seen[e] {
+			seen[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	for _, t := range g.tasks {
+		for _, id := range t.Depends {
+			add(t, config.TaskTagDepends, id, Edge{From: id, To: Key(t)})
+		}
+		for _, id := range t.Blocks {
+			add(t, config.TaskTagBlocks, id, Edge{From: Key(t), To: id})
+		}
+	}
+
+	return g
+}
+
+// Key returns the node key of a task in a Graph.
+//
+// Parameters:
+//   - t: Task
+//
+// Returns:
+//   - string: The task ID, or "L<line>" for tasks without one
+func Key(t *Task) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return fmt.Sprintf("L%d", t.Line+1)
+}
+
+// Task returns the task with a node key.
+//
+// Parameters:
+//   - key: Node key (see Key)
+//
+// Returns:
+//   - *Task: Task, or nil for archived and unknown IDs
+func (g *Graph) Task(key string) *Task {
+	return g.byKey[key]
+}
+
+// Nodes returns the keys of tasks that take part in a dependency, in
+// file order, followed by archived and unknown IDs that are referenced.
+//
+// Parameters:
+//   - all: Include every task, even those without dependencies
+//
+// Returns:
+//   - []string: Node keys
+func (g *Graph) Nodes(all bool) []string {
+	linked := make(map[string]bool)
+	for _, e := range g.Edges {
+		linked[e.From] = true
+		linked[e.To] = true
+	}
+
+	var nodes []string
+	for _, t := range g.tasks {
+		if k := Key(t); all || linked[k] {
+			nodes = append(nodes, k)
+			delete(linked, k)
+		}
+	}
+	var rest []string
+	for k := range linked {
+		if g.byKey[k] == nil {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(nodes, rest...)
+}
+
+// Open returns the dependencies of a task that are not done yet.
+//
+// Archived and skipped dependencies count as done; unknown IDs are not
+// returned (see Unknown).
+//
+// Parameters:
+//   - t: Task to check
+//
+// Returns:
+//   - []*Task: Pending prerequisite tasks, in file order
+func (g *Graph) Open(t *Task) []*Task {
+	key := Key(t)
+	var open []*Task
+	for _, e := range g.Edges {
+		if e.To != key {
+			continue
+		}
+		if dep := g.byKey[e.From]; dep != nil && dep.Pending() {
+			open = append(open, dep)
+		}
+	}
+	sort.SliceStable(open, func(a, b int) bool {
+		return open[a].Line < open[b].Line
+	})
+	return open
+}
+
+// Unknown returns the "#depends:" IDs of a task that name neither a
+// task nor an archived one.
+//
+// Parameters:
+//   - t: Task to check
+//
+// Returns:
+//   - []string: Unknown IDs, in tag order, without duplicates
+func (g *Graph) Unknown(t *Task) []string {
+	var ids []string
+	for _, r := range g.Dangling {
+		if r.Task == t && r.Tag == config.TaskTagDepends &&
+			!slices.Contains(ids, r.ID) {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+// Cycles returns the groups of tasks that depend on each other in a
+// loop, so none of them can be started.
+//
+// Each group is a strongly connected component with more than one task,
+// or a task that depends on itself.
+//
+// Returns:
+//   - [][]string: Node keys per cycle, each in file order
+func (g *Graph) Cycles() [][]string {
+	next := make(map[string][]string)
+	for _, e := range g.Edges {
+		next[e.From] = append(next[e.From], e.To)
+	}
+
+	// Tarjan's strongly connected components
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(k string)
+	visit = func(k string) {
+		index[k] = len(index)
+		low[k] = index[k]
+		stack = append(stack, k)
+		onStack[k] = true
+
+		for _, n := range next[k] {
+			if _, ok := index[n]; !ok {
+				visit(n)
+				low[k] = min(low[k], low[n])
+			} else if onStack[n] {
+				low[k] = min(low[k], index[n])
+			}
+		}
+
+		if low[k] != index[k] {
+			return
+		}
+		var scc []string
+		for {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[n] = false
+			scc = append(scc, n)
+			if n == k {
+				break
+			}
+		}
+		if len(scc) > 1 || g.selfLoop(k) {
+			cycles = append(cycles, g.fileOrder(scc))
+		}
+	}
+
+	for _, k := range g.Nodes(false) {
+		if _, ok := index[k]; !ok {
+			visit(k)
+		}
+	}
+	return cycles
+}
+
+// selfLoop reports whether a task depends on itself.
+//
+// Parameters:
+//   - k: Node key
+//
+// Returns:
+//   - bool: True if an edge leads from k to k
+func (g *Graph) selfLoop(k string) bool {
+	for _, e := range g.Edges {
+		if e.From == k && e.To == k {
+			return true
+		}
+	}
+	return false
+}
+
+// fileOrder sorts node keys by the position of their task in the file.
+//
+// Parameters:
+//   - keys: Node keys
+//
+// Returns:
+//   - []string: Keys of known tasks in file order, then unknown IDs
+func (g *Graph) fileOrder(keys []string) []string {
+	line := func(k string) int {
+		if t := g.byKey[k]; t != nil {
+			return t.Line
+		}
+		return math.MaxInt
+	}
+	sort.SliceStable(keys, func(a, b int) bool {
+		return line(keys[a]) < line(keys[b])
+	})
+	return keys
+}
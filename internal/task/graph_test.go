@@ -0,0 +1,132 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const dependencyTasks = `# Tasks
+
+- [x] Set up auth service #id:k3f9 #blocks:a1b2
+- [ ] Add login form #id:a1b2 #depends:k3f9
+- [ ] Add logout #id:q7r2 #depends:a1b2,K3F9 #depends:old1
+- [ ] Write docs #depends:q7r2
+- [ ] Refactor #id:c1c1
+`
+
+func TestParse_Dependencies(t *testing.T) {
+	tasks := Parse(strings.Split(dependencyTasks, "\n"))
+
+	if got := tasks[2].Depends; !reflect.DeepEqual(got, []string{"a1b2", "k3f9", "old1"}) {
+		t.Errorf("Depends = %v", got)
+	}
+	if got := tasks[0].Blocks; !reflect.DeepEqual(got, []string{"a1b2"}) {
+		t.Errorf("Blocks = %v", got)
+	}
+	if tasks[2].HasLabel("depends") {
+		t.Error("#depends:<id> should not be a label")
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	tasks := Parse(strings.Split(dependencyTasks, "\n"))
+	g := NewGraph(tasks, map[string]bool{"old1": true})
+
+	want := []Edge{
+		{From: "k3f9", To: "a1b2"},
+		{From: "a1b2", To: "q7r2"},
+		{From: "k3f9", To: "q7r2"},
+		{From: "old1", To: "q7r2"},
+		{From: "q7r2", To: "L6"},
+	}
+	if !reflect.DeepEqual(g.Edges, want) {
+		t.Errorf("Edges = %v, want %v", g.Edges, want)
+	}
+	if len(g.Dangling) != 0 {
+		t.Errorf("Dangling = %+v, want none (old1 is archived)", g.Dangling)
+	}
+
+	nodes := g.Nodes(false)
+	if !reflect.DeepEqual(nodes, []string{"k3f9", "a1b2", "q7r2", "L6", "old1"}) {
+		t.Errorf("Nodes(false) = %v", nodes)
+	}
+	if all := g.Nodes(true); len(all) != 6 {
+		t.Errorf("Nodes(true) = %v, want 6 nodes", all)
+	}
+
+	open := g.Open(tasks[2])
+	if len(open) != 1 || open[0].ID != "a1b2" {
+		t.Errorf("Open(q7r2) = %+v, want [a1b2]", open)
+	}
+	if open := g.Open(tasks[1]); len(open) != 0 {
+		t.Errorf("Open(a1b2) = %+v, want none (k3f9 is done)", open)
+	}
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Errorf("Cycles() = %v, want none", cycles)
+	}
+}
+
+func TestGraph_Dangling(t *testing.T) {
+	tasks := Parse(strings.Split(dependencyTasks, "\n"))
+	g := NewGraph(tasks, nil)
+
+	if len(g.Dangling) != 1 {
+		t.Fatalf("Dangling = %+v, want 1", g.Dangling)
+	}
+	r := g.Dangling[0]
+	if r.Task.ID != "q7r2" || r.Tag != "depends" || r.ID != "old1" {
+		t.Errorf("Dangling[0] = %+v", r)
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	tasks := Parse([]string{
+		"- [ ] A #id:aaaa #depends:cccc",
+		"- [ ] B #id:bbbb #depends:aaaa",
+		"- [ ] C #id:cccc #depends:bbbb",
+		"- [ ] D #id:dddd #depends:dddd",
+		"- [ ] E #id:eeee #depends:aaaa",
+	})
+	g := NewGraph(tasks, nil)
+
+	want := [][]string{{"aaaa", "bbbb", "cccc"}, {"dddd"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestParseArchive(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"tasks-2026-01-10.md":               "# Archived\n\n- [x] Old task #id:old1\n",
+		"tasks-snapshot-2026-01-11-1200.md": "# Tasks\n\n- [ ] Pending #id:pend\n",
+		"notes.md":                          "- [x] Not a task archive #id:note\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tasks, err := ParseArchive(dir)
+	if err != nil {
+		t.Fatalf("ParseArchive() error = %v", err)
+	}
+	if ids := IDs(tasks); len(ids) != 1 || !ids["old1"] {
+		t.Errorf("archived IDs = %v, want [old1]", ids)
+	}
+
+	missing, err := ParseArchive(filepath.Join(dir, "missing"))
+	if err != nil || missing != nil {
+		t.Errorf("ParseArchive(missing) = %v, %v; want nil, nil", missing, err)
+	}
+}
@@ -0,0 +1,181 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// idTag is the inline prefix of a task ID ("#id:").
+const idTag = "#" + config.TaskTagID + ":"
+
+// NewID generates a short task ID that is not already taken.
+//
+// IDs are derived from a hash of the seed, so they are reproducible,
+// and always contain a letter so they can never be mistaken for a
+// positional task number.
+//
+// Parameters:
+//   - seed: Text to derive the ID from (e.g., task text and time)
+//   - taken: IDs already in use
+//
+// Returns:
+//   - string: config.TaskIDLen lowercase base-36 characters
+func NewID(seed string, taken map[string]bool) string {
+	for n := 0; ; n++ {
+		sum := sha256.Sum256([]byte(seed + "\x00" + strconv.Itoa(n)))
+		id := strconv.FormatUint(binary.BigEndian.Uint64(sum[:8]), 36)
+		id = id[len(id)-config.TaskIDLen:]
+		if !taken[id] && ValidID(id) {
+			return id
+		}
+	}
+}
+
+// ValidID reports whether an ID could have been generated by NewID.
+//
+// Length is not checked, so hand-picked IDs may be longer or shorter,
+// but they must use the same alphabet and contain a letter.
+//
+// Parameters:
+//   - id: Normalized ID (see NormalizeID)
+//
+// Returns:
+//   - bool: True if id is lowercase base-36 with at least one letter
+func ValidID(id string) bool {
+	return id != "" &&
+		strings.Trim(id, "abcdefghijklmnopqrstuvwxyz0123456789") == "" &&
+		strings.ContainsAny(id, "abcdefghijklmnopqrstuvwxyz")
+}
+
+// NormalizeID strips the tag prefix and case from a task ID.
+//
+// Parameters:
+//   - id: ID as typed ("k3f9", "#k3f9" or "#id:K3F9")
+//
+// Returns:
+//   - string: Bare lowercase ID
+func NormalizeID(id string) string {
+	id = strings.TrimSpace(id)
+	id = strings.TrimPrefix(id, idTag)
+	id = strings.TrimPrefix(id, "#")
+	return strings.ToLower(id)
+}
+
+// IDs returns the set of IDs in use.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//
+// Returns:
+//   - map[string]bool: Assigned IDs
+func IDs(tasks []*Task) map[string]bool {
+	ids := make(map[string]bool)
+	for _, t := range All(tasks) {
+		if t.ID != "" {
+			ids[t.ID] = true
+		}
+	}
+	return ids
+}
+
+// WithID appends an ID tag to a task's checkbox line.
+//
+// Parameters:
+//   - line: Checkbox line
+//   - id: ID to add
+//
+// Returns:
+//   - string: Line ending in "#id:<id>"
+func WithID(line, id string) string {
+	return WithTag(line, config.TaskTagID, id)
+}
+
+// WithTag appends a key-value tag to a task's checkbox line.
+//
+// Parameters:
+//   - line: Checkbox line
+//   - key: Tag key (e.g., config.TaskTagDone)
+//   - value: Tag value
+//
+// Returns:
+//   - string: Line ending in "#key:value"
+func WithTag(line, key, value string) string {
+	return strings.TrimRight(line, config.Whitespace) + " #" + key + ":" + value
+}
+
+// WithLabel appends a bare label to a task's checkbox line.
+//
+// Parameters:
+//   - line: Checkbox line
+//   - label: Label without "#" (e.g., config.TaskLabelBlocked)
+//
+// Returns:
+//   - string: Line ending in "#label"
+func WithLabel(line, label string) string {
+	return strings.TrimRight(line, config.Whitespace) + " #" + label
+}
+
+// WithoutLabel removes a bare label from a task's checkbox line.
+//
+// Key-value tags and longer labels that start with the same text
+// (e.g., "#in-progress-review") are left alone.
+//
+// Parameters:
+//   - line: Checkbox line
+//   - label: Label without "#" (e.g., config.TaskLabelInProgress)
+//
+// Returns:
+//   - string: Line without the label; unchanged if it has none
+func WithoutLabel(line, label string) string {
+	re := regexp.MustCompile(`[ \t]#` + regexp.QuoteMeta(label) + `([ \t]|$)`)
+	return strings.TrimRight(re.ReplaceAllString(line, "${1}"), config.Whitespace)
+}
+
+// Check marks a task's checkbox line as completed.
+//
+// Parameters:
+//   - line: Checkbox line
+//
+// Returns:
+//   - string: Line with "- [x]"; unchanged if it is not a task
+func Check(line string) string {
+	return config.RegExTask.ReplaceAllString(line, "$1- [x] $3")
+}
+
+// AssignIDs gives every task without an ID a new one.
+//
+// Lines are updated in place: the ID tag is appended to each task's
+// checkbox line. IDs are seeded from the task text, so running the
+// migration on identical files yields identical IDs.
+//
+// Parameters:
+//   - lines: TASKS.md lines, modified in place
+//
+// Returns:
+//   - []*Task: Tasks that were given an ID, with ID set
+func AssignIDs(lines []string) []*Task {
+	tasks := Parse(lines)
+	taken := IDs(tasks)
+	var assigned []*Task
+	for _, t := range All(tasks) {
+		if t.ID != "" {
+			continue
+		}
+		t.ID = NewID(t.Text, taken)
+		taken[t.ID] = true
+		lines[t.Line] = WithID(lines[t.Line], t.ID)
+		assigned = append(assigned, t)
+	}
+	return assigned
+}
@@ -0,0 +1,104 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+func TestNewID(t *testing.T) {
+	taken := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		id := NewID("task "+strconv.Itoa(i%50), taken)
+		if len(id) != config.TaskIDLen {
+			t.Fatalf("NewID() = %q, want %d characters", id, config.TaskIDLen)
+		}
+		if _, err := strconv.Atoi(id); err == nil {
+			t.Fatalf("NewID() = %q, looks like a task number", id)
+		}
+		if taken[id] {
+			t.Fatalf("NewID() = %q, already taken", id)
+		}
+		taken[id] = true
+	}
+
+	if NewID("same", nil) != NewID("same", nil) {
+		t.Error("NewID should be deterministic for the same seed")
+	}
+}
+
+func TestValidID(t *testing.T) {
+	for _, id := range []string{"k3f9", "aaaa", "x", "legacy2"} {
+		if !ValidID(id) {
+			t.Errorf("ValidID(%q) = false, want true", id)
+		}
+	}
+	for _, id := range []string{"", "1", "1234", "bad/id!", "K3F9", "k3-f9"} {
+		if ValidID(id) {
+			t.Errorf("ValidID(%q) = true, want false", id)
+		}
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	lines := strings.Split(`# Tasks
+
+- [ ] First task
+  - [ ] Subtask
+- [x] Done task #id:k3f9
+`, "\n")
+
+	assigned := AssignIDs(lines)
+	if len(assigned) != 2 {
+		t.Fatalf("assigned %d IDs, want 2", len(assigned))
+	}
+	if !strings.HasSuffix(lines[2], " #id:"+assigned[0].ID) {
+		t.Errorf("line 2 = %q, want ID suffix", lines[2])
+	}
+	if !strings.HasSuffix(lines[3], " #id:"+assigned[1].ID) {
+		t.Errorf("line 3 = %q, want ID suffix", lines[3])
+	}
+	if lines[4] != "- [x] Done task #id:k3f9" {
+		t.Errorf("existing ID should be kept, got %q", lines[4])
+	}
+
+	if again := AssignIDs(lines); len(again) != 0 {
+		t.Errorf("second run assigned %d IDs, want 0", len(again))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if got := Check("  - [ ] Task #id:k3f9"); got != "  - [x] Task #id:k3f9" {
+		t.Errorf("Check() = %q", got)
+	}
+	if got := Check("Not a task"); got != "Not a task" {
+		t.Errorf("Check() changed a non-task line: %q", got)
+	}
+}
+
+func TestWithoutLabel(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"- [ ] Task #in-progress #id:k3f9", "- [ ] Task #id:k3f9"},
+		{"- [ ] Task #id:k3f9 #in-progress", "- [ ] Task #id:k3f9"},
+		{"- [ ] Task #in-progress-review", "- [ ] Task #in-progress-review"},
+		{"- [ ] Task #in-progress:no", "- [ ] Task #in-progress:no"},
+	}
+	for _, tt := range tests {
+		if got := WithoutLabel(tt.line, "in-progress"); got != tt.want {
+			t.Errorf("WithoutLabel(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+	if got := WithLabel("- [ ] Task  ", "blocked"); got != "- [ ] Task #blocked" {
+		t.Errorf("WithLabel() = %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Task is a checkbox item from TASKS.md, with its nested content.
+//
+// Line and End delimit the task's block: the checkbox line plus every
+// following line indented deeper than it (continuation text, metadata
+// and subtasks), so lines[Line:End] is the whole task.
+//
+// Fields:
+//   - ID: Stable short ID from the "#id:" tag; empty if not assigned
+//   - Content: Text after the checkbox on the first line, as written
+//   - Text: Content plus continuation lines, joined onto one line
+//   - Title: Text with all tags removed
+//   - Done: True if the checkbox is checked
+//   - Skipped: True if the checkbox is marked "[-]" (will not be done)
+//   - Phase: Nearest heading above the task, without "#" markers
+//   - Section: Nearest level-2 heading above the task (e.g., "Completed")
+//   - Labels: Bare tags without values (e.g., "in-progress")
+//   - Tags: Key-value tags (e.g., "priority" → "high"); the last wins
+//   - Added: Time from the "#added:" tag; zero if absent
+//   - Started: Time from the "#started:" tag; zero if absent
+//   - Completed: Time from the "#done:" tag; zero if absent
+//   - Depends: IDs from "#depends:" tags (tasks to finish first)
+//   - Blocks: IDs from "#blocks:" tags (tasks waiting on this one)
+//   - Indent: Leading whitespace width of the checkbox line
+//   - Line: Index of the checkbox line
+//   - TextEnd: Index after the last line of the description (Text)
+//   - End: Index after the last line of the block
+//   - Subtasks: Nested checkbox items, in file order
+type Task struct {
+	ID        string
+	Content   string
+	Text      string
+	Title     string
+	Done      bool
+	Skipped   bool
+	Phase     string
+	Section   string
+	Labels    []string
+	Tags      map[string]string
+	Added     time.Time
+	Started   time.Time
+	Completed time.Time
+	Depends   []string
+	Blocks    []string
+	Indent    int
+	Line      int
+	TextEnd   int
+	End       int
+	Subtasks  []*Task
+}
+
+// Pending reports whether the task still needs doing.
+//
+// Returns:
+//   - bool: True unless the task is done or skipped
+func (t *Task) Pending() bool {
+	return !t.Done && !t.Skipped
+}
+
+// Status returns the task's status as reported by "ctx tasks list".
+//
+// Returns:
+//   - string: One of the config.TaskStatus* values
+func (t *Task) Status() string {
+	switch {
+	case t.Done:
+		return config.TaskStatusDone
+	case t.Skipped:
+		return config.TaskStatusSkipped
+	case t.HasLabel(config.TaskLabelInProgress):
+		return config.TaskStatusInProgress
+	default:
+		return config.TaskStatusPending
+	}
+}
+
+// HasLabel reports whether the task carries a bare label.
+//
+// Parameters:
+//   - label: Label without "#" (e.g., "in-progress")
+//
+// Returns:
+//   - bool: True if the label is present
+func (t *Task) HasLabel(label string) bool {
+	for _, l := range t.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPending reports whether any subtask, at any depth, is pending.
+//
+// Returns:
+//   - bool: True if completing the task would orphan pending work
+func (t *Task) HasPending() bool {
+	for _, sub := range t.Subtasks {
+		if sub.Pending() || sub.HasPending() {
+			return true
+		}
+	}
+	return false
+}
+
+// All flattens tasks and their subtasks into file order.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//
+// Returns:
+//   - []*Task: Every task, parents before their subtasks
+func All(tasks []*Task) []*Task {
+	var all []*Task
+	for _, t := range tasks {
+		all = append(all, t)
+		all = append(all, All(t.Subtasks)...)
+	}
+	return all
+}
+
+// PendingTasks returns the unchecked tasks, including subtasks, in file order.
+//
+// This is the numbering used by "ctx complete <n>". Skipped tasks are
+// not pending.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//
+// Returns:
+//   - []*Task: Unchecked tasks
+func PendingTasks(tasks []*Task) []*Task {
+	var pending []*Task
+	for _, t := range All(tasks) {
+		if t.Pending() {
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
+
+// Find returns the task with the given ID.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//   - id: Task ID, with or without a leading "#id:" or "#"
+//
+// Returns:
+//   - *Task: Matching task, or nil if no task has that ID
+func Find(tasks []*Task, id string) *Task {
+	id = NormalizeID(id)
+	if id == "" {
+		return nil
+	}
+	for _, t := range All(tasks) {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Candidate is a pending task with the facts Rank orders it by.
+//
+// Fields:
+//   - Task: The pending task
+//   - Priority: The task's "#priority:" value, or its phase heading's
+//     if the task has none; empty if neither is set
+//   - PhasePriority: True if Priority was inherited from the phase
+//   - Phase: 1-based position of the task's phase among the phases of
+//     TASKS.md; 0 for tasks above the first heading or in the Blocked
+//     section
+//   - Phases: Number of phases in TASKS.md
+//   - InProgress: True if the task is labeled "#in-progress"
+//   - Blocked: True if the task is labeled "#blocked" or sits in the
+//     Blocked section
+//   - Open: Dependencies that are not done yet, in file order
+//   - Unknown: Dependency IDs that name no task, in TASKS.md or the
+//     archive; they can never be done, so the task is not ready
+type Candidate struct {
+	Task          *Task
+	Priority      string
+	PhasePriority bool
+	Phase         int
+	Phases        int
+	InProgress    bool
+	Blocked       bool
+	Open          []*Task
+	Unknown       []string
+}
+
+// Ready reports whether work on the task can start.
+//
+// Returns:
+//   - bool: True if the task is not blocked and has no open or unknown
+//     dependencies
+func (c Candidate) Ready() bool {
+	return !c.Blocked && !c.waiting()
+}
+
+// waiting reports whether the candidate has unmet dependencies.
+//
+// Returns:
+//   - bool: True if a dependency is open or unknown
+func (c Candidate) waiting() bool {
+	return len(c.Open) > 0 || len(c.Unknown) > 0
+}
+
+// state returns the sort position of the candidate's readiness.
+//
+// Returns:
+//   - int: 0 if ready, 1 if waiting on dependencies, 2 if blocked
+func (c Candidate) state() int {
+	switch {
+	case c.Blocked:
+		return 2
+	case c.waiting():
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Reasons explains the candidate's rank, one fact per criterion.
+//
+// Returns:
+//   - []string: Human-readable reasons, in ranking order
+func (c Candidate) Reasons() []string {
+	var reasons []string
+
+	switch {
+	case c.Blocked && c.Task.HasLabel(config.TaskLabelBlocked):
+		reasons = append(reasons, "blocked (#"+config.TaskLabelBlocked+")")
+	case c.Blocked:
+		reasons = append(reasons, "blocked (in "+c.Task.Section+" section)")
+	case c.waiting():
+		var ids []string
+		for _, t := range c.Open {
+			ids = append(ids, Key(t))
+		}
+		for _, id := range c.Unknown {
+			ids = append(ids, id+" (unknown ID)")
+		}
+		reasons = append(reasons, "waiting on "+strings.Join(ids, ", "))
+	default:
+		reasons = append(reasons, "ready: not blocked, dependencies done")
+	}
+
+	switch {
+	case c.Priority == "":
+		reasons = append(reasons, "no priority")
+	case c.PhasePriority:
+		reasons = append(reasons, "priority "+c.Priority+" (from phase)")
+	default:
+		reasons = append(reasons, "priority "+c.Priority)
+	}
+
+	if c.InProgress {
+		reasons = append(reasons, "in progress")
+	}
+
+	if c.Phase > 0 {
+		reasons = append(reasons, fmt.Sprintf(
+			"phase %d of %d: %s", c.Phase, c.Phases, StripTags(c.Task.Phase),
+		))
+	}
+
+	if c.Task.Added.IsZero() {
+		reasons = append(reasons, "no #added date")
+	} else {
+		reasons = append(reasons, "added "+c.Task.Added.Format("2006-01-02"))
+	}
+
+	return reasons
+}
+
+// Rank orders the pending top-level tasks by what to work on next.
+//
+// The order is deterministic, so autonomous loops pick the same task
+// for the same file. Tasks are compared by, in order:
+//  1. Ready, then waiting on open or unknown dependencies, then blocked
+//  2. Priority: high, medium, low, then none
+//  3. In progress before not started (finishing beats starting)
+//  4. Phase order: earlier phases first
+//  5. Age: older "#added" first; undated tasks last
+//  6. Position in the file
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse
+//   - archived: IDs of archived tasks, which count as done
+//
+// Returns:
+//   - []Candidate: Pending tasks, best first
+func Rank(tasks []*Task, archived map[string]bool) []Candidate {
+	g := NewGraph(tasks, archived)
+
+	// The Blocked section is a parking place, not a phase
+	inBlocked := func(t *Task) bool {
+		return strings.HasPrefix("## "+t.Section, config.HeadingBlocked)
+	}
+	var phases []string
+	for _, t := range tasks {
+		if t.Phase != "" && !inBlocked(t) && !slices.Contains(phases, t.Phase) {
+			phases = append(phases, t.Phase)
+		}
+	}
+
+	var candidates []Candidate
+	for _, t := range tasks {
+		if !t.Pending() {
+			continue
+		}
+		c := Candidate{
+			Task:       t,
+			Priority:   t.Tags[config.TaskTagPriority],
+			Phase:      slices.Index(phases, t.Phase) + 1,
+			Phases:     len(phases),
+			InProgress: t.HasLabel(config.TaskLabelInProgress),
+			Blocked:    t.HasLabel(config.TaskLabelBlocked) || inBlocked(t),
+			Open:       g.Open(t),
+			Unknown:    g.Unknown(t),
+		}
+		if c.Priority == "" {
+			c.Priority = phaseTag(t.Phase, config.TaskTagPriority)
+			c.PhasePriority = c.Priority != ""
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if sa, sb := a.state(), b.state(); sa != sb {
+			return sa < sb
+		}
+		if pa, pb := priorityRank(a.Priority), priorityRank(b.Priority); pa != pb {
+			return pa < pb
+		}
+		if a.InProgress != b.InProgress {
+			return a.InProgress
+		}
+		if a.Phase != b.Phase {
+			return a.Phase < b.Phase
+		}
+		if !a.Task.Added.Equal(b.Task.Added) {
+			if a.Task.Added.IsZero() || b.Task.Added.IsZero() {
+				return b.Task.Added.IsZero()
+			}
+			return a.Task.Added.Before(b.Task.Added)
+		}
+		return a.Task.Line < b.Task.Line
+	})
+
+	return candidates
+}
+
+// priorityRank returns the sort position of a priority level.
+//
+// Parameters:
+//   - priority: Priority value (e.g., "high")
+//
+// Returns:
+//   - int: Index in config.TaskPriorities; unknown or empty values
+//     sort after every known level
+func priorityRank(priority string) int {
+	if i := slices.Index(config.TaskPriorities, strings.ToLower(priority)); i >= 0 {
+		return i
+	}
+	return len(config.TaskPriorities)
+}
+
+// phaseTag reads a key-value tag from a phase heading, such as
+// "Phase 1: Auth `#priority:high`".
+//
+// Parameters:
+//   - phase: Heading text
+//   - key: Tag key
+//
+// Returns:
+//   - string: Tag value; empty if the heading has no such tag
+func phaseTag(phase, key string) string {
+	for _, m := range config.RegExTaskTag.FindAllStringSubmatch(phase, -1) {
+		if m[1] == key {
+			return m[2]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const rankTasks = `# Tasks
+
+### Phase 1: Setup ` + "`#priority:medium`" + `
+- [x] Repo #id:aaaa
+- [ ] CI pipeline #id:bbbb #added:2026-01-10-100000
+- [ ] Lint config #id:cccc #in-progress
+- [ ] Docs site #id:hhhh
+
+### Phase 2: Features
+- [ ] Login #id:dddd #priority:high #depends:eeee
+- [ ] Auth service #id:eeee #priority:low
+- [ ] Reports #id:ffff #blocked
+- [ ] Search #id:iiii #added:2026-01-05-100000
+
+## Blocked
+- [ ] Payment #id:gggg #priority:high
+`
+
+func TestRank(t *testing.T) {
+	ranked := Rank(Parse(strings.Split(rankTasks, "\n")), nil)
+
+	var got []string
+	for _, c := range ranked {
+		got = append(got, c.Task.ID)
+	}
+	// Ready tasks first (priority, in progress, phase, age, line), then
+	// tasks waiting on dependencies, then blocked tasks.
+	want := []string{
+		"cccc", "bbbb", "hhhh", "eeee", "iiii", "dddd", "gggg", "ffff",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Rank() = %v, want %v", got, want)
+	}
+
+	top := ranked[0]
+	if !top.Ready() || top.Priority != "medium" || !top.PhasePriority ||
+		!top.InProgress || top.Phase != 1 || top.Phases != 2 {
+		t.Errorf("top candidate = %+v", top)
+	}
+	if reasons := strings.Join(top.Reasons(), "|"); reasons !=
+		"ready: not blocked, dependencies done|priority medium (from phase)|"+
+			"in progress|phase 1 of 2: Phase 1: Setup|no #added date" {
+		t.Errorf("Reasons() = %q", reasons)
+	}
+
+	login := ranked[5]
+	if login.Ready() || len(login.Open) != 1 || login.Open[0].ID != "eeee" {
+		t.Errorf("login = %+v", login)
+	}
+	if login.Reasons()[0] != "waiting on eeee" {
+		t.Errorf("login reasons = %v", login.Reasons())
+	}
+	if payment := ranked[6]; !payment.Blocked || payment.Phase != 0 {
+		t.Errorf("payment = %+v", payment)
+	}
+}
+
+func TestRank_Deterministic(t *testing.T) {
+	lines := strings.Split(rankTasks, "\n")
+	first := Rank(Parse(lines), nil)
+	for i := 0; i < 10; i++ {
+		again := Rank(Parse(lines), nil)
+		for j := range first {
+			if first[j].Task.ID != again[j].Task.ID {
+				t.Fatalf("run %d: rank %d = %s, want %s",
+					i, j, again[j].Task.ID, first[j].Task.ID)
+			}
+		}
+	}
+}
+
+func TestRank_UnknownDependency(t *testing.T) {
+	lines := strings.Split(`# Tasks
+
+- [ ] Deploy #id:aaaa #depends:zzzz
+- [ ] Release #id:bbbb #depends:yyyy
+- [ ] Docs #id:cccc #priority:low
+`, "\n")
+	ranked := Rank(Parse(lines), map[string]bool{"yyyy": true})
+
+	var got []string
+	for _, c := range ranked {
+		got = append(got, c.Task.ID)
+	}
+	if want := []string{"cccc", "bbbb", "aaaa"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Rank() = %v, want %v", got, want)
+	}
+
+	deploy := ranked[2]
+	if deploy.Ready() || !reflect.DeepEqual(deploy.Unknown, []string{"zzzz"}) {
+		t.Errorf("deploy = %+v, want not ready with unknown zzzz", deploy)
+	}
+	if reason := deploy.Reasons()[0]; reason != "waiting on zzzz (unknown ID)" {
+		t.Errorf("deploy reason = %q", reason)
+	}
+	if !ranked[1].Ready() {
+		t.Errorf("an archived dependency should count as done: %+v", ranked[1])
+	}
+}
@@ -0,0 +1,273 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Parse reads the tasks in TASKS.md lines.
+//
+// Every checkbox item that is not nested in another task's block is a
+// top-level task; nested checkbox items become its Subtasks. Lines
+// inside HTML comments are skipped, so format guides do not produce
+// tasks.
+//
+// Parameters:
+//   - lines: TASKS.md content split on newlines
+//
+// Returns:
+//   - []*Task: Top-level tasks in file order
+func Parse(lines []string) []*Task {
+	var tasks []*Task
+	var phase, section string
+	inComment := false
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if inComment {
+			inComment = !strings.Contains(trimmed, config.CommentClose)
+			i++
+			continue
+		}
+		if strings.HasPrefix(trimmed, config.CommentOpen) {
+			inComment = !strings.Contains(trimmed, config.CommentClose)
+			i++
+			continue
+		}
+
+		if level, text := heading(line); level >= 2 {
+			phase = text
+			if level == 2 {
+				section = text
+			}
+			i++
+			continue
+		}
+
+		if config.RegExTask.MatchString(line) {
+			t := parseTask(lines, i, len(lines), phase, section)
+			tasks = append(tasks, t)
+			i = t.End
+			continue
+		}
+		i++
+	}
+
+	return tasks
+}
+
+// ParseTime reads a task timestamp tag value.
+//
+// Both "YYYY-MM-DD-HHMMSS" (written by ctx) and "YYYY-MM-DD" (common in
+// hand-edited files) are accepted, in local time.
+//
+// Parameters:
+//   - value: Tag value (e.g., "2026-01-15-120000")
+//
+// Returns:
+//   - time.Time: Parsed time; zero if the value is not a timestamp
+func ParseTime(value string) time.Time {
+	for _, layout := range []string{"2006-01-02-150405", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseTask parses the task whose checkbox is at lines[start].
+//
+// Parameters:
+//   - lines: All lines
+//   - start: Index of the checkbox line
+//   - limit: Index the block may not extend past (the parent's End)
+//   - phase: Heading the task sits under
+//   - section: Level-2 heading the task sits under
+//
+// Returns:
+//   - *Task: Parsed task with subtasks
+func parseTask(lines []string, start, limit int, phase, section string) *Task {
+	match := config.RegExTask.FindStringSubmatch(lines[start])
+	t := &Task{
+		Content: strings.TrimSpace(Content(match)),
+		Done:    Completed(match),
+		Skipped: Skipped(match),
+		Phase:   phase,
+		Section: section,
+		Indent:  len(Indent(match)),
+		Line:    start,
+		TextEnd: start + 1,
+		End:     blockEnd(lines, start, limit),
+		Tags:    make(map[string]string),
+	}
+
+	// The first paragraph is the description; any later lines of the
+	// task's own (metadata, notes) only contribute tags.
+	text := []string{t.Content}
+	own := []string{t.Content}
+	paragraph := true
+	for j := start + 1; j < t.End; {
+		line := lines[j]
+		if config.RegExTask.MatchString(line) {
+			sub := parseTask(lines, j, t.End, phase, section)
+			t.Subtasks = append(t.Subtasks, sub)
+			paragraph = false
+			j = sub.End
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "- ") ||
+			strings.HasPrefix(trimmed, "* ") {
+			paragraph = false
+		}
+		if paragraph {
+			text = append(text, trimmed)
+			t.TextEnd = j + 1
+		}
+		own = append(own, trimmed)
+		j++
+	}
+
+	t.Text = strings.Join(text, " ")
+	t.Title = StripTags(t.Text)
+	applyTags(t, strings.Join(own, " "))
+
+	return t
+}
+
+// applyTags fills a task's labels, tags, ID, timestamps and dependencies.
+//
+// Parameters:
+//   - t: Task to update
+//   - text: All of the task's own text (not its subtasks')
+func applyTags(t *Task, text string) {
+	for _, m := range config.RegExTaskTag.FindAllStringSubmatch(text, -1) {
+		key, value := m[1], strings.TrimRight(m[2], ".,;)")
+		switch {
+		case value == "":
+			if !t.HasLabel(key) {
+				t.Labels = append(t.Labels, key)
+			}
+			continue
+		case key == config.TaskTagDepends:
+			t.Depends = appendIDs(t.Depends, value)
+		case key == config.TaskTagBlocks:
+			t.Blocks = appendIDs(t.Blocks, value)
+		}
+		t.Tags[key] = value
+	}
+	t.ID = NormalizeID(t.Tags[config.TaskTagID])
+	t.Added = ParseTime(t.Tags[config.TaskTagAdded])
+	t.Started = ParseTime(t.Tags[config.TaskTagStarted])
+	t.Completed = ParseTime(t.Tags[config.TaskTagDone])
+}
+
+// StripTags removes inline tags from text and collapses whitespace.
+//
+// Parameters:
+//   - text: Task or heading text
+//
+// Returns:
+//   - string: Text without tags
+func StripTags(text string) string {
+	return strings.Join(
+		strings.Fields(config.RegExTaskTag.ReplaceAllString(text, " ")), " ",
+	)
+}
+
+// appendIDs adds the comma-separated IDs of a tag value to a list.
+//
+// Parameters:
+//   - ids: IDs collected so far
+//   - value: Tag value (e.g., "k3f9,a1b2")
+//
+// Returns:
+//   - []string: ids plus any new, normalized IDs
+func appendIDs(ids []string, value string) []string {
+	for _, id := range strings.Split(value, ",") {
+		if id = NormalizeID(id); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// blockEnd finds the end of the block starting at lines[start].
+//
+// The block holds every following line indented deeper than the first.
+// Blank lines are included only when more indented content follows.
+//
+// Parameters:
+//   - lines: All lines
+//   - start: Index of the block's first line
+//   - limit: Index the block may not extend past
+//
+// Returns:
+//   - int: Index after the block's last line
+func blockEnd(lines []string, start, limit int) int {
+	base := indentWidth(lines[start])
+	end := start + 1
+
+	for i := start + 1; i < limit; i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			more := false
+			for j := i + 1; j < limit; j++ {
+				if strings.TrimSpace(lines[j]) == "" {
+					continue
+				}
+				more = indentWidth(lines[j]) > base
+				break
+			}
+			if !more {
+				break
+			}
+			end = i + 1
+			continue
+		}
+		if indentWidth(line) <= base {
+			break
+		}
+		end = i + 1
+	}
+
+	return end
+}
+
+// heading reads a Markdown ATX heading.
+//
+// Parameters:
+//   - line: Line to check
+//
+// Returns:
+//   - int: Heading level (1–6); 0 if the line is not a heading
+//   - string: Heading text without the "#" markers
+func heading(line string) (int, string) {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || len(line) == level || line[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(line[level:])
+}
+
+// indentWidth returns the number of leading whitespace characters.
+//
+// Parameters:
+//   - line: Line to measure
+//
+// Returns:
+//   - int: Leading space and tab count
+func indentWidth(line string) int {
+	return len(line) - len(strings.TrimLeft(line, config.Whitespace))
+}
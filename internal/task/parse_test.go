@@ -0,0 +1,218 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleTasks = `# Tasks
+
+<!--
+- [ ] Example inside a format guide
+-->
+
+### Phase 1: Auth
+
+- [ ] Implement login #priority:high #added:2026-01-15-120000 #id:k3f9
+  - [x] Add form #id:a1b2
+  - [ ] Validate password
+- [x] Write design doc
+      covering token refresh. #done:2026-01-20 #in-progress
+  - Note: reviewed by #security
+
+## Completed
+
+- [x] Set up repo #id:z9y8
+`
+
+func TestParse(t *testing.T) {
+	tasks := Parse(strings.Split(sampleTasks, "\n"))
+
+	if len(tasks) != 3 {
+		t.Fatalf("got %d top-level tasks, want 3", len(tasks))
+	}
+
+	login := tasks[0]
+	if login.ID != "k3f9" || login.Done {
+		t.Errorf("login: ID=%q Done=%v", login.ID, login.Done)
+	}
+	if login.Title != "Implement login" {
+		t.Errorf("login title = %q", login.Title)
+	}
+	if login.Phase != "Phase 1: Auth" || login.Section != "" {
+		t.Errorf("login phase=%q section=%q", login.Phase, login.Section)
+	}
+	if login.Tags["priority"] != "high" {
+		t.Errorf("login priority = %q", login.Tags["priority"])
+	}
+	want := time.Date(2026, 1, 15, 12, 0, 0, 0, time.Local)
+	if !login.Added.Equal(want) {
+		t.Errorf("login added = %v, want %v", login.Added, want)
+	}
+	if len(login.Subtasks) != 2 || login.Subtasks[0].ID != "a1b2" {
+		t.Fatalf("login subtasks = %+v", login.Subtasks)
+	}
+	if !login.HasPending() {
+		t.Error("login has a pending subtask")
+	}
+	if login.Line != 8 || login.End != 11 {
+		t.Errorf("login block = [%d, %d), want [8, 11)", login.Line, login.End)
+	}
+
+	doc := tasks[1]
+	if doc.Text != "Write design doc covering token refresh. #done:2026-01-20 #in-progress" {
+		t.Errorf("doc text = %q", doc.Text)
+	}
+	if doc.Title != "Write design doc covering token refresh." {
+		t.Errorf("doc title = %q", doc.Title)
+	}
+	if !doc.HasLabel("in-progress") || !doc.HasLabel("security") {
+		t.Errorf("doc labels = %v", doc.Labels)
+	}
+	if doc.Completed.IsZero() || doc.Completed.Day() != 20 {
+		t.Errorf("doc completed = %v", doc.Completed)
+	}
+
+	setup := tasks[2]
+	if setup.Section != "Completed" || setup.Phase != "Completed" {
+		t.Errorf("setup phase=%q section=%q", setup.Phase, setup.Section)
+	}
+
+	if got := len(All(tasks)); got != 5 {
+		t.Errorf("All() = %d tasks, want 5", got)
+	}
+	pending := PendingTasks(tasks)
+	if len(pending) != 2 || pending[1].Title != "Validate password" {
+		t.Errorf("PendingTasks() = %+v", pending)
+	}
+}
+
+func TestParse_BlankLinesInBlock(t *testing.T) {
+	lines := strings.Split(`- [x] Task
+  First paragraph
+
+  Second paragraph
+
+- [ ] Next`, "\n")
+
+	tasks := Parse(lines)
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	if tasks[0].End != 4 {
+		t.Errorf("End = %d, want 4 (trailing blank line excluded)", tasks[0].End)
+	}
+	if tasks[0].Text != "Task First paragraph" {
+		t.Errorf("Text = %q", tasks[0].Text)
+	}
+	if tasks[0].TextEnd != 2 {
+		t.Errorf("TextEnd = %d, want 2 (first paragraph only)", tasks[0].TextEnd)
+	}
+}
+
+func TestParse_BacktickTags(t *testing.T) {
+	tasks := Parse([]string{
+		"- [ ] Add CI pipeline `#in-progress` `#priority:high` see issue #2",
+	})
+	if len(tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(tasks))
+	}
+	tk := tasks[0]
+	if !tk.HasLabel("in-progress") || tk.Tags["priority"] != "high" {
+		t.Errorf("labels=%v tags=%v", tk.Labels, tk.Tags)
+	}
+	if tk.Title != "Add CI pipeline see issue #2" {
+		t.Errorf("title = %q", tk.Title)
+	}
+}
+
+func TestParse_Skipped(t *testing.T) {
+	tasks := Parse([]string{
+		"- [-] Old idea (superseded)",
+		"- [ ] Parent",
+		"  - [-] Dropped subtask",
+	})
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2", len(tasks))
+	}
+	if !tasks[0].Skipped || tasks[0].Done || tasks[0].Pending() {
+		t.Errorf("skipped task = %+v", tasks[0])
+	}
+	if tasks[0].Status() != "skipped" || tasks[1].Status() != "pending" {
+		t.Errorf("statuses = %q, %q", tasks[0].Status(), tasks[1].Status())
+	}
+	if tasks[1].HasPending() {
+		t.Error("a skipped subtask is not pending work")
+	}
+	if got := len(PendingTasks(tasks)); got != 1 {
+		t.Errorf("PendingTasks() = %d tasks, want 1", got)
+	}
+}
+
+func TestFind(t *testing.T) {
+	tasks := Parse(strings.Split(sampleTasks, "\n"))
+
+	for _, id := range []string{"a1b2", "#a1b2", "#id:A1B2"} {
+		if got := Find(tasks, id); got == nil || got.Title != "Add form" {
+			t.Errorf("Find(%q) = %+v", id, got)
+		}
+	}
+	if got := Find(tasks, "nope"); got != nil {
+		t.Errorf("Find(nope) = %+v, want nil", got)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantZero bool
+		wantYear int
+	}{
+		{name: "empty", value: "", wantZero: true},
+		{name: "full timestamp", value: "2026-01-20-143000", wantYear: 2026},
+		{name: "date only", value: "2025-12-25", wantYear: 2025},
+		{name: "invalid", value: "yesterday", wantZero: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTime(tt.value)
+			if got.IsZero() != tt.wantZero {
+				t.Fatalf("ParseTime(%q) = %v", tt.value, got)
+			}
+			if !tt.wantZero && got.Year() != tt.wantYear {
+				t.Errorf("year = %d, want %d", got.Year(), tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestIndentWidth(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected int
+	}{
+		{"no indent", 0},
+		{" one space", 1},
+		{"  two spaces", 2},
+		{"\ttab", 1},
+		{"    four spaces", 4},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			if got := indentWidth(tt.line); got != tt.expected {
+				t.Errorf("indentWidth(%q) = %d, want %d", tt.line, got, tt.expected)
+			}
+		})
+	}
+}
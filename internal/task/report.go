@@ -0,0 +1,195 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ActiveMemory/ctx/internal/config"
+)
+
+// Durations summarizes a set of time spans.
+//
+// Fields:
+//   - Count: Number of spans measured
+//   - Median: Middle span (the mean of the two middle spans for an even
+//     count); zero if Count is 0
+//   - Mean: Average span; zero if Count is 0
+//   - Max: Longest span; zero if Count is 0
+type Durations struct {
+	Count  int
+	Median time.Duration
+	Mean   time.Duration
+	Max    time.Duration
+}
+
+// Week is the number of tasks completed in one calendar week.
+//
+// Fields:
+//   - Start: Monday 00:00 of the week, in local time
+//   - Done: Tasks completed during the week
+type Week struct {
+	Start time.Time
+	Done  int
+}
+
+// Report holds task flow metrics for a period.
+//
+// Fields:
+//   - Since: Start of the period
+//   - Until: End of the period (the time of the report)
+//   - Completed: Tasks with a "#done:" time in the period, oldest first
+//   - LeadTime: Time from "#added:" to "#done:" of completed tasks that
+//     have both
+//   - CycleTime: Time from "#started:" to "#done:" of completed tasks
+//     that have both
+//   - Throughput: Completed tasks per week, every week of the period
+//     included
+//   - Stale: Pending "#in-progress" tasks (not "#blocked") started longer
+//     ago than the stale threshold, longest-running first
+type Report struct {
+	Since      time.Time
+	Until      time.Time
+	Completed  []*Task
+	LeadTime   Durations
+	CycleTime  Durations
+	Throughput []Week
+	Stale      []*Task
+}
+
+// NewReport computes flow metrics from tasks.
+//
+// Pass the tasks of TASKS.md and of the archive together: completed
+// tasks are usually archived before the report is run. A task found
+// twice under the same ID is counted once, the first occurrence wins.
+//
+// Parameters:
+//   - tasks: Top-level tasks from Parse and ParseArchive
+//   - since: Start of the period
+//   - now: End of the period; also the reference for staleness
+//   - staleAfter: How long a task may be in progress before it is stale
+//
+// Returns:
+//   - Report: Metrics for the period
+func NewReport(
+	tasks []*Task, since, now time.Time, staleAfter time.Duration,
+) Report {
+	r := Report{Since: since, Until: now}
+
+	var lead, cycle []time.Duration
+	seen := make(map[string]bool)
+	for _, t := range All(tasks) {
+		if t.ID != "" {
+			if seen[t.ID] {
+				continue
+			}
+			seen[t.ID] = true
+		}
+
+		if t.Pending() {
+			// Blocked tasks are waiting, not being worked on
+			if t.HasLabel(config.TaskLabelInProgress) &&
+				!t.HasLabel(config.TaskLabelBlocked) &&
+				!t.Started.IsZero() && now.Sub(t.Started) > staleAfter {
+				r.Stale = append(r.Stale, t)
+			}
+			continue
+		}
+
+		if !t.Done || t.Completed.Before(since) || t.Completed.After(now) {
+			continue
+		}
+		r.Completed = append(r.Completed, t)
+		if !t.Added.IsZero() && !t.Completed.Before(t.Added) {
+			lead = append(lead, t.Completed.Sub(t.Added))
+		}
+		if !t.Started.IsZero() && !t.Completed.Before(t.Started) {
+			cycle = append(cycle, t.Completed.Sub(t.Started))
+		}
+	}
+
+	slices.SortStableFunc(r.Completed, func(a, b *Task) int {
+		return a.Completed.Compare(b.Completed)
+	})
+	slices.SortStableFunc(r.Stale, func(a, b *Task) int {
+		return a.Started.Compare(b.Started)
+	})
+
+	r.LeadTime = summarize(lead)
+	r.CycleTime = summarize(cycle)
+	r.Throughput = throughput(r.Completed, since, now)
+
+	return r
+}
+
+// summarize computes the statistics of a set of spans.
+//
+// Parameters:
+//   - spans: Spans to summarize, in any order
+//
+// Returns:
+//   - Durations: Count, median, mean and maximum
+func summarize(spans []time.Duration) Durations {
+	d := Durations{Count: len(spans)}
+	if len(spans) == 0 {
+		return d
+	}
+
+	sorted := slices.Clone(spans)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, s := range sorted {
+		total += s
+	}
+	mid := len(sorted) / 2
+	d.Median = sorted[mid]
+	if len(sorted)%2 == 0 {
+		d.Median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	d.Mean = total / time.Duration(len(sorted))
+	d.Max = sorted[len(sorted)-1]
+
+	return d
+}
+
+// throughput counts completed tasks per calendar week.
+//
+// Parameters:
+//   - completed: Tasks completed between since and now
+//   - since: Start of the period
+//   - now: End of the period
+//
+// Returns:
+//   - []Week: One entry per week touching the period, oldest first
+func throughput(completed []*Task, since, now time.Time) []Week {
+	var weeks []Week
+	for start := weekStart(since); !start.After(now); start = start.AddDate(0, 0, 7) {
+		weeks = append(weeks, Week{Start: start})
+	}
+	for _, t := range completed {
+		i := int(weekStart(t.Completed).Sub(weekStart(since)).Hours()+12) / (7 * 24)
+		if i >= 0 && i < len(weeks) {
+			weeks[i].Done++
+		}
+	}
+	return weeks
+}
+
+// weekStart returns the Monday 00:00 of the week containing t.
+//
+// Parameters:
+//   - t: Any time
+//
+// Returns:
+//   - time.Time: Start of t's week, in t's location
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,76 @@
+//   /    Context:                     https://ctx.ist
+// ,'`./    do you remember?
+// `.,'\
+//   \    Copyright 2026-present Context contributors.
+//                 SPDX-License-Identifier: Apache-2.0
+
+package task
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewReport(t *testing.T) {
+	tasks := Parse(strings.Split(`# Tasks
+
+- [x] A #id:aaaa #added:2026-03-02 #started:2026-03-03 #done:2026-03-04
+- [x] B #id:bbbb #added:2026-03-01 #done:2026-03-11
+- [x] Old #id:o1d0 #added:2026-01-01 #done:2026-01-02
+- [-] Dropped #id:drop #started:2026-03-01
+- [ ] Long running #id:long #in-progress #started:2026-03-02
+- [ ] Recent #id:rcnt #started:2026-03-12
+- [ ] Waiting #id:wait #blocked #started:2026-03-02
+- [ ] Hand-edited #id:both #in-progress #blocked #started:2026-03-02
+- [ ] Unlabeled #id:unlb #started:2026-03-02
+- [ ] Undated #id:undt #in-progress
+  - [x] Sub #id:sub1 #started:2026-03-09 #done:2026-03-10
+`, "\n"))
+	archived := Parse([]string{
+		"- [x] A again #id:aaaa #added:2026-02-01 #done:2026-03-04",
+	})
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	now := time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+	r := NewReport(append(tasks, archived...), since, now, 7*24*time.Hour)
+
+	var done []string
+	for _, c := range r.Completed {
+		done = append(done, c.ID)
+	}
+	if got := strings.Join(done, ","); got != "aaaa,sub1,bbbb" {
+		t.Errorf("Completed = %s, want aaaa,sub1,bbbb", got)
+	}
+
+	day := 24 * time.Hour
+	if want := (Durations{Count: 2, Median: 6 * day, Mean: 6 * day, Max: 10 * day}); r.LeadTime != want {
+		t.Errorf("LeadTime = %+v, want %+v", r.LeadTime, want)
+	}
+	if want := (Durations{Count: 2, Median: day, Mean: day, Max: day}); r.CycleTime != want {
+		t.Errorf("CycleTime = %+v, want %+v", r.CycleTime, want)
+	}
+
+	// 2026-03-01 is a Sunday: its week starts on 2026-02-23
+	var weeks []string
+	for _, w := range r.Throughput {
+		weeks = append(weeks, w.Start.Format("01-02")+":"+string(rune('0'+w.Done)))
+	}
+	if got := strings.Join(weeks, " "); got != "02-23:0 03-02:1 03-09:2" {
+		t.Errorf("Throughput = %s", got)
+	}
+
+	if len(r.Stale) != 1 || r.Stale[0].ID != "long" {
+		t.Errorf("Stale = %+v, want [long]", r.Stale)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	if d := summarize(nil); d != (Durations{}) {
+		t.Errorf("summarize(nil) = %+v", d)
+	}
+	d := summarize([]time.Duration{4, 1, 3, 8})
+	if want := (Durations{Count: 4, Median: 3, Mean: 4, Max: 8}); d != want {
+		t.Errorf("summarize() = %+v, want %+v", d, want)
+	}
+}
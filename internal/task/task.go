@@ -6,8 +6,10 @@
 
 // Package task provides task item parsing and matching.
 //
-// This package handles the domain logic for task items, independent of
-// their Markdown representation.
+// This package handles the domain logic for task items. Parse turns
+// TASKS.md into a typed model (Task) with stable IDs, phases, labels,
+// timestamps and nested subtasks; the Match helpers below work on
+// single-line RegExTask matches.
 package task
 
 import "github.com/ActiveMemory/ctx/internal/config"
@@ -25,7 +27,7 @@ import "github.com/ActiveMemory/ctx/internal/config"
 const (
 	MatchFull    = iota // Full match
 	MatchIndent         // Leading whitespace
-	MatchState          // "x" or " " or ""
+	MatchState          // "x", "-", " " or ""
 	MatchContent        // Task text
 )
 
@@ -43,6 +45,20 @@ func Completed(match []string) bool {
 	return match[MatchState] == config.MarkTaskComplete
 }
 
+// Skipped reports whether a match represents a skipped task.
+//
+// Parameters:
+//   - match: Result from ItemPattern.FindStringSubmatch
+//
+// Returns:
+//   - bool: True if the checkbox is marked skipped ([-])
+func Skipped(match []string) bool {
+	if len(match) <= MatchState {
+		return false
+	}
+	return match[MatchState] == config.MarkTaskSkipped
+}
+
 // Pending reports whether a match represents a pending task.
 //
 // Parameters:
@@ -54,7 +70,7 @@ func Pending(match []string) bool {
 	if len(match) <= MatchState {
 		return false
 	}
-	return match[MatchState] != config.MarkTaskComplete
+	return !Completed(match) && !Skipped(match)
 }
 
 // Indent returns the leading whitespace from a match.